@@ -61,6 +61,7 @@ var services = mapOf(
         "hsm" to "Hardware Security Module",
         "healthcare" to "Health Care",
         "hybridcompute" to "Hybrid Compute",
+        "imagebuilder" to "Image Builder",
         "iotcentral" to "IoT Central",
         "iothub" to "IoT Hub",
         "keyvault" to "KeyVault",
@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// ImportComparer compares the State of a resource prior to it being imported with the imported State,
+// reporting each attribute which differs, rather than the entire State of the resource.
+type ImportComparer struct {
+	// resourceName being the full resource name e.g. azurerm_foo.bar
+	resourceName string
+
+	// ignore is a list of the attributes (including any nested attributes) which shouldn't be compared
+	ignore []string
+
+	// expected is the State of the resource prior to it being imported
+	expected *terraform.InstanceState
+}
+
+// ImportedState returns an ImportComparer for the specified resource, which should be used for both the
+// `ImportStateIdFunc` and `ImportStateCheck` fields of an Import Test Step - see `TestData.ImportStepWithComparer`.
+func ImportedState(resourceName string, ignore ...string) *ImportComparer {
+	return &ImportComparer{
+		resourceName: resourceName,
+		ignore:       ignore,
+	}
+}
+
+// ImportStateIdFunc records the State of the resource prior to it being imported, returning its ID
+func (c *ImportComparer) ImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources[c.resourceName]
+	if !ok {
+		return "", fmt.Errorf("%q was not found in the state", c.resourceName)
+	}
+	if rs.Primary == nil {
+		return "", fmt.Errorf("%q has no primary instance in the state", c.resourceName)
+	}
+
+	c.expected = rs.Primary
+	return rs.Primary.ID, nil
+}
+
+// ImportStateCheck compares the imported State with the State recorded prior to the import
+func (c *ImportComparer) ImportStateCheck(states []*terraform.InstanceState) error {
+	if c.expected == nil {
+		return fmt.Errorf("the State for %q wasn't recorded prior to import - ensure `ImportStateIdFunc` is set", c.resourceName)
+	}
+
+	var actual *terraform.InstanceState
+	for _, state := range states {
+		if state != nil && state.ID == c.expected.ID {
+			actual = state
+			break
+		}
+	}
+	if actual == nil {
+		return fmt.Errorf("an imported resource with the ID %q was not found for %q", c.expected.ID, c.resourceName)
+	}
+
+	differences := c.Compare(c.expected.Attributes, actual.Attributes)
+	if len(differences) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("the imported State for %q differs from the State prior to import:\n\n%s", c.resourceName, strings.Join(differences, "\n"))
+}
+
+// Compare returns a description of each attribute which differs between the expected and actual attributes
+func (c *ImportComparer) Compare(expected map[string]string, actual map[string]string) []string {
+	keys := make(map[string]struct{})
+	for k, v := range expected {
+		if c.shouldCompare(k, v) {
+			keys[k] = struct{}{}
+		}
+	}
+	for k, v := range actual {
+		if c.shouldCompare(k, v) {
+			keys[k] = struct{}{}
+		}
+	}
+
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	differences := make([]string, 0)
+	for _, k := range sortedKeys {
+		expectedValue, inExpected := expected[k]
+		actualValue, inActual := actual[k]
+
+		switch {
+		case inExpected && !inActual:
+			if expectedValue == "" {
+				continue
+			}
+			differences = append(differences, fmt.Sprintf("* %s: %q was missing after import", k, expectedValue))
+		case !inExpected && inActual:
+			if actualValue == "" {
+				continue
+			}
+			differences = append(differences, fmt.Sprintf("* %s: %q was only present after import", k, actualValue))
+		case expectedValue != actualValue:
+			differences = append(differences, fmt.Sprintf("* %s: expected %q but got %q", k, expectedValue, actualValue))
+		}
+	}
+
+	return differences
+}
+
+func (c *ImportComparer) shouldCompare(key string, value string) bool {
+	// the counts of empty lists/maps/sets are only sometimes present in the State
+	if (strings.HasSuffix(key, ".#") || strings.HasSuffix(key, ".%")) && value == "0" {
+		return false
+	}
+
+	// timeouts are only sometimes set in the State and aren't returned from the API
+	if key == "timeouts" || strings.HasPrefix(key, "timeouts.") {
+		return false
+	}
+
+	for _, ignore := range c.ignore {
+		if key == ignore || strings.HasPrefix(key, ignore+".") {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+func TestImportComparer_Compare(t *testing.T) {
+	expected := map[string]string{
+		"id":                 "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		"location":           "westeurope",
+		"tags.%":             "1",
+		"tags.environment":   "production",
+		"identity.#":         "0",
+		"timeouts.create":    "5m",
+		"password":           "secret",
+		"network_rules.#":    "1",
+		"network_rules.0.ip": "10.0.0.1",
+		"description":        "",
+		"password_hash":      "abc",
+		"ip_rules.#":         "1",
+		"ip_rules.0":         "10.0.0.1",
+	}
+	actual := map[string]string{
+		"id":                 "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		"location":           "westeurope",
+		"tags.%":             "1",
+		"tags.environment":   "staging",
+		"network_rules.#":    "1",
+		"network_rules.0.ip": "10.0.0.2",
+		"managed_by":         "example",
+		"password_hash":      "def",
+		"ip_rules.#":         "1",
+		"ip_rules.0":         "10.0.0.2",
+	}
+
+	// ignored attributes should match either exactly or as the parent of a nested attribute
+	comparer := check.ImportedState(resourceName, "password", "ip_rules")
+	differences := comparer.Compare(expected, actual)
+	expectedDifferences := []string{
+		`* managed_by: "example" was only present after import`,
+		`* network_rules.0.ip: expected "10.0.0.1" but got "10.0.0.2"`,
+		`* password_hash: expected "abc" but got "def"`,
+		`* tags.environment: expected "production" but got "staging"`,
+	}
+	if !reflect.DeepEqual(differences, expectedDifferences) {
+		t.Fatalf("expected the differences to be %+v but got %+v", expectedDifferences, differences)
+	}
+}
+
+func TestImportComparer_ImportStateCheck(t *testing.T) {
+	state := terraform.NewState()
+	state.RootModule().Resources[resourceName] = &terraform.ResourceState{
+		Type: "azurerm_resource_group",
+		Primary: &terraform.InstanceState{
+			ID: "example",
+			Attributes: map[string]string{
+				"id":       "example",
+				"location": "westeurope",
+			},
+		},
+	}
+
+	comparer := check.ImportedState(resourceName)
+	if err := comparer.ImportStateCheck(nil); err == nil {
+		t.Fatalf("expected an error when the State wasn't recorded prior to import")
+	}
+
+	id, err := comparer.ImportStateIdFunc(state)
+	if err != nil {
+		t.Fatalf("recording the state: %+v", err)
+	}
+	if id != "example" {
+		t.Fatalf("expected the ID to be %q but got %q", "example", id)
+	}
+
+	imported := []*terraform.InstanceState{
+		{
+			ID: "example",
+			Attributes: map[string]string{
+				"id":       "example",
+				"location": "westeurope",
+			},
+		},
+	}
+	if err := comparer.ImportStateCheck(imported); err != nil {
+		t.Fatalf("expected no differences but got: %+v", err)
+	}
+
+	imported[0].Attributes["location"] = "northeurope"
+	err = comparer.ImportStateCheck(imported)
+	if err == nil || !strings.Contains(err.Error(), `location: expected "westeurope" but got "northeurope"`) {
+		t.Fatalf("expected the difference to be reported but got: %+v", err)
+	}
+}
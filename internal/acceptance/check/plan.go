@@ -0,0 +1,143 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+type planType struct{}
+
+// Plan provides assertions against the Terraform Plan, which can be used within the `ConfigPlanChecks`
+// of a Test Step - for example:
+//
+//	ConfigPlanChecks: resource.ConfigPlanChecks{
+//		PreApply: []plancheck.PlanCheck{
+//			check.Plan.ExpectUpdateInPlace(data.ResourceName, "tags.env"),
+//		},
+//	},
+var Plan = planType{}
+
+// ExpectNoChanges returns a PlanCheck which validates that the Plan contains no changes, which can be
+// used to confirm that a subsequent apply (e.g. once the resource has been refreshed) would be a no-op
+func (planType) ExpectNoChanges() plancheck.PlanCheck {
+	return plancheck.ExpectEmptyPlan()
+}
+
+// ExpectCreate returns a PlanCheck which validates that the specified resource is going to be created
+func (planType) ExpectCreate(resourceName string) plancheck.PlanCheck {
+	return plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionCreate)
+}
+
+// ExpectReplace returns a PlanCheck which validates that the specified resource is going to be replaced
+// (that is, destroyed and re-created, in either order)
+func (planType) ExpectReplace(resourceName string) plancheck.PlanCheck {
+	return plancheck.ExpectResourceAction(resourceName, plancheck.ResourceActionReplace)
+}
+
+// ExpectUpdateInPlace returns a PlanCheck which validates that the specified resource is going to be updated
+// in-place - and, when specified, that each of the specified attributes (e.g. `tags.env` or `foo.0.bar`) is
+// going to change.
+func (planType) ExpectUpdateInPlace(resourceName string, attributes ...string) plancheck.PlanCheck {
+	return expectUpdateInPlace{
+		resourceName: resourceName,
+		attributes:   attributes,
+	}
+}
+
+// ExpectUnknownValue returns a PlanCheck which validates that the specified attribute (e.g. `foo.0.bar`)
+// is unknown until the resource has been applied
+func (planType) ExpectUnknownValue(resourceName string, attribute string) plancheck.PlanCheck {
+	return plancheck.ExpectUnknownValue(resourceName, attributePath(attribute))
+}
+
+var _ plancheck.PlanCheck = expectUpdateInPlace{}
+
+type expectUpdateInPlace struct {
+	resourceName string
+	attributes   []string
+}
+
+func (e expectUpdateInPlace) CheckPlan(_ context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	change := findResourceChange(req.Plan, e.resourceName)
+	if change == nil {
+		resp.Error = fmt.Errorf("%q was not found in the Plan", e.resourceName)
+		return
+	}
+
+	if !change.Actions.Update() {
+		resp.Error = fmt.Errorf("expected %q to be updated in-place but got the action(s) %v", e.resourceName, change.Actions)
+		return
+	}
+
+	unchanged := make([]string, 0)
+	for _, attribute := range e.attributes {
+		if !attributeChanges(change, attributePath(attribute)) {
+			unchanged = append(unchanged, attribute)
+		}
+	}
+	if len(unchanged) > 0 {
+		resp.Error = fmt.Errorf("expected the attribute(s) %s of %q to change but they were unchanged", strings.Join(unchanged, ", "), e.resourceName)
+	}
+}
+
+func findResourceChange(plan *tfjson.Plan, resourceName string) *tfjson.Change {
+	if plan == nil {
+		return nil
+	}
+
+	for _, rc := range plan.ResourceChanges {
+		if rc != nil && rc.Address == resourceName {
+			return rc.Change
+		}
+	}
+
+	return nil
+}
+
+// attributeChanges returns whether the value at the specified path is going to change, which includes
+// values which are unknown until apply
+func attributeChanges(change *tfjson.Change, path tfjsonpath.Path) bool {
+	if change == nil {
+		return false
+	}
+
+	if v, err := tfjsonpath.Traverse(change.AfterUnknown, path); err == nil {
+		if unknown, ok := v.(bool); ok && unknown {
+			return true
+		}
+	}
+
+	before, beforeErr := tfjsonpath.Traverse(change.Before, path)
+	after, afterErr := tfjsonpath.Traverse(change.After, path)
+	if beforeErr != nil || afterErr != nil {
+		// the value is being added or removed
+		return (beforeErr == nil) != (afterErr == nil)
+	}
+
+	return !reflect.DeepEqual(before, after)
+}
+
+// attributePath converts an attribute in the format used in the State (e.g. `foo.0.bar` or `tags.env`)
+// into the path used to traverse the JSON representation of the Plan
+func attributePath(attribute string) tfjsonpath.Path {
+	segments := strings.Split(attribute, ".")
+	path := tfjsonpath.New(segments[0])
+	for _, segment := range segments[1:] {
+		if index, err := strconv.Atoi(segment); err == nil {
+			path = path.AtSliceIndex(index)
+			continue
+		}
+		path = path.AtMapKey(segment)
+	}
+	return path
+}
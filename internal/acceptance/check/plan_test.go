@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package check_test
+
+import (
+	"context"
+	"testing"
+
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+const resourceName = "azurerm_resource_group.test"
+
+func planWithChange(actions tfjson.Actions, before, after, afterUnknown map[string]interface{}) *tfjson.Plan {
+	return &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{
+				Address: resourceName,
+				Change: &tfjson.Change{
+					Actions:      actions,
+					Before:       before,
+					After:        after,
+					AfterUnknown: afterUnknown,
+				},
+			},
+		},
+	}
+}
+
+func runPlanCheck(planCheck plancheck.PlanCheck, plan *tfjson.Plan) error {
+	resp := plancheck.CheckPlanResponse{}
+	planCheck.CheckPlan(context.TODO(), plancheck.CheckPlanRequest{Plan: plan}, &resp)
+	return resp.Error
+}
+
+func TestPlan_ExpectUpdateInPlace(t *testing.T) {
+	before := map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "production",
+			"cost_center": "MSFT",
+		},
+		"block": []interface{}{
+			map[string]interface{}{
+				"value": "first",
+			},
+		},
+	}
+	after := map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+		"tags": map[string]interface{}{
+			"environment": "staging",
+		},
+		"block": []interface{}{
+			map[string]interface{}{
+				"value": "second",
+			},
+		},
+	}
+	afterUnknown := map[string]interface{}{
+		"computed": true,
+	}
+
+	testData := []struct {
+		name       string
+		actions    tfjson.Actions
+		attributes []string
+		expectErr  bool
+	}{
+		{
+			name:    "update",
+			actions: tfjson.Actions{tfjson.ActionUpdate},
+		},
+		{
+			name:       "changed attributes",
+			actions:    tfjson.Actions{tfjson.ActionUpdate},
+			attributes: []string{"tags.environment", "tags.cost_center", "block.0.value", "computed"},
+		},
+		{
+			name:       "unchanged attribute",
+			actions:    tfjson.Actions{tfjson.ActionUpdate},
+			attributes: []string{"tags.environment", "location"},
+			expectErr:  true,
+		},
+		{
+			name:      "replace",
+			actions:   tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate},
+			expectErr: true,
+		},
+		{
+			name:      "no-op",
+			actions:   tfjson.Actions{tfjson.ActionNoop},
+			expectErr: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := runPlanCheck(check.Plan.ExpectUpdateInPlace(resourceName, v.attributes...), planWithChange(v.actions, before, after, afterUnknown))
+		if v.expectErr && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !v.expectErr && err != nil {
+			t.Fatalf("expected no error but got: %+v", err)
+		}
+	}
+}
+
+func TestPlan_ExpectReplace(t *testing.T) {
+	if err := runPlanCheck(check.Plan.ExpectReplace(resourceName), planWithChange(tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}, nil, nil, nil)); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	if err := runPlanCheck(check.Plan.ExpectReplace(resourceName), planWithChange(tfjson.Actions{tfjson.ActionUpdate}, nil, nil, nil)); err == nil {
+		t.Fatalf("expected an error for an in-place update but didn't get one")
+	}
+
+	if err := runPlanCheck(check.Plan.ExpectReplace("azurerm_resource_group.other"), planWithChange(tfjson.Actions{tfjson.ActionDelete, tfjson.ActionCreate}, nil, nil, nil)); err == nil {
+		t.Fatalf("expected an error for a resource not in the plan but didn't get one")
+	}
+}
+
+func TestPlan_ExpectUnknownValue(t *testing.T) {
+	afterUnknown := map[string]interface{}{
+		"id": true,
+		"block": []interface{}{
+			map[string]interface{}{
+				"value": true,
+				"other": false,
+			},
+		},
+	}
+	plan := planWithChange(tfjson.Actions{tfjson.ActionCreate}, nil, map[string]interface{}{}, afterUnknown)
+
+	if err := runPlanCheck(check.Plan.ExpectUnknownValue(resourceName, "block.0.value"), plan); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	if err := runPlanCheck(check.Plan.ExpectUnknownValue(resourceName, "block.0.other"), plan); err == nil {
+		t.Fatalf("expected an error for a known value but didn't get one")
+	}
+}
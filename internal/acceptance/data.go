@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 )
 
@@ -55,6 +56,10 @@ type TestData struct {
 
 	// resourceLabel is the local used for the resource - generally "test""
 	resourceLabel string
+
+	// mockClient is the client used to connect to the Mock ARM Server, when this Test is configured
+	// to run against it using UseMockServer
+	mockClient *clients.Client
 }
 
 // BuildTestData generates some test data for the given resource
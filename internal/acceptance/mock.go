@@ -0,0 +1,99 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package acceptance
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+)
+
+// UseMockServer configures this Test to run against the specified Mock ARM Server rather than Azure,
+// which allows the CRUD logic of a Resource (including the expand/flatten functions and error paths)
+// to be tested without a Subscription.
+//
+// Since the Mock ARM Server only implements the generic Resource Manager semantics, this is intended
+// to be opted into on a per-test basis for Services whose APIs follow these - and whilst a Terraform
+// binary is still required, no credentials or `TF_ACC` environment variable are needed.
+//
+// Since the checks within the Test use the shared test client, which is overridden for the duration of
+// the Test, Tests using the Mock ARM Server can't be run in parallel - calling `t.Parallel()` after this
+// (or calling this after `t.Parallel()`) panics.
+func (td *TestData) UseMockServer(t *testing.T, server *mockarm.Server) {
+	// since these tests run as unit tests, skip rather than downloading Terraform when it's unavailable - other
+	// than in CI, where a Terraform binary is provided so that these tests can't be silently skipped
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			if os.Getenv("GITHUB_ACTIONS_STAGE") == "UNIT_TESTS" {
+				t.Fatal("a Terraform binary couldn't be found - one must be specified using `TF_ACC_TERRAFORM_PATH` when running the Unit Tests in CI")
+			}
+			t.Skip("Skipping since a Terraform binary couldn't be found - specify one using `TF_ACC_TERRAFORM_PATH`")
+			return
+		}
+	}
+
+	// `t.Setenv` can't be used alongside `t.Parallel`, which ensures that Tests using the Mock ARM Server
+	// (and so overriding the shared test client) are never run in parallel
+	t.Setenv("ARM_SUBSCRIPTION_ID", mockarm.SubscriptionId)
+
+	client, err := server.Client(context.Background(), features.Default())
+	if err != nil {
+		t.Fatalf("building client for the Mock ARM Server: %+v", err)
+	}
+
+	td.mockClient = client
+	td.Subscriptions = Subscriptions{
+		Primary:   mockarm.SubscriptionId,
+		Secondary: mockarm.SubscriptionId,
+	}
+	if td.Locations.Primary == "" {
+		td.Locations = Regions{
+			Primary:   "westeurope",
+			Secondary: "northeurope",
+			Ternary:   "eastus2",
+		}
+	}
+
+	// the checks within the Test (e.g. `ExistsInAzure`) use the shared test client
+	t.Cleanup(testclient.Override(client))
+}
+
+func (td TestData) runMockTest(t *testing.T, testCase resource.TestCase) {
+	// the Provider is configured using the client for the Mock ARM Server, so there's no need to check
+	// for credentials - and since External Providers would need to be downloaded, these aren't available
+	testCase.PreCheck = nil
+	testCase.IsUnitTest = true
+	testCase.ProviderFactories = map[string]func() (*schema.Provider, error){
+		"azurerm": td.mockProvider,
+	}
+
+	resource.Test(t, testCase)
+}
+
+func (td TestData) mockProvider() (*schema.Provider, error) { //nolint:unparam
+	azurerm := provider.TestAzureProvider()
+	azurerm.ConfigureContextFunc = func(ctx context.Context, _ *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
+		stopCtx, ok := schema.StopContext(ctx) //nolint:staticcheck
+		if !ok {
+			stopCtx = ctx
+		}
+		// the shared test client is used by the checks once Terraform has stopped the Provider (cancelling the
+		// Stop Context), so the Provider is given its own copy of the client
+		client := *td.mockClient
+		client.StopContext = stopCtx
+
+		return &client, nil
+	}
+	return azurerm, nil
+}
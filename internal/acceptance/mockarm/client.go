@@ -0,0 +1,100 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	authWrapper "github.com/hashicorp/go-azure-sdk/sdk/auth/autorest"
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"golang.org/x/oauth2"
+)
+
+var _ auth.Authorizer = authorizer{}
+
+// authorizer is an auth.Authorizer which returns a static token, since the Server doesn't authenticate requests
+type authorizer struct{}
+
+func (authorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{
+		AccessToken: "mockarm",
+		TokenType:   "Bearer",
+		Expiry:      time.Now().Add(time.Hour),
+	}, nil
+}
+
+func (authorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return []*oauth2.Token{}, nil
+}
+
+// Environment returns the Azure Environment exposed by the Server, which is Azure Public
+// with the Resource Manager endpoint pointing to the Server.
+func (s *Server) Environment() environments.Environment {
+	env := environments.AzurePublic()
+	env.Name = "MockARM"
+	env.ResourceManager = environments.ResourceManagerAPI(s.URL)
+	return *env
+}
+
+// ClientOptions returns the options used to build clients which connect to the Server
+func (s *Server) ClientOptions(userFeatures features.UserFeatures) *common.ClientOptions {
+	a := authorizer{}
+	return &common.ClientOptions{
+		Authorizers: &common.Authorizers{
+			BatchManagement: a,
+			KeyVault:        a,
+			ManagedHSM:      a,
+			ResourceManager: a,
+			Storage:         a,
+			Synapse:         a,
+			AuthorizerFunc: func(api environments.Api) (auth.Authorizer, error) {
+				return a, nil
+			},
+		},
+
+		Environment: s.Environment(),
+		Features:    userFeatures,
+
+		SubscriptionId:   SubscriptionId,
+		TenantId:         TenantId,
+		TerraformVersion: "0.0.0",
+
+		DisableTerraformPartnerID: true,
+		SkipProviderReg:           true,
+
+		ResourceManagerEndpoint: s.URL,
+
+		BatchManagementAuthorizer: authWrapper.AutorestAuthorizer(a),
+		KeyVaultAuthorizer:        authWrapper.AutorestAuthorizer(a).BearerAuthorizerCallback(),
+		ManagedHSMAuthorizer:      authWrapper.AutorestAuthorizer(a).BearerAuthorizerCallback(),
+		ResourceManagerAuthorizer: authWrapper.AutorestAuthorizer(a),
+		SynapseAuthorizer:         authWrapper.AutorestAuthorizer(a),
+	}
+}
+
+// Client builds a Provider client which connects to the Server, using the specified features
+func (s *Server) Client(ctx context.Context, userFeatures features.UserFeatures) (*clients.Client, error) {
+	client := clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			Environment:                      s.Environment(),
+			ClientId:                         "22222222-2222-2222-2222-222222222222",
+			ObjectId:                         "33333333-3333-3333-3333-333333333333",
+			SubscriptionId:                   SubscriptionId,
+			TenantId:                         TenantId,
+			AuthenticatedAsAServicePrincipal: true,
+			SkipResourceProviderRegistration: true,
+		},
+	}
+	if err := client.Build(ctx, s.ClientOptions(userFeatures)); err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
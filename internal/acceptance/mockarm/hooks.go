@@ -0,0 +1,137 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// Hook is called for each request received by the Server before it's handled, and can return a
+// Response which is used instead of the default behaviour - or nil to continue as normal.
+type Hook func(r *http.Request) *Response
+
+// Response is a response returned from a Hook
+type Response struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       interface{}
+}
+
+func (r Response) write(w http.ResponseWriter) {
+	for k, v := range r.Headers {
+		w.Header().Set(k, v)
+	}
+
+	if r.Body == nil {
+		w.WriteHeader(r.StatusCode)
+		return
+	}
+
+	writeJson(w, r.StatusCode, r.Body)
+}
+
+// AddHook registers a Hook which is called for each subsequent request, in the order they were added
+func (s *Server) AddHook(hook Hook) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.hooks = append(s.hooks, hook)
+}
+
+// InjectError returns an ARM error with the specified status code and error code for the next
+// `times` requests using the specified HTTP Method whose path matches `pathPattern` - a regular
+// expression which is matched case-insensitively.
+func (s *Server) InjectError(method, pathPattern string, times int, statusCode int, code string) {
+	s.AddHook(matchingHook(method, pathPattern, times, func(r *http.Request) *Response {
+		return &Response{
+			StatusCode: statusCode,
+			Body:       errorBody(code, fmt.Sprintf("injected %d response for %s %s", statusCode, r.Method, r.URL.Path)),
+		}
+	}))
+}
+
+// InjectNotFound returns a 404 Not Found for the next `times` matching requests
+func (s *Server) InjectNotFound(method, pathPattern string, times int) {
+	s.InjectError(method, pathPattern, times, http.StatusNotFound, "ResourceNotFound")
+}
+
+// InjectConflict returns a 409 Conflict for the next `times` matching requests
+func (s *Server) InjectConflict(method, pathPattern string, times int) {
+	s.InjectError(method, pathPattern, times, http.StatusConflict, "Conflict")
+}
+
+// InjectThrottling returns a 429 Too Many Requests for the next `times` matching requests, which
+// the clients will retry immediately since the `Retry-After` header is set to 0.
+func (s *Server) InjectThrottling(method, pathPattern string, times int) {
+	s.AddHook(matchingHook(method, pathPattern, times, func(r *http.Request) *Response {
+		return &Response{
+			StatusCode: http.StatusTooManyRequests,
+			Headers: map[string]string{
+				"Retry-After": strconv.Itoa(0),
+			},
+			Body: errorBody("TooManyRequests", fmt.Sprintf("injected throttling for %s %s", r.Method, r.URL.Path)),
+		}
+	}))
+}
+
+// InjectFailedOperation causes the Long Running Operation started by the next matching request
+// to complete with a status of `Failed` and the specified error.
+func (s *Server) InjectFailedOperation(method, pathPattern string, code, message string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.operationFailures = append(s.operationFailures, pendingOperationFailure{
+		matches: requestMatcher(method, pathPattern),
+		failure: operationFailure{
+			code:    code,
+			message: message,
+		},
+	})
+}
+
+type pendingOperationFailure struct {
+	matches func(r *http.Request) bool
+	failure operationFailure
+}
+
+func (s *Server) hasOperationFailure(r *http.Request) bool {
+	for _, v := range s.operationFailures {
+		if v.matches(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) popOperationFailure(r *http.Request) *operationFailure {
+	for i, v := range s.operationFailures {
+		if v.matches(r) {
+			s.operationFailures = append(s.operationFailures[:i], s.operationFailures[i+1:]...)
+			return &v.failure
+		}
+	}
+	return nil
+}
+
+func matchingHook(method, pathPattern string, times int, respond func(r *http.Request) *Response) Hook {
+	matches := requestMatcher(method, pathPattern)
+	remaining := times
+	return func(r *http.Request) *Response {
+		if remaining <= 0 || !matches(r) {
+			return nil
+		}
+		remaining--
+		return respond(r)
+	}
+}
+
+func requestMatcher(method, pathPattern string) func(r *http.Request) bool {
+	pattern := regexp.MustCompile("(?i)" + pathPattern)
+	return func(r *http.Request) bool {
+		return (method == "" || r.Method == method) && pattern.MatchString(r.URL.Path)
+	}
+}
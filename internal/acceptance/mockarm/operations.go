@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// operation is a Long Running Operation, which completes once it's been polled enough times
+type operation struct {
+	resourceKey    string
+	remainingPolls int
+	failure        *operationFailure
+}
+
+type operationFailure struct {
+	code    string
+	message string
+}
+
+func (s *Server) nextOperationId() string {
+	s.operationCounter++
+	return fmt.Sprintf("operation-%d", s.operationCounter)
+}
+
+func (s *Server) operationUrl(r *http.Request, operationId string) string {
+	return fmt.Sprintf("http://%s%s%s?api-version=%s", r.Host, operationsPath, operationId, r.URL.Query().Get("api-version"))
+}
+
+// startOperation responds to the request with a Long Running Operation for the resource
+func (s *Server) startOperation(w http.ResponseWriter, r *http.Request, key string, statusCode int, body interface{}) {
+	polls := s.longRunningOperationPolls
+	if polls <= 0 {
+		polls = 1
+	}
+
+	operationId := s.nextOperationId()
+	s.operations[operationId] = &operation{
+		resourceKey:    key,
+		remainingPolls: polls,
+		failure:        s.popOperationFailure(r),
+	}
+
+	w.Header().Set("Azure-AsyncOperation", s.operationUrl(r, operationId))
+	w.Header().Set("Retry-After", "0")
+	writeJson(w, statusCode, body)
+}
+
+func (s *Server) handleOperation(w http.ResponseWriter, r *http.Request) {
+	operationId := strings.TrimPrefix(r.URL.Path, operationsPath)
+	op, ok := s.operations[operationId]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	w.Header().Set("Retry-After", "0")
+
+	if op.remainingPolls > 0 {
+		op.remainingPolls--
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"name":   operationId,
+			"status": "InProgress",
+		})
+		return
+	}
+
+	resource, exists := s.resources[op.resourceKey]
+	if op.failure != nil {
+		if exists {
+			setProvisioningState(resource, "Failed")
+		}
+		writeJson(w, http.StatusOK, map[string]interface{}{
+			"name":   operationId,
+			"status": "Failed",
+			"error": map[string]interface{}{
+				"code":    op.failure.code,
+				"message": op.failure.message,
+			},
+		})
+		return
+	}
+
+	if exists {
+		setProvisioningState(resource, "Succeeded")
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"name":   operationId,
+		"status": "Succeeded",
+	})
+}
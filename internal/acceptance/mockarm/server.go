@@ -0,0 +1,492 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const (
+	// SubscriptionId is the ID of the Subscription which is exposed by the Server
+	SubscriptionId = "00000000-0000-0000-0000-000000000000"
+
+	// TenantId is the ID of the Tenant which is exposed by the Server
+	TenantId = "11111111-1111-1111-1111-111111111111"
+
+	// operationsPath is the path prefix used for the Long Running Operations returned by the Server
+	operationsPath = "/mockarm/operations/"
+)
+
+// Server is an in-process fake of the Azure Resource Manager API, which stores resources keyed by
+// their Resource ID and implements the PUT/PATCH/GET/DELETE semantics used by the Provider, including
+// polling of Long Running Operations.
+//
+// Hooks can be registered to return a different response for specific requests, allowing error paths
+// (such as a 404, 409 or 429 response, or a failed Long Running Operation) to be tested.
+type Server struct {
+	// URL is the base URL of the Server, which should be used as the Resource Manager endpoint
+	URL string
+
+	server *httptest.Server
+	mutex  *sync.Mutex
+
+	resources  map[string]map[string]interface{}
+	operations map[string]*operation
+	deletions  map[string]int
+	hooks      []Hook
+	requests   []Request
+
+	operationFailures []pendingOperationFailure
+
+	longRunningOperationPolls int
+	operationCounter          int
+}
+
+// Request is a record of a request received by the Server
+type Request struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// NewServer starts a new Server which is shut down when the test completes
+func NewServer(t *testing.T) *Server {
+	s := &Server{
+		mutex:      &sync.Mutex{},
+		resources:  make(map[string]map[string]interface{}),
+		operations: make(map[string]*operation),
+		deletions:  make(map[string]int),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	s.URL = s.server.URL
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+// UseLongRunningOperations configures the Server to respond to PUT and DELETE requests with a
+// Long Running Operation, which completes after the specified number of polls.
+func (s *Server) UseLongRunningOperations(polls int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.longRunningOperationPolls = polls
+}
+
+// Put stores the specified resource, as if it had been created outside of Terraform
+func (s *Server) Put(id string, body map[string]interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.resources[resourceKey(id)] = normalizeResource(id, body, "Succeeded")
+}
+
+// Get returns the resource with the specified ID, if it exists
+func (s *Server) Get(id string) (map[string]interface{}, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	v, ok := s.resources[resourceKey(id)]
+	return v, ok
+}
+
+// Delete removes the resource with the specified ID, as if it had been deleted outside of Terraform
+func (s *Server) Delete(id string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.resources, resourceKey(id))
+}
+
+// ResourceIds returns the IDs of all the resources currently stored within the Server
+func (s *Server) ResourceIds() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ids := make([]string, 0)
+	for _, v := range s.resources {
+		ids = append(ids, v["id"].(string))
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Requests returns the requests received by the Server, in the order they were received
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	out := make([]Request, len(s.requests))
+	copy(out, s.requests)
+	return out
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("reading request body: %+v", err))
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Body:   string(body),
+	})
+
+	for _, hook := range s.hooks {
+		// each Hook is able to read the request body
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		if resp := hook(r); resp != nil {
+			resp.write(w)
+			return
+		}
+	}
+
+	if strings.HasPrefix(r.URL.Path, operationsPath) {
+		s.handleOperation(w, r)
+		return
+	}
+
+	// actions (e.g. `{id}/listKeys`) have the same number of segments as a collection
+	if isCollection(r.URL.Path) && r.Method != http.MethodPost {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not supported for collection %q", r.Method, r.URL.Path))
+			return
+		}
+		s.handleList(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGet(w, r)
+	case http.MethodPut:
+		s.handlePut(w, r, body)
+	case http.MethodPatch:
+		s.handlePatch(w, r, body)
+	case http.MethodDelete:
+		s.handleDelete(w, r)
+	case http.MethodPost:
+		s.handlePost(w, r)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed", fmt.Sprintf("%s is not supported", r.Method))
+	}
+}
+
+func (s *Server) handleGet(w http.ResponseWriter, r *http.Request) {
+	key := resourceKey(r.URL.Path)
+	existing, ok := s.resources[key]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	// resources which are being deleted are removed once they've been polled enough times
+	if remaining, deleting := s.deletions[key]; deleting {
+		if remaining <= 0 {
+			delete(s.deletions, key)
+			delete(s.resources, key)
+			writeNotFound(w, r.URL.Path)
+			return
+		}
+		s.deletions[key] = remaining - 1
+	}
+
+	writeJson(w, http.StatusOK, existing)
+}
+
+func (s *Server) handleList(w http.ResponseWriter, r *http.Request) {
+	// extension resources (e.g. Management Locks) are listed alongside those defined on the parent scopes, and
+	// (unless filtered using `atScope()`) those defined on the Resources within the scope
+	atScope := strings.EqualFold(r.URL.Query().Get("$filter"), "atScope()")
+	extension := strings.Contains(strings.ToLower(r.URL.Path), "/providers/microsoft.authorization/")
+
+	items := make([]interface{}, 0)
+	for _, k := range s.sortedKeys() {
+		if isWithinCollection(k, r.URL.Path) || ((atScope || extension) && isWithinExtensionCollection(k, r.URL.Path, extension && !atScope)) {
+			items = append(items, s.resources[k])
+		}
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{
+		"value": items,
+	})
+}
+
+func (s *Server) handlePut(w http.ResponseWriter, r *http.Request, body []byte) {
+	payload := make(map[string]interface{})
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("parsing request body: %+v", err))
+			return
+		}
+	}
+
+	key := resourceKey(r.URL.Path)
+	_, exists := s.resources[key]
+
+	if s.longRunningOperationPolls > 0 || s.hasOperationFailure(r) {
+		statusCode := http.StatusCreated
+		if exists {
+			statusCode = http.StatusOK
+		}
+		s.resources[key] = normalizeResource(r.URL.Path, payload, "Creating")
+		s.startOperation(w, r, key, statusCode, s.resources[key])
+		return
+	}
+
+	// some APIs (e.g. Availability Sets) only expect a 200 when a Resource is created synchronously, rather than a 201
+	s.resources[key] = normalizeResource(r.URL.Path, payload, "Succeeded")
+	writeJson(w, http.StatusOK, s.resources[key])
+}
+
+func (s *Server) handlePatch(w http.ResponseWriter, r *http.Request, body []byte) {
+	key := resourceKey(r.URL.Path)
+	existing, ok := s.resources[key]
+	if !ok {
+		writeNotFound(w, r.URL.Path)
+		return
+	}
+
+	patch := make(map[string]interface{})
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &patch); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidRequestContent", fmt.Sprintf("parsing request body: %+v", err))
+			return
+		}
+	}
+
+	s.resources[key] = normalizeResource(r.URL.Path, mergePatch(existing, patch), "Succeeded")
+	writeJson(w, http.StatusOK, s.resources[key])
+}
+
+func (s *Server) handleDelete(w http.ResponseWriter, r *http.Request) {
+	key := resourceKey(r.URL.Path)
+	if _, ok := s.resources[key]; !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	// a failed deletion leaves the resource (and any nested resources) in place
+	if s.hasOperationFailure(r) {
+		setProvisioningState(s.resources[key], "Deleting")
+		s.startOperation(w, r, key, http.StatusAccepted, map[string]interface{}{})
+		return
+	}
+
+	// nested resources are removed alongside their parent, as they are in Azure
+	for _, k := range s.sortedKeys() {
+		if strings.HasPrefix(k, key+"/") {
+			delete(s.resources, k)
+		}
+	}
+
+	if s.longRunningOperationPolls > 0 {
+		s.deletions[key] = s.longRunningOperationPolls
+		setProvisioningState(s.resources[key], "Deleting")
+		operationId := s.nextOperationId()
+		s.operations[operationId] = &operation{
+			resourceKey:    key,
+			remainingPolls: s.longRunningOperationPolls,
+		}
+		w.Header().Set("Location", s.operationUrl(r, operationId))
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	delete(s.resources, key)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handlePost(w http.ResponseWriter, r *http.Request) {
+	// actions (e.g. `listKeys`) are performed against an existing resource, the response for which
+	// needs to be registered using a Hook - so by default we only confirm that the resource exists
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	parent := "/" + strings.Join(segments[:len(segments)-1], "/")
+	if _, ok := s.resources[resourceKey(parent)]; !ok {
+		writeNotFound(w, parent)
+		return
+	}
+
+	// soft-deleted resources (e.g. `deletedVaults`) are permanently removed using a `purge` action,
+	// which is a Long Running Operation
+	if strings.EqualFold(segments[len(segments)-1], "purge") {
+		delete(s.resources, resourceKey(parent))
+		s.startOperation(w, r, resourceKey(parent), http.StatusAccepted, map[string]interface{}{})
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]interface{}{})
+}
+
+func (s *Server) sortedKeys() []string {
+	keys := make([]string, 0, len(s.resources))
+	for k := range s.resources {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func normalizeResource(id string, payload map[string]interface{}, provisioningState string) map[string]interface{} {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	payload["id"] = "/" + strings.Join(segments, "/")
+	payload["name"] = segments[len(segments)-1]
+	if resourceType := resourceTypeForId(segments); resourceType != "" {
+		payload["type"] = resourceType
+	}
+
+	setProvisioningState(payload, provisioningState)
+	return payload
+}
+
+func setProvisioningState(payload map[string]interface{}, provisioningState string) {
+	props, ok := payload["properties"].(map[string]interface{})
+	if !ok {
+		props = make(map[string]interface{})
+		payload["properties"] = props
+	}
+	props["provisioningState"] = provisioningState
+}
+
+func resourceTypeForId(segments []string) string {
+	// e.g. `Microsoft.KeyVault/vaults` or `Microsoft.Network/virtualNetworks/subnets`
+	for i := len(segments) - 1; i >= 0; i-- {
+		if strings.EqualFold(segments[i], "providers") && i+1 < len(segments) {
+			types := []string{segments[i+1]}
+			for j := i + 2; j < len(segments); j += 2 {
+				types = append(types, segments[j])
+			}
+			return strings.Join(types, "/")
+		}
+	}
+
+	if len(segments) == 4 && strings.EqualFold(segments[2], "resourceGroups") {
+		return "Microsoft.Resources/resourceGroups"
+	}
+
+	return ""
+}
+
+// mergePatch applies the JSON Merge Patch (RFC 7396) in patch to target
+func mergePatch(target map[string]interface{}, patch map[string]interface{}) map[string]interface{} {
+	for k, v := range patch {
+		if v == nil {
+			delete(target, k)
+			continue
+		}
+
+		patchValue, patchIsMap := v.(map[string]interface{})
+		targetValue, targetIsMap := target[k].(map[string]interface{})
+		if patchIsMap && targetIsMap {
+			target[k] = mergePatch(targetValue, patchValue)
+			continue
+		}
+		target[k] = v
+	}
+	return target
+}
+
+// resourceKey returns the key used to store the resource with the specified ID, since Resource IDs
+// are case-insensitive
+func resourceKey(id string) string {
+	return "/" + strings.ToLower(strings.Trim(id, "/"))
+}
+
+// isCollection returns whether the specified path refers to a collection of resources (for example
+// `/subscriptions/{id}/resourceGroups`) rather than a single resource - which is determined from the
+// number of segments once any `/providers/{namespace}` segments have been removed.
+func isCollection(path string) bool {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	count := 0
+	for i := 0; i < len(segments); i++ {
+		if strings.EqualFold(segments[i], "providers") && i+1 < len(segments) {
+			i++
+			continue
+		}
+		count++
+	}
+	return count%2 == 1
+}
+
+func isWithinCollection(key string, collection string) bool {
+	collection = resourceKey(collection)
+
+	// e.g. `/subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.KeyVault/vaults/{name}`
+	// within `/subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.KeyVault/vaults`
+	if strings.HasPrefix(key, collection+"/") && !strings.Contains(strings.TrimPrefix(key, collection+"/"), "/") {
+		return true
+	}
+
+	// e.g. `/subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.KeyVault/vaults/{name}` or
+	// `/subscriptions/{id}/providers/Microsoft.KeyVault/locations/{location}/deletedVaults/{name}`
+	// within `/subscriptions/{id}/providers/Microsoft.KeyVault/vaults` (or `deletedVaults`)
+	segments := strings.Split(strings.Trim(collection, "/"), "/")
+	if len(segments) == 5 && segments[0] == "subscriptions" && segments[2] == "providers" {
+		subscriptionPrefix := fmt.Sprintf("/subscriptions/%s/", segments[1])
+		keySegments := strings.Split(strings.Trim(key, "/"), "/")
+		if strings.HasPrefix(key, subscriptionPrefix) && strings.Contains(key, fmt.Sprintf("/providers/%s/", segments[3])) && keySegments[len(keySegments)-2] == segments[4] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isWithinExtensionCollection returns whether the key is an extension resource within the same collection defined
+// on a parent scope (or when `nested` is set, a nested scope), e.g. `/subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.Authorization/locks/{name}`
+// within `/subscriptions/{id}/resourceGroups/{name}/providers/Microsoft.Network/virtualNetworks/{name}/providers/Microsoft.Authorization/locks`
+func isWithinExtensionCollection(key string, collection string, nested bool) bool {
+	collection = resourceKey(collection)
+	index := strings.LastIndex(collection, "/providers/")
+	if index <= 0 {
+		return false
+	}
+	scope := collection[:index]
+	suffix := collection[index:]
+
+	index = strings.LastIndex(key, suffix+"/")
+	if index <= 0 || strings.Contains(key[index+len(suffix)+1:], "/") {
+		return false
+	}
+	keyScope := key[:index]
+
+	return strings.HasPrefix(scope, keyScope+"/") || (nested && strings.HasPrefix(keyScope, scope+"/"))
+}
+
+func writeJson(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, code, message string) {
+	writeJson(w, statusCode, errorBody(code, message))
+}
+
+func writeNotFound(w http.ResponseWriter, id string) {
+	writeError(w, http.StatusNotFound, "ResourceNotFound", fmt.Sprintf("The Resource %q was not found.", id))
+}
+
+func errorBody(code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+		},
+	}
+}
@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package mockarm_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/vaults"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func buildResourceGroupsClient(t *testing.T, server *mockarm.Server) *resourcegroups.ResourceGroupsClient {
+	o := server.ClientOptions(features.Default())
+	client, err := resourcegroups.NewResourceGroupsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	o.Configure(client.Client, o.Authorizers.ResourceManager)
+	return client
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestServer_CRUD(t *testing.T) {
+	server := mockarm.NewServer(t)
+	client := buildResourceGroupsClient(t, server)
+	ctx := testContext(t)
+	id := commonids.NewResourceGroupID(mockarm.SubscriptionId, "example-resources")
+
+	existing, err := client.Get(ctx, id)
+	if !response.WasNotFound(existing.HttpResponse) {
+		t.Fatalf("expected a 404 for a missing Resource Group but got %+v", err)
+	}
+
+	payload := resourcegroups.ResourceGroup{
+		Location: "westeurope",
+		Tags: pointer.To(map[string]string{
+			"env": "test",
+		}),
+	}
+	if _, err := client.CreateOrUpdate(ctx, id, payload); err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+
+	resp, err := client.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("retrieving %s: %+v", id, err)
+	}
+	if resp.Model == nil || resp.Model.Id == nil || !strings.EqualFold(*resp.Model.Id, id.ID()) {
+		t.Fatalf("expected the ID to be %q but got %+v", id.ID(), resp.Model)
+	}
+	if resp.Model.Properties == nil || pointer.From(resp.Model.Properties.ProvisioningState) != "Succeeded" {
+		t.Fatalf("expected the provisioningState to be `Succeeded` but got %+v", resp.Model.Properties)
+	}
+
+	update := resourcegroups.ResourceGroupPatchable{
+		Tags: pointer.To(map[string]string{
+			"env": "prod",
+		}),
+	}
+	if _, err := client.Update(ctx, id, update); err != nil {
+		t.Fatalf("updating %s: %+v", id, err)
+	}
+	resp, err = client.Get(ctx, id)
+	if err != nil {
+		t.Fatalf("retrieving %s: %+v", id, err)
+	}
+	if resp.Model.Location != "westeurope" || (*resp.Model.Tags)["env"] != "prod" {
+		t.Fatalf("expected the patch to be merged but got %+v", *resp.Model)
+	}
+
+	list, err := client.ListComplete(ctx, commonids.NewSubscriptionID(mockarm.SubscriptionId), resourcegroups.DefaultListOperationOptions())
+	if err != nil {
+		t.Fatalf("listing Resource Groups: %+v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected 1 Resource Group but got %d", len(list.Items))
+	}
+
+	// the resource is removed immediately, so there's no need to poll
+	if _, err := client.Delete(ctx, id, resourcegroups.DefaultDeleteOperationOptions()); err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	if ids := server.ResourceIds(); len(ids) != 0 {
+		t.Fatalf("expected no resources to remain but got %+v", ids)
+	}
+}
+
+func TestServer_LongRunningOperations(t *testing.T) {
+	server := mockarm.NewServer(t)
+	server.UseLongRunningOperations(1)
+	client := buildResourceGroupsClient(t, server)
+	ctx := testContext(t)
+	id := commonids.NewResourceGroupID(mockarm.SubscriptionId, "example-resources")
+
+	server.Put(id.ID()+"/providers/Microsoft.Storage/storageAccounts/example", map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	// whilst Resource Groups aren't created using an LRO in Azure, the Server applies this to all resources
+	resp, err := client.CreateOrUpdate(ctx, id, resourcegroups.ResourceGroup{Location: "westeurope"})
+	if err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+	if resp.HttpResponse.Header.Get("Azure-AsyncOperation") == "" {
+		t.Fatalf("expected an `Azure-AsyncOperation` header to be returned")
+	}
+
+	if err := client.DeleteThenPoll(ctx, id, resourcegroups.DefaultDeleteOperationOptions()); err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	if ids := server.ResourceIds(); len(ids) != 0 {
+		t.Fatalf("expected the nested resources to be deleted but got %+v", ids)
+	}
+}
+
+func TestServer_InjectedErrors(t *testing.T) {
+	server := mockarm.NewServer(t)
+	client := buildResourceGroupsClient(t, server)
+	ctx := testContext(t)
+	id := commonids.NewResourceGroupID(mockarm.SubscriptionId, "example-resources")
+	server.Put(id.ID(), map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	server.InjectConflict(http.MethodPut, "/resourceGroups/example-resources$", 1)
+	resp, err := client.CreateOrUpdate(ctx, id, resourcegroups.ResourceGroup{Location: "westeurope"})
+	if err == nil || !response.WasConflict(resp.HttpResponse) {
+		t.Fatalf("expected a 409 Conflict but got %+v", err)
+	}
+
+	server.InjectNotFound(http.MethodGet, "/resourceGroups/example-resources$", 1)
+	existing, _ := client.Get(ctx, id)
+	if !response.WasNotFound(existing.HttpResponse) {
+		t.Fatalf("expected a 404 Not Found")
+	}
+
+	// throttled requests should be retried by the client
+	server.InjectThrottling(http.MethodGet, "/resourceGroups/example-resources$", 2)
+	if _, err := client.Get(ctx, id); err != nil {
+		t.Fatalf("expected the throttled request to be retried but got %+v", err)
+	}
+
+	getCount := 0
+	for _, r := range server.Requests() {
+		if r.Method == http.MethodGet {
+			getCount++
+		}
+	}
+	if getCount != 4 {
+		t.Fatalf("expected 4 GET requests (including 2 retries) but got %d", getCount)
+	}
+}
+
+func TestServer_InjectedFailedOperation(t *testing.T) {
+	server := mockarm.NewServer(t)
+	ctx := testContext(t)
+	o := server.ClientOptions(features.Default())
+	client, err := vaults.NewVaultsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+	o.Configure(client.Client, o.Authorizers.ResourceManager)
+
+	id := commonids.NewKeyVaultID(mockarm.SubscriptionId, "example-resources", "example")
+	payload := vaults.VaultCreateOrUpdateParameters{
+		Location: "westeurope",
+		Properties: vaults.VaultProperties{
+			TenantId: mockarm.TenantId,
+			Sku: vaults.Sku{
+				Family: vaults.SkuFamilyA,
+				Name:   vaults.SkuNameStandard,
+			},
+		},
+	}
+
+	server.InjectFailedOperation(http.MethodPut, "/vaults/example$", "InternalServerError", "something went wrong")
+	if err := client.CreateOrUpdateThenPoll(ctx, id, payload); err == nil || !strings.Contains(err.Error(), "something went wrong") {
+		t.Fatalf("expected the Long Running Operation to fail but got %+v", err)
+	}
+
+	// subsequent requests should succeed
+	if err := client.CreateOrUpdateThenPoll(ctx, id, payload); err != nil {
+		t.Fatalf("creating %s: %+v", id, err)
+	}
+	if _, ok := server.Get(id.ID()); !ok {
+		t.Fatalf("expected %s to exist", id)
+	}
+}
+
+func TestServer_InjectedFailedDeleteOperation(t *testing.T) {
+	server := mockarm.NewServer(t)
+	client := buildResourceGroupsClient(t, server)
+	ctx := testContext(t)
+	id := commonids.NewResourceGroupID(mockarm.SubscriptionId, "example-resources")
+	nestedId := id.ID() + "/providers/Microsoft.Storage/storageAccounts/example"
+
+	server.Put(id.ID(), map[string]interface{}{
+		"location": "westeurope",
+	})
+	server.Put(nestedId, map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	// NOTE: the `go-azure-sdk` polls the resource (rather than the operation) following a DELETE, which
+	// would continue until the context times out - so the operation is polled directly here
+	server.InjectFailedOperation(http.MethodDelete, "/resourceGroups/example-resources$", "InternalServerError", "something went wrong")
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, server.URL+id.ID()+"?api-version=2023-07-01", nil)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected a 202 Accepted but got %d", resp.StatusCode)
+	}
+
+	var operation struct {
+		Status string `json:"status"`
+		Error  struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	for operation.Status == "" || operation.Status == "InProgress" {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, resp.Header.Get("Azure-AsyncOperation"), nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		pollResp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("polling the deletion of %s: %+v", id, err)
+		}
+		err = json.NewDecoder(pollResp.Body).Decode(&operation)
+		pollResp.Body.Close()
+		if err != nil {
+			t.Fatalf("parsing the operation: %+v", err)
+		}
+	}
+	if operation.Status != "Failed" || operation.Error.Message != "something went wrong" {
+		t.Fatalf("expected the Long Running Operation to fail but got %+v", operation)
+	}
+
+	// a failed deletion should leave the resource and any nested resources in place
+	existing, ok := server.Get(id.ID())
+	if !ok {
+		t.Fatalf("expected %s to still exist", id)
+	}
+	if state := existing["properties"].(map[string]interface{})["provisioningState"]; state != "Failed" {
+		t.Fatalf("expected the `provisioningState` of %s to be `Failed` but got %q", id, state)
+	}
+	if _, ok := server.Get(nestedId); !ok {
+		t.Fatalf("expected %s to still exist", nestedId)
+	}
+
+	// subsequent requests should succeed
+	if err := client.DeleteThenPoll(ctx, id, resourcegroups.DefaultDeleteOperationOptions()); err != nil {
+		t.Fatalf("deleting %s: %+v", id, err)
+	}
+	if ids := server.ResourceIds(); len(ids) != 0 {
+		t.Fatalf("expected the resources to be deleted but got %+v", ids)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
@@ -25,6 +26,10 @@ type StateChangeConf = retry.StateChangeConf
 
 type TestCheckFunc = resource.TestCheckFunc
 
+type ConfigPlanChecks = resource.ConfigPlanChecks
+
+type PlanCheck = plancheck.PlanCheck
+
 func ComposeTestCheckFunc(fs ...resource.TestCheckFunc) pluginsdk.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(fs...)
 }
@@ -139,6 +139,29 @@ func (td TestData) ImportStepFor(resourceName string, ignore ...string) resource
 	return step
 }
 
+// ImportStepWithComparer returns a Test Step which Imports the Resource, optionally ignoring any
+// fields which may not be imported - reporting each field which differs after import, rather than
+// the entire State of the Resource
+func (td TestData) ImportStepWithComparer(ignore ...string) resource.TestStep {
+	return td.ImportStepWithComparerFor(td.ResourceName, ignore...)
+}
+
+// ImportStepWithComparerFor returns a Test Step which Imports a given resource by name, optionally
+// ignoring any fields which may not be imported - reporting each field which differs after import
+func (td TestData) ImportStepWithComparerFor(resourceName string, ignore ...string) resource.TestStep {
+	if strings.HasPrefix(resourceName, "data.") {
+		return td.ImportStepFor(resourceName, ignore...)
+	}
+
+	comparer := check.ImportedState(resourceName, ignore...)
+	return resource.TestStep{
+		ResourceName:      resourceName,
+		ImportState:       true,
+		ImportStateIdFunc: comparer.ImportStateIdFunc,
+		ImportStateCheck:  comparer.ImportStateCheck,
+	}
+}
+
 // RequiresImportErrorStep returns a Test Step which expects a Requires Import
 // error to be returned when running this step
 func (td TestData) RequiresImportErrorStep(configBuilder func(data TestData) string) resource.TestStep {
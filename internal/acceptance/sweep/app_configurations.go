@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/appconfiguration/2023-03-01/deletedconfigurationstores"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+const SoftDeletedAppConfigurationsSweeperName = "soft_deleted_app_configurations"
+
+func init() {
+	Register(Sweeper{
+		Name:         SoftDeletedAppConfigurationsSweeperName,
+		Dependencies: []string{ResourceGroupsSweeperName},
+		Sweep:        sweepSoftDeletedAppConfigurations,
+	})
+}
+
+func sweepSoftDeletedAppConfigurations(ctx context.Context, client *clients.Client, options Options) ([]string, error) {
+	deletedClient := client.AppConfiguration.DeletedConfigurationStoresClient
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+
+	resp, err := deletedClient.ConfigurationStoresListDeletedComplete(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("listing Soft-Deleted App Configurations within %s: %+v", subscriptionId, err)
+	}
+
+	ids := make([]string, 0)
+	for _, item := range resp.Items {
+		if item.Properties == nil {
+			continue
+		}
+		props := *item.Properties
+
+		// stores with Purge Protection enabled can't be purged until the retention period has passed
+		if pointer.From(props.PurgeProtectionEnabled) {
+			continue
+		}
+
+		name := pointer.From(item.Name)
+		deletedAt, _ := props.GetDeletionDateAsTime()
+		if !options.shouldSweep(name, deletedAt) {
+			continue
+		}
+
+		ids = append(ids, deletedconfigurationstores.NewDeletedConfigurationStoreID(subscriptionId.SubscriptionId, location.Normalize(pointer.From(props.Location)), name).ID())
+	}
+
+	return removeAll(ctx, options, ids, func(ctx context.Context, input string) error {
+		id, err := deletedconfigurationstores.ParseDeletedConfigurationStoreID(input)
+		if err != nil {
+			return err
+		}
+
+		return deletedClient.ConfigurationStoresPurgeDeletedThenPoll(ctx, *id)
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/cognitive/2023-05-01/cognitiveservicesaccounts"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+const SoftDeletedCognitiveAccountsSweeperName = "soft_deleted_cognitive_accounts"
+
+func init() {
+	Register(Sweeper{
+		Name:         SoftDeletedCognitiveAccountsSweeperName,
+		Dependencies: []string{ResourceGroupsSweeperName},
+		Sweep:        sweepSoftDeletedCognitiveAccounts,
+	})
+}
+
+func sweepSoftDeletedCognitiveAccounts(ctx context.Context, client *clients.Client, options Options) ([]string, error) {
+	accountsClient := client.Cognitive.AccountsClient
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+
+	resp, err := accountsClient.DeletedAccountsListComplete(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("listing Soft-Deleted Cognitive Accounts within %s: %+v", subscriptionId, err)
+	}
+
+	ids := make([]string, 0)
+	for _, item := range resp.Items {
+		// the Resource Group isn't exposed in the model, so we need to parse this from the ID
+		id, err := cognitiveservicesaccounts.ParseDeletedAccountIDInsensitively(pointer.From(item.Id))
+		if err != nil {
+			continue
+		}
+
+		var deletedAt *time.Time
+		if item.Properties != nil && item.Properties.DeletionDate != nil {
+			if v, err := time.Parse(time.RFC3339, *item.Properties.DeletionDate); err == nil {
+				deletedAt = &v
+			}
+		}
+		if !options.shouldSweep(id.DeletedAccountName, deletedAt) {
+			continue
+		}
+
+		id.LocationName = location.Normalize(id.LocationName)
+		ids = append(ids, id.ID())
+	}
+
+	return removeAll(ctx, options, ids, func(ctx context.Context, input string) error {
+		id, err := cognitiveservicesaccounts.ParseDeletedAccountID(input)
+		if err != nil {
+			return err
+		}
+
+		return accountsClient.DeletedAccountsPurgeThenPoll(ctx, *id)
+	})
+}
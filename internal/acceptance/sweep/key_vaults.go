@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+const SoftDeletedKeyVaultsSweeperName = "soft_deleted_key_vaults"
+
+func init() {
+	Register(Sweeper{
+		Name:         SoftDeletedKeyVaultsSweeperName,
+		Dependencies: []string{ResourceGroupsSweeperName},
+		Sweep:        sweepSoftDeletedKeyVaults,
+	})
+}
+
+func sweepSoftDeletedKeyVaults(ctx context.Context, client *clients.Client, options Options) ([]string, error) {
+	vaultsClient := client.KeyVault.VaultsClient
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+
+	resp, err := vaultsClient.ListDeletedComplete(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("listing Soft-Deleted Key Vaults within %s: %+v", subscriptionId, err)
+	}
+
+	ids := make([]string, 0)
+	for _, item := range resp.Items {
+		if item.Properties == nil {
+			continue
+		}
+		props := *item.Properties
+
+		// vaults with Purge Protection enabled can't be purged until the retention period has passed
+		if pointer.From(props.PurgeProtectionEnabled) {
+			continue
+		}
+
+		name := pointer.From(item.Name)
+		deletedAt, _ := props.GetDeletionDateAsTime()
+		if !options.shouldSweep(name, deletedAt) {
+			continue
+		}
+
+		ids = append(ids, vaults.NewDeletedVaultID(subscriptionId.SubscriptionId, location.Normalize(pointer.From(props.Location)), name).ID())
+	}
+
+	return removeAll(ctx, options, ids, func(ctx context.Context, input string) error {
+		id, err := vaults.ParseDeletedVaultID(input)
+		if err != nil {
+			return err
+		}
+
+		return vaultsClient.PurgeDeletedThenPoll(ctx, *id)
+	})
+}
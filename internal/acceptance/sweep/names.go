@@ -0,0 +1,82 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultPrefixes returns the name prefixes used by the Acceptance Tests, e.g. `acctestRG-{RandomInteger}`
+func DefaultPrefixes() []string {
+	return []string{
+		"acctest",
+		"testacc",
+	}
+}
+
+// randomIntegerRegex matches the `YYMMddHHmmss` component at the start of `acceptance.RandTimeInt`
+// (which is also retained when using `RandomIntOfLength` with a length of 12 or more) - which, as with
+// `acceptance.RandTimeInt`, is in UTC
+var randomIntegerRegex = regexp.MustCompile(`[0-9]{12,18}`)
+
+// createdAtFromName returns the time that a resource was created, parsed from the random integer
+// within its name - or nil if the name doesn't contain one.
+func createdAtFromName(name string, now time.Time) *time.Time {
+	for _, match := range randomIntegerRegex.FindAllString(name, -1) {
+		createdAt, err := time.ParseInLocation("060102150405", match[0:12], time.UTC)
+		if err != nil {
+			continue
+		}
+
+		// a random number may happen to be a valid date, so ignore anything too far in the past or future
+		if createdAt.After(now.Add(24*time.Hour)) || createdAt.Before(now.AddDate(-2, 0, 0)) {
+			continue
+		}
+
+		return &createdAt
+	}
+
+	return nil
+}
+
+// shouldSweep returns whether the resource with the specified name was created by the Acceptance Tests
+// and is older than the Minimum Age. The age is determined from the random integer within the name when
+// present, falling back to the `fallback` time (e.g. the Deletion Date) - when neither are available the
+// resource is only removed when no Minimum Age is specified.
+func (o Options) shouldSweep(name string, fallback *time.Time) bool {
+	now := o.Now()
+	createdAt := createdAtFromName(name, now)
+
+	matchesPrefix := false
+	for _, prefix := range o.Prefixes {
+		if strings.HasPrefix(strings.ToLower(name), strings.ToLower(prefix)) {
+			matchesPrefix = true
+			break
+		}
+	}
+	if !matchesPrefix {
+		return false
+	}
+
+	if createdAt == nil {
+		createdAt = fallback
+	}
+	if createdAt == nil {
+		if o.MinimumAge > 0 {
+			log.Printf("[DEBUG] Skipping %q since its age couldn't be determined", name)
+			return false
+		}
+		return true
+	}
+
+	if now.Sub(*createdAt) < o.MinimumAge {
+		log.Printf("[DEBUG] Skipping %q since it's newer than the Minimum Age", name)
+		return false
+	}
+
+	return true
+}
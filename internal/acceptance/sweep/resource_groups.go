@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+const ResourceGroupsSweeperName = "resource_groups"
+
+func init() {
+	Register(Sweeper{
+		Name:  ResourceGroupsSweeperName,
+		Sweep: sweepResourceGroups,
+	})
+}
+
+func sweepResourceGroups(ctx context.Context, client *clients.Client, options Options) ([]string, error) {
+	resourceGroupsClient := client.Resource.ResourceGroupsClient
+	subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
+
+	resp, err := resourceGroupsClient.ListComplete(ctx, subscriptionId, resourcegroups.DefaultListOperationOptions())
+	if err != nil {
+		return nil, fmt.Errorf("listing Resource Groups within %s: %+v", subscriptionId, err)
+	}
+
+	ids := make([]string, 0)
+	for _, item := range resp.Items {
+		name := pointer.From(item.Name)
+
+		// Resource Groups managed by another resource (e.g. a Kubernetes Cluster's Node Resource Group)
+		// are removed when that resource is deleted
+		if item.ManagedBy != nil {
+			continue
+		}
+		if item.Properties != nil && strings.EqualFold(pointer.From(item.Properties.ProvisioningState), "Deleting") {
+			continue
+		}
+		if !options.shouldSweep(name, nil) {
+			continue
+		}
+
+		ids = append(ids, commonids.NewResourceGroupID(subscriptionId.SubscriptionId, name).ID())
+	}
+
+	return removeAll(ctx, options, ids, func(ctx context.Context, input string) error {
+		id, err := commonids.ParseResourceGroupID(input)
+		if err != nil {
+			return err
+		}
+
+		return resourceGroupsClient.DeleteThenPoll(ctx, *id, resourcegroups.DefaultDeleteOperationOptions())
+	})
+}
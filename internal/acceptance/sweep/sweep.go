@@ -0,0 +1,223 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// Sweeper removes resources leaked by the Acceptance Tests for a single Service/resource type
+type Sweeper struct {
+	// Name is the unique name of this Sweeper, for example `resource_groups`
+	Name string
+
+	// Dependencies is a list of the names of the Sweepers which must run before this one - for example
+	// Soft-Deleted items only exist once the Resource Group containing them has been deleted.
+	Dependencies []string
+
+	// Sweep removes (or, when running in Dry Run mode, lists) the leaked resources, returning
+	// the IDs of the resources which were (or would have been) removed.
+	Sweep func(ctx context.Context, client *clients.Client, options Options) ([]string, error)
+}
+
+// Options configures which resources a Sweeper should remove
+type Options struct {
+	// DryRun specifies that the matching resources should only be listed, rather than removed
+	DryRun bool
+
+	// MinimumAge is the minimum age of a resource before it's removed, which ensures that
+	// resources used by any in-progress test runs aren't removed.
+	MinimumAge time.Duration
+
+	// Parallelism is the maximum number of resources removed concurrently by each Sweeper
+	Parallelism int
+
+	// Prefixes is a list of the name prefixes used to identify resources created by the Acceptance Tests
+	Prefixes []string
+
+	// Now returns the current time, this exists to allow this to be overridden in tests
+	Now func() time.Time
+}
+
+// DefaultOptions returns the default Options used when sweeping
+func DefaultOptions() Options {
+	return Options{
+		DryRun:      false,
+		MinimumAge:  3 * time.Hour,
+		Parallelism: 10,
+		Prefixes:    DefaultPrefixes(),
+		Now:         time.Now,
+	}
+}
+
+var (
+	sweepers     = map[string]Sweeper{}
+	sweepersLock = &sync.Mutex{}
+)
+
+// Register registers the specified Sweeper, panicking if a Sweeper with the same name already exists
+func Register(sweeper Sweeper) {
+	sweepersLock.Lock()
+	defer sweepersLock.Unlock()
+
+	if _, exists := sweepers[sweeper.Name]; exists {
+		panic(fmt.Sprintf("a Sweeper named %q has already been registered", sweeper.Name))
+	}
+	sweepers[sweeper.Name] = sweeper
+}
+
+// Names returns the names of all of the registered Sweepers, sorted alphabetically
+func Names() []string {
+	sweepersLock.Lock()
+	defer sweepersLock.Unlock()
+
+	names := make([]string, 0, len(sweepers))
+	for name := range sweepers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the specified Sweepers (or all registered Sweepers when none are specified) in dependency
+// order - returning the IDs of the resources which were (or would have been) removed, by Sweeper.
+// A Sweeper failing doesn't prevent the remaining Sweepers from running, any errors are returned once
+// all of the Sweepers have completed.
+func Run(ctx context.Context, client *clients.Client, options Options, names ...string) (map[string][]string, error) {
+	ordered, err := orderSweepers(names)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Now == nil {
+		options.Now = time.Now
+	}
+	if options.Parallelism <= 0 {
+		options.Parallelism = 1
+	}
+	if len(options.Prefixes) == 0 {
+		options.Prefixes = DefaultPrefixes()
+	}
+
+	var errs *multierror.Error
+	results := make(map[string][]string)
+	for _, sweeper := range ordered {
+		log.Printf("[DEBUG] Running Sweeper %q (Dry Run: %t)..", sweeper.Name, options.DryRun)
+		ids, err := sweeper.Sweep(ctx, client, options)
+		results[sweeper.Name] = ids
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("running Sweeper %q: %+v", sweeper.Name, err))
+			continue
+		}
+		log.Printf("[DEBUG] Sweeper %q matched %d resources", sweeper.Name, len(ids))
+	}
+
+	return results, errs.ErrorOrNil()
+}
+
+// orderSweepers returns the specified Sweepers (including any dependencies) sorted such that each
+// Sweeper runs after the Sweepers it depends on
+func orderSweepers(names []string) ([]Sweeper, error) {
+	sweepersLock.Lock()
+	defer sweepersLock.Unlock()
+
+	if len(names) == 0 {
+		for name := range sweepers {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	output := make([]Sweeper, 0)
+	visited := make(map[string]bool)
+	inProgress := make(map[string]bool)
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		if visited[name] {
+			return nil
+		}
+		if inProgress[name] {
+			return fmt.Errorf("a circular dependency was detected for the Sweeper %q", name)
+		}
+
+		sweeper, ok := sweepers[name]
+		if !ok {
+			return fmt.Errorf("a Sweeper named %q was not registered", name)
+		}
+
+		inProgress[name] = true
+		for _, dependency := range sweeper.Dependencies {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+		inProgress[name] = false
+		visited[name] = true
+
+		output = append(output, sweeper)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+
+	return output, nil
+}
+
+// removeAll calls `remove` for each of the specified IDs (unless running in Dry Run mode), using
+// up to `options.Parallelism` concurrent calls - returning the IDs which were (or would have been) removed.
+func removeAll(ctx context.Context, options Options, ids []string, remove func(ctx context.Context, id string) error) ([]string, error) {
+	if options.DryRun {
+		for _, id := range ids {
+			log.Printf("[DEBUG] Dry Run: would remove %q", id)
+		}
+		return ids, nil
+	}
+
+	var (
+		errs    *multierror.Error
+		removed = make([]string, 0)
+		lock    = &sync.Mutex{}
+		wg      = &sync.WaitGroup{}
+		limit   = make(chan struct{}, options.Parallelism)
+	)
+
+	for _, id := range ids {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(id string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+
+			log.Printf("[DEBUG] Removing %q..", id)
+			err := remove(ctx, id)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("removing %q: %+v", id, err))
+				return
+			}
+			removed = append(removed, id)
+		}(id)
+	}
+	wg.Wait()
+
+	sort.Strings(removed)
+	return removed, errs.ErrorOrNil()
+}
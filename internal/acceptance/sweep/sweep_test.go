@@ -0,0 +1,166 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sweep_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func TestSweepers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	server := mockarm.NewServer(t)
+	server.UseLongRunningOperations(1)
+	client, err := server.Client(ctx, features.Default())
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	randomInteger := func(t time.Time) string {
+		// matches the format used by `acceptance.RandTimeInt`
+		return strings.Replace(t.Format("060102150405.00"), ".", "", 1) + "1234"
+	}
+	old := randomInteger(now.Add(-24 * time.Hour))
+	recent := randomInteger(now.Add(-10 * time.Minute))
+	deletedAt := now.Add(-24 * time.Hour).UTC().Format(time.RFC3339)
+
+	subscriptionId := fmt.Sprintf("/subscriptions/%s", mockarm.SubscriptionId)
+	leakedResourceGroupId := fmt.Sprintf("%s/resourceGroups/acctestRG-%s", subscriptionId, old)
+	expected := map[string][]string{
+		sweep.ResourceGroupsSweeperName: {
+			leakedResourceGroupId,
+		},
+		sweep.SoftDeletedKeyVaultsSweeperName: {
+			fmt.Sprintf("%s/providers/Microsoft.KeyVault/locations/westeurope/deletedVaults/acctestkv-abcde", subscriptionId),
+		},
+		sweep.SoftDeletedAppConfigurationsSweeperName: {
+			fmt.Sprintf("%s/providers/Microsoft.AppConfiguration/locations/westeurope/deletedConfigurationStores/testaccappconf%s", subscriptionId, old),
+		},
+		sweep.SoftDeletedCognitiveAccountsSweeperName: {
+			fmt.Sprintf("%s/providers/Microsoft.CognitiveServices/locations/westeurope/resourceGroups/acctestRG-%s/deletedAccounts/acctestcogacc-%s", subscriptionId, old, old),
+		},
+	}
+
+	// resources which should be removed
+	server.Put(leakedResourceGroupId, map[string]interface{}{
+		"location": "westeurope",
+	})
+	server.Put(leakedResourceGroupId+"/providers/Microsoft.Storage/storageAccounts/acctestsa", map[string]interface{}{
+		"location": "westeurope",
+	})
+	server.Put(expected[sweep.SoftDeletedKeyVaultsSweeperName][0], map[string]interface{}{
+		"properties": map[string]interface{}{
+			"location":     "westeurope",
+			"deletionDate": deletedAt,
+		},
+	})
+	server.Put(expected[sweep.SoftDeletedAppConfigurationsSweeperName][0], map[string]interface{}{
+		"properties": map[string]interface{}{
+			"location":     "westeurope",
+			"deletionDate": deletedAt,
+		},
+	})
+	server.Put(expected[sweep.SoftDeletedCognitiveAccountsSweeperName][0], map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"deletionDate": deletedAt,
+		},
+	})
+
+	// resources which should be retained
+	retained := []string{
+		fmt.Sprintf("%s/resourceGroups/acctestRG-%s", subscriptionId, recent),
+		fmt.Sprintf("%s/resourceGroups/production", subscriptionId),
+		fmt.Sprintf("%s/resourceGroups/MC_acctestRG-aks-%s_acctestaks%s_westeurope", subscriptionId, old, old),
+		fmt.Sprintf("%s/providers/Microsoft.KeyVault/locations/westeurope/deletedVaults/acctestkv-fghij", subscriptionId),
+		fmt.Sprintf("%s/providers/Microsoft.KeyVault/locations/westeurope/deletedVaults/acctestkv-klmno", subscriptionId),
+		fmt.Sprintf("%s/resourceGroups/production-%s", subscriptionId, old),
+	}
+	server.Put(retained[0], map[string]interface{}{
+		"location": "westeurope",
+	})
+	server.Put(retained[1], map[string]interface{}{
+		"location": "westeurope",
+	})
+	server.Put(retained[2], map[string]interface{}{
+		"location":  "westeurope",
+		"managedBy": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/acctestRG-aks/providers/Microsoft.ContainerService/managedClusters/acctestaks",
+	})
+	server.Put(retained[3], map[string]interface{}{
+		"properties": map[string]interface{}{
+			"location":     "westeurope",
+			"deletionDate": now.Add(-time.Minute).UTC().Format(time.RFC3339),
+		},
+	})
+	server.Put(retained[4], map[string]interface{}{
+		"properties": map[string]interface{}{
+			"location":               "westeurope",
+			"deletionDate":           deletedAt,
+			"purgeProtectionEnabled": true,
+		},
+	})
+	// names containing a timestamp which don't match one of the prefixes shouldn't be removed
+	server.Put(retained[5], map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	options := sweep.DefaultOptions()
+	options.Now = func() time.Time {
+		return now
+	}
+
+	// a Dry Run should list, but not remove, the leaked resources
+	options.DryRun = true
+	results, err := sweep.Run(ctx, client, options)
+	if err != nil {
+		t.Fatalf("running the Sweepers in Dry Run mode: %+v", err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected the Dry Run to return %+v but got %+v", expected, results)
+	}
+	if ids := server.ResourceIds(); len(ids) != 11 {
+		t.Fatalf("expected a Dry Run to not remove any resources but got %+v", ids)
+	}
+
+	options.DryRun = false
+	results, err = sweep.Run(ctx, client, options)
+	if err != nil {
+		t.Fatalf("running the Sweepers: %+v", err)
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Fatalf("expected the Sweepers to remove %+v but got %+v", expected, results)
+	}
+
+	remaining := server.ResourceIds()
+	expectedRemaining := make([]string, 0)
+	for _, id := range retained {
+		expectedRemaining = append(expectedRemaining, strings.ToLower(id))
+	}
+	for i := range remaining {
+		remaining[i] = strings.ToLower(remaining[i])
+	}
+	sort.Strings(remaining)
+	sort.Strings(expectedRemaining)
+	if !reflect.DeepEqual(remaining, expectedRemaining) {
+		t.Fatalf("expected the remaining resources to be %+v but got %+v", expectedRemaining, remaining)
+	}
+}
+
+func TestRun_UnknownSweeper(t *testing.T) {
+	if _, err := sweep.Run(context.Background(), nil, sweep.DefaultOptions(), "does_not_exist"); err == nil {
+		t.Fatalf("expected an error when running a Sweeper which isn't registered")
+	}
+}
@@ -83,3 +83,23 @@ func Build() (*clients.Client, error) {
 
 	return _client, nil
 }
+
+// Override replaces the client returned by Build with the specified client, returning a function
+// which restores the previous client - this is used when running tests against the Mock ARM Server.
+//
+// Since the client is shared by every test within the package, tests which override it must not be run
+// in parallel (using `t.Parallel()`).
+func Override(client *clients.Client) func() {
+	clientLock.Lock()
+	defer clientLock.Unlock()
+
+	previous := _client
+	_client = client
+
+	return func() {
+		clientLock.Lock()
+		defer clientLock.Unlock()
+
+		_client = previous
+	}
+}
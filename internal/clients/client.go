@@ -80,6 +80,7 @@ import (
 	healthcare "github.com/hashicorp/terraform-provider-azurerm/internal/services/healthcare/client"
 	hsm "github.com/hashicorp/terraform-provider-azurerm/internal/services/hsm/client"
 	hybridcompute "github.com/hashicorp/terraform-provider-azurerm/internal/services/hybridcompute/client"
+	imagebuilder "github.com/hashicorp/terraform-provider-azurerm/internal/services/imagebuilder/client"
 	iotcentral "github.com/hashicorp/terraform-provider-azurerm/internal/services/iotcentral/client"
 	iothub "github.com/hashicorp/terraform-provider-azurerm/internal/services/iothub/client"
 	timeseriesinsights "github.com/hashicorp/terraform-provider-azurerm/internal/services/iottimeseriesinsights/client"
@@ -151,6 +152,7 @@ import (
 	voiceServices "github.com/hashicorp/terraform-provider-azurerm/internal/services/voiceservices/client"
 	web "github.com/hashicorp/terraform-provider-azurerm/internal/services/web/client"
 	workloads "github.com/hashicorp/terraform-provider-azurerm/internal/services/workloads/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 )
 
 type Client struct {
@@ -162,6 +164,9 @@ type Client struct {
 	Account  *ResourceManagerAccount
 	Features features.UserFeatures
 
+	// TagPolicy is the (optional) policy which the tags of each resource must comply with
+	TagPolicy *tags.Policy
+
 	AadB2c                            *aadb2c_v2021_04_01_preview.Client
 	Advisor                           *advisor.Client
 	AnalysisServices                  *analysisservices_v2017_08_01.Client
@@ -217,6 +222,7 @@ type Client struct {
 	HDInsight                         *hdinsight_v2021_06_01.Client
 	HybridCompute                     *hybridcompute.Client
 	HealthCare                        *healthcare.Client
+	ImageBuilder                      *imagebuilder.Client
 	IoTCentral                        *iotcentral.Client
 	IoTHub                            *iothub.Client
 	IoTTimeSeriesInsights             *timeseriesinsights_v2020_05_15.Client
@@ -466,6 +472,7 @@ func (client *Client) Build(ctx context.Context, o *common.ClientOptions) error
 	if client.HybridCompute, err = hybridcompute.NewClient(o); err != nil {
 		return fmt.Errorf("building clients for HybridCompute: %+v", err)
 	}
+	client.ImageBuilder = imagebuilder.NewClient(o)
 	if client.IoTCentral, err = iotcentral.NewClient(o); err != nil {
 		return fmt.Errorf("building clients for IoTCentral: %+v", err)
 	}
@@ -74,6 +74,8 @@ func (o ClientOptions) Configure(c client.BaseClient, authorizer auth.Authorizer
 	}
 
 	c.AppendRequestMiddleware(requestLoggerMiddleware("AzureRM"))
+	c.AppendRequestMiddleware(tracingRequestMiddleware())
+	c.AppendResponseMiddleware(tracingResponseMiddleware())
 	c.AppendResponseMiddleware(responseLoggerMiddleware("AzureRM"))
 }
 
@@ -82,7 +84,7 @@ func (o ClientOptions) ConfigureClient(c *autorest.Client, authorizer autorest.A
 	c.UserAgent = userAgent(c.UserAgent, o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID)
 
 	c.Authorizer = authorizer
-	c.Sender = sender.BuildSender("AzureRM")
+	c.Sender = autorest.DecorateSender(sender.BuildSender("AzureRM"), withTracing())
 	c.SkipResourceProviderRegistration = o.SkipProviderReg
 	if !o.DisableCorrelationRequestID {
 		id := o.CustomCorrelationRequestID
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"runtime"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// HTTPClient returns an http.Client for APIs which aren't supported by an SDK (for example the data plane of a
+// Container Registry) - which sends requests using the same User Agent, Correlation Request ID, logging, tracing,
+// proxy and retry configuration as the clients configured using Configure. The bodies of requests to (and responses
+// from) OAuth2 endpoints aren't logged, since these contain credentials.
+func (o ClientOptions) HTTPClient() *http.Client {
+	requestMiddlewares := make([]client.RequestMiddleware, 0)
+	if !o.DisableCorrelationRequestID {
+		id := o.CustomCorrelationRequestID
+		if id == "" {
+			id = correlationRequestID()
+		}
+		requestMiddlewares = append(requestMiddlewares, correlationRequestIDMiddleware(id))
+	}
+	requestMiddlewares = append(requestMiddlewares, credentialRequestLoggerMiddleware("AzureRM"), tracingRequestMiddleware())
+
+	tlsConfig := tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	transport := &middlewareTransport{
+		userAgent:           userAgent("", o.TerraformVersion, o.PartnerId, o.DisableTerraformPartnerID),
+		requestMiddlewares:  requestMiddlewares,
+		responseMiddlewares: []client.ResponseMiddleware{tracingResponseMiddleware(), credentialResponseLoggerMiddleware("AzureRM")},
+		next: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				d := &net.Dialer{Resolver: &net.Resolver{}}
+				return d.DialContext(ctx, network, addr)
+			},
+			TLSClientConfig:       &tlsConfig,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConnsPerHost:   runtime.GOMAXPROCS(0) + 1,
+		},
+	}
+
+	// the retry configuration matches that used by hashicorp/go-azure-sdk, however the last response is returned
+	// once the retries are exhausted so that callers can inspect the status code
+	r := retryablehttp.NewClient()
+	r.HTTPClient = &http.Client{
+		Transport: transport,
+	}
+	r.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	r.RetryWaitMin = 1 * time.Second
+	r.RetryWaitMax = 61 * time.Second
+	r.RetryMax = 16
+	return r.StandardClient()
+}
+
+// middlewareTransport applies the request and response middlewares used by the SDK clients to each request
+type middlewareTransport struct {
+	userAgent           string
+	requestMiddlewares  []client.RequestMiddleware
+	responseMiddlewares []client.ResponseMiddleware
+	next                http.RoundTripper
+}
+
+func (t *middlewareTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper mustn't modify the request
+	req = req.Clone(req.Context())
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	var err error
+	for _, m := range t.requestMiddlewares {
+		if req, err = m(req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range t.responseMiddlewares {
+		if resp, err = m(req, resp); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package common
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHTTPClient(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+
+		if !strings.Contains(r.Header.Get("User-Agent"), "terraform-provider-azurerm/") {
+			t.Errorf("expected the provider User Agent but got %q", r.Header.Get("User-Agent"))
+		}
+		if r.Header.Get(HeaderCorrelationRequestID) != "correlation1" {
+			t.Errorf("expected the Correlation Request ID %q but got %q", "correlation1", r.Header.Get(HeaderCorrelationRequestID))
+		}
+
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	options := ClientOptions{
+		CustomCorrelationRequestID: "correlation1",
+		TerraformVersion:           "1.0.0",
+	}
+	resp, err := options.HTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the status %d but got %d", http.StatusOK, resp.StatusCode)
+	}
+	if attempts != 2 {
+		t.Fatalf("expected the throttled request to be retried but got %d attempts", attempts)
+	}
+}
+
+func TestHTTPClient_returnsLastResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	resp, err := ClientOptions{}.HTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected the status %d but got %d", http.StatusNotFound, resp.StatusCode)
+	}
+}
+
+func TestHTTPClient_omitsCredentialsFromLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/oauth2/") {
+			w.Write([]byte(`{"refresh_token": "refresh-secret"}`))
+			return
+		}
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"token": "bearer-secret"}`))
+			return
+		}
+		w.Write([]byte(`{"name": "repository1"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	client := ClientOptions{}.HTTPClient()
+	resp, err := client.PostForm(server.URL+"/oauth2/exchange", url.Values{"access_token": []string{"access-secret"}})
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+
+	// the token endpoint of a registry can be at any path, so the request is marked as requesting credentials
+	req, err := http.NewRequestWithContext(WithCredentialRequest(context.Background()), http.MethodGet, server.URL+"/token?scope=registry%3Acatalog%3A%2A", http.NoBody)
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+
+	// the query string of a SAS URL contains the signature
+	req, err = http.NewRequestWithContext(WithCredentialRequest(context.Background()), http.MethodPut, server.URL+"/disk?comp=page&sig=sas-secret", strings.NewReader("page-secret"))
+	if err != nil {
+		t.Fatalf("building request: %+v", err)
+	}
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+
+	resp, err = client.Get(server.URL + "/v2/_catalog")
+	if err != nil {
+		t.Fatalf("sending request: %+v", err)
+	}
+	resp.Body.Close()
+
+	for _, secret := range []string{"access-secret", "refresh-secret", "bearer-secret", "sas-secret", "page-secret"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Fatalf("expected %q to be omitted from the logs but got:\n%s", secret, logs.String())
+		}
+	}
+	if !strings.Contains(logs.String(), "repository1") {
+		t.Fatalf("expected the body of other responses to be logged but got:\n%s", logs.String())
+	}
+}
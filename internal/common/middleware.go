@@ -4,11 +4,16 @@
 package common
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"net/http/httputil"
+	"net/url"
+	"strings"
 
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/go-azure-sdk/sdk/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
 )
 
 func correlationRequestIDMiddleware(id string) client.RequestMiddleware {
@@ -57,3 +62,86 @@ func responseLoggerMiddleware(providerName string) client.ResponseMiddleware {
 		return response, nil
 	}
 }
+
+type credentialRequestContextKey struct{}
+
+// WithCredentialRequest returns a Context which marks the requests sent using it as containing credentials (for example
+// requesting a Bearer token from the `realm` of a registry's authentication challenge, or writing to a SAS URL), such
+// that the query string and the body of the request and its response are never logged
+func WithCredentialRequest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, credentialRequestContextKey{}, true)
+}
+
+// credentialRequestLoggerMiddleware logs requests in the same way as requestLoggerMiddleware, except that the query
+// string and body are omitted for requests which are marked using WithCredentialRequest or are to an OAuth2 endpoint
+// (for example `/oauth2/exchange` on a Container Registry), since these contain access and refresh tokens
+func credentialRequestLoggerMiddleware(providerName string) client.RequestMiddleware {
+	logger := requestLoggerMiddleware(providerName)
+	return func(request *http.Request) (*http.Request, error) {
+		if !isCredentialRequest(request) {
+			return logger(request)
+		}
+
+		log.Printf("[DEBUG] %s Request: %s to %s (query string and body omitted since these contain credentials)\n", providerName, request.Method, withoutQueryString(request.URL))
+		return request, nil
+	}
+}
+
+// credentialResponseLoggerMiddleware logs responses in the same way as responseLoggerMiddleware, except that the body
+// is omitted for responses to a credential request (see credentialRequestLoggerMiddleware), since these contain access
+// and refresh tokens
+func credentialResponseLoggerMiddleware(providerName string) client.ResponseMiddleware {
+	logger := responseLoggerMiddleware(providerName)
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		if !isCredentialRequest(request) {
+			return logger(request, response)
+		}
+
+		log.Printf("[DEBUG] %s Response: %s for %s (query string and body omitted since these contain credentials)\n", providerName, response.Status, withoutQueryString(request.URL))
+		return response, nil
+	}
+}
+
+func isCredentialRequest(request *http.Request) bool {
+	if v, ok := request.Context().Value(credentialRequestContextKey{}).(bool); ok && v {
+		return true
+	}
+
+	return request.URL != nil && strings.HasPrefix(strings.ToLower(request.URL.Path), "/oauth2/")
+}
+
+func withoutQueryString(input *url.URL) string {
+	if input == nil {
+		return ""
+	}
+
+	output := *input
+	output.RawQuery = ""
+	output.ForceQuery = false
+	return output.String()
+}
+
+func tracingRequestMiddleware() client.RequestMiddleware {
+	return func(request *http.Request) (*http.Request, error) {
+		return tracing.WithRequestStartTime(request), nil
+	}
+}
+
+func tracingResponseMiddleware() client.ResponseMiddleware {
+	return func(request *http.Request, response *http.Response) (*http.Response, error) {
+		tracing.RecordRequest(request, response, nil)
+		return response, nil
+	}
+}
+
+// withTracing records a Span for each request sent using a go-autorest Sender
+func withTracing() autorest.SendDecorator {
+	return func(s autorest.Sender) autorest.Sender {
+		return autorest.SenderFunc(func(r *http.Request) (*http.Response, error) {
+			r = tracing.WithRequestStartTime(r)
+			resp, err := s.Do(r)
+			tracing.RecordRequest(r, resp, err)
+			return resp, err
+		})
+	}
+}
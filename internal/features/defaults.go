@@ -35,6 +35,9 @@ func Default() UserFeatures {
 			PurgeSoftDeletedHSMKeysOnDestroy: true,
 			RecoverSoftDeletedHSMKeys:        true,
 		},
+		KubernetesCluster: KubernetesClusterFeatures{
+			PauseNodePoolUpgradeOnFailure: false,
+		},
 		LogAnalyticsWorkspace: LogAnalyticsWorkspaceFeatures{
 			PermanentlyDeleteOnDestroy: true,
 		},
@@ -43,12 +46,15 @@ func Default() UserFeatures {
 		},
 		ResourceGroup: ResourceGroupFeatures{
 			PreventDeletionIfContainsResources: true,
+			DeleteNestedResourcesInOrder:       false,
+			RemoveManagementLocksDuringDelete:  false,
 		},
 		RecoveryServicesVault: RecoveryServicesVault{
 			RecoverSoftDeletedBackupProtectedVM: true,
 		},
 		TemplateDeployment: TemplateDeploymentFeatures{
 			DeleteNestedItemsDuringDeletion: true,
+			WhatIfDuringPlan:                false,
 		},
 		VirtualMachine: VirtualMachineFeatures{
 			DeleteOSDiskOnDeletion:     true,
@@ -64,6 +70,10 @@ func Default() UserFeatures {
 		Subscription: SubscriptionFeatures{
 			PreventCancellationOnDestroy: false,
 		},
+		ManagementLock: ManagementLockFeatures{
+			LiftParentLocksDuringUpdateAndDelete: false,
+			LiftSubscriptionLocks:                false,
+		},
 		PostgresqlFlexibleServer: PostgresqlFlexibleServerFeatures{
 			RestartServerOnConfigurationValueChange: true,
 		},
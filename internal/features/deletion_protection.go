@@ -0,0 +1,32 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreventsDeletion returns an error when the deletion of the specified resource is prevented by the
+// `deletion_protection` feature - either since it's one of the protected Resource Types or since the
+// resource has the protected tag (compared case-insensitively, as tag names are in Azure).
+func (f DeletionProtectionFeatures) PreventsDeletion(resourceType string, id string, tags map[string]interface{}) error {
+	for _, v := range f.ResourceTypes {
+		if strings.EqualFold(v, resourceType) {
+			return fmt.Errorf("deleting %s: the Resource Type %q is protected from deletion by the `deletion_protection` feature - to delete this resource remove %q from `resource_types` within the `features` block of the Provider", id, resourceType, resourceType)
+		}
+	}
+
+	if f.TagKey == "" {
+		return nil
+	}
+
+	for k := range tags {
+		if strings.EqualFold(k, f.TagKey) {
+			return fmt.Errorf("deleting %s: this resource has the tag %q and is protected from deletion by the `deletion_protection` feature - to delete this resource remove this tag and apply this change prior to deleting the resource", id, k)
+		}
+	}
+
+	return nil
+}
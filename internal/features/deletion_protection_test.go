@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import "testing"
+
+func TestDeletionProtectionPreventsDeletion(t *testing.T) {
+	testData := []struct {
+		name         string
+		features     DeletionProtectionFeatures
+		resourceType string
+		tags         map[string]interface{}
+		prevented    bool
+	}{
+		{
+			name:         "disabled",
+			features:     DeletionProtectionFeatures{},
+			resourceType: "azurerm_resource_group",
+			tags: map[string]interface{}{
+				"lock": "true",
+			},
+			prevented: false,
+		},
+		{
+			name: "tagged",
+			features: DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			resourceType: "azurerm_resource_group",
+			tags: map[string]interface{}{
+				"env":  "prod",
+				"lock": "",
+			},
+			prevented: true,
+		},
+		{
+			name: "tagged with a different casing",
+			features: DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			resourceType: "azurerm_resource_group",
+			tags: map[string]interface{}{
+				"Lock": "true",
+			},
+			prevented: true,
+		},
+		{
+			name: "not tagged",
+			features: DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			resourceType: "azurerm_resource_group",
+			tags: map[string]interface{}{
+				"env": "prod",
+			},
+			prevented: false,
+		},
+		{
+			name: "no tags",
+			features: DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			resourceType: "azurerm_resource_group",
+			prevented:    false,
+		},
+		{
+			name: "protected resource type",
+			features: DeletionProtectionFeatures{
+				ResourceTypes: []string{"azurerm_key_vault", "azurerm_resource_group"},
+			},
+			resourceType: "azurerm_resource_group",
+			prevented:    true,
+		},
+		{
+			name: "unprotected resource type",
+			features: DeletionProtectionFeatures{
+				TagKey:        "lock",
+				ResourceTypes: []string{"azurerm_key_vault"},
+			},
+			resourceType: "azurerm_resource_group",
+			tags: map[string]interface{}{
+				"env": "prod",
+			},
+			prevented: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := v.features.PreventsDeletion(v.resourceType, "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example", v.tags)
+		if prevented := err != nil; prevented != v.prevented {
+			t.Fatalf("expected deletion to be prevented to be %t but got %t (%+v)", v.prevented, prevented, err)
+		}
+	}
+}
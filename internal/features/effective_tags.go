@@ -0,0 +1,18 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package features
+
+import (
+	"os"
+	"strings"
+)
+
+// EffectiveTagsEnabled returns whether or not the computed `effective_tags` attribute should be added to each
+// Resource which supports tags.
+//
+// Since this changes the schema of each Resource (which is determined before the Provider block is configured)
+// this is opted into by setting the Environment Variable `ARM_PROVIDER_EFFECTIVE_TAGS` to `true`.
+func EffectiveTagsEnabled() bool {
+	return strings.EqualFold(os.Getenv("ARM_PROVIDER_EFFECTIVE_TAGS"), "true")
+}
@@ -8,15 +8,18 @@ type UserFeatures struct {
 	AppConfiguration         AppConfigurationFeatures
 	ApplicationInsights      ApplicationInsightFeatures
 	CognitiveAccount         CognitiveAccountFeatures
+	DeletionProtection       DeletionProtectionFeatures
 	VirtualMachine           VirtualMachineFeatures
 	VirtualMachineScaleSet   VirtualMachineScaleSetFeatures
 	KeyVault                 KeyVaultFeatures
+	KubernetesCluster        KubernetesClusterFeatures
 	TemplateDeployment       TemplateDeploymentFeatures
 	LogAnalyticsWorkspace    LogAnalyticsWorkspaceFeatures
 	ResourceGroup            ResourceGroupFeatures
 	RecoveryServicesVault    RecoveryServicesVault
 	ManagedDisk              ManagedDiskFeatures
 	Subscription             SubscriptionFeatures
+	ManagementLock           ManagementLockFeatures
 	PostgresqlFlexibleServer PostgresqlFlexibleServerFeatures
 	MachineLearning          MachineLearningFeatures
 	RecoveryService          RecoveryServiceFeatures
@@ -26,6 +29,11 @@ type CognitiveAccountFeatures struct {
 	PurgeSoftDeleteOnDestroy bool
 }
 
+type DeletionProtectionFeatures struct {
+	TagKey        string
+	ResourceTypes []string
+}
+
 type VirtualMachineFeatures struct {
 	DeleteOSDiskOnDeletion     bool
 	GracefulShutdown           bool
@@ -53,8 +61,13 @@ type KeyVaultFeatures struct {
 	RecoverSoftDeletedHSMKeys        bool
 }
 
+type KubernetesClusterFeatures struct {
+	PauseNodePoolUpgradeOnFailure bool
+}
+
 type TemplateDeploymentFeatures struct {
 	DeleteNestedItemsDuringDeletion bool
+	WhatIfDuringPlan                bool
 }
 
 type LogAnalyticsWorkspaceFeatures struct {
@@ -63,6 +76,8 @@ type LogAnalyticsWorkspaceFeatures struct {
 
 type ResourceGroupFeatures struct {
 	PreventDeletionIfContainsResources bool
+	DeleteNestedResourcesInOrder       bool
+	RemoveManagementLocksDuringDelete  bool
 }
 
 type ApiManagementFeatures struct {
@@ -83,6 +98,11 @@ type AppConfigurationFeatures struct {
 	RecoverSoftDeleted       bool
 }
 
+type ManagementLockFeatures struct {
+	LiftParentLocksDuringUpdateAndDelete bool
+	LiftSubscriptionLocks                bool
+}
+
 type SubscriptionFeatures struct {
 	PreventCancellationOnDestroy bool
 }
@@ -0,0 +1,430 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/managementlocks"
+)
+
+// liftedManagementLock is a Management Lock which has been temporarily removed, along with the number of
+// operations which currently depend on it being removed
+type liftedManagementLock struct {
+	id         managementlocks.ScopedLockId
+	properties managementlocks.ManagementLockProperties
+	references int
+
+	// removed is closed once the Management Lock has been removed, or removing it failed (in which case err is set)
+	removed chan struct{}
+	err     error
+
+	// restored is set whilst the Management Lock is being recreated, and closed once it has been
+	restored chan struct{}
+}
+
+var (
+	// liftedManagementLocksMutex guards the bookkeeping below, but isn't held whilst calling the API so that
+	// operations on unrelated Resources aren't serialized
+	liftedManagementLocks      = make(map[string]*liftedManagementLock)
+	liftedManagementLocksMutex sync.Mutex
+
+	// restoredManagementLocks is the number of times a lifted Management Lock has been recreated, keyed by its scope
+	restoredManagementLocks = make(map[string]int)
+)
+
+// managementLockRestoreTimeout is the time allowed to recreate the lifted Management Locks, which is used in place
+// of the timeout for the operation so that the Management Locks are restored when the operation timed out
+const managementLockRestoreTimeout = 5 * time.Minute
+
+// IsOwnedBy returns whether the Application ID is recorded as an Owner of the Management Lock - which is how the
+// `azurerm_management_lock` resource marks the Management Locks which Terraform may temporarily remove, when
+// `lift_during_nested_changes_enabled` is set.
+func IsOwnedBy(properties managementlocks.ManagementLockProperties, applicationId string) bool {
+	if properties.Owners == nil || applicationId == "" {
+		return false
+	}
+
+	for _, owner := range *properties.Owners {
+		if strings.EqualFold(pointer.From(owner.ApplicationId), applicationId) {
+			return true
+		}
+	}
+	return false
+}
+
+// LiftedManagementLocks are the Management Locks which have been temporarily removed for an operation
+type LiftedManagementLocks struct {
+	client     *managementlocks.ManagementLocksClient
+	resourceId string
+
+	// referenced are the Management Locks this operation depends on, which must be released when restoring
+	referenced map[string]*liftedManagementLock
+
+	once     sync.Once
+	restored []managementlocks.ScopedLockId
+	err      error
+}
+
+// Lifted returns the IDs of the Management Locks which have been removed for the operation, including those which
+// had already been removed for another operation in progress
+func (l *LiftedManagementLocks) Lifted() []managementlocks.ScopedLockId {
+	ids := make([]managementlocks.ScopedLockId, 0, len(l.referenced))
+	for _, v := range l.referenced {
+		ids = append(ids, v.id)
+	}
+	sortManagementLockIds(ids)
+	return ids
+}
+
+// Restore recreates the lifted Management Locks which are no longer needed by another operation, this can safely
+// be called multiple times
+func (l *LiftedManagementLocks) Restore(ctx context.Context) error {
+	l.once.Do(func() {
+		l.restored, l.err = restoreManagementLocks(ctx, l.client, l.resourceId, l.referenced)
+	})
+	return l.err
+}
+
+// Restored returns the IDs of the Management Locks which have been recreated by Restore - which excludes those still
+// needed by another operation (which are recreated once that completes) and those whose scope has since been deleted
+func (l *LiftedManagementLocks) Restored() []managementlocks.ScopedLockId {
+	return l.restored
+}
+
+// WithLiftedManagementLocks temporarily removes the `CanNotDelete` and `ReadOnly` Management Locks owned by the
+// Application ID which are defined on the parent scopes of the Resource ID, calls `f` and then recreates the
+// Management Locks - regardless of whether `f` succeeded. Management Locks defined on the Resource itself are left
+// as-is, as are those defined on the Subscription unless `includeSubscription` is set.
+func WithLiftedManagementLocks(ctx context.Context, client *managementlocks.ManagementLocksClient, resourceId string, applicationId string, includeSubscription bool, f func() error) (*LiftedManagementLocks, error) {
+	lifted, err := LiftManagementLocks(ctx, client, resourceId, applicationId, includeSubscription)
+	if err != nil {
+		return nil, err
+	}
+
+	opErr := f()
+
+	if err := lifted.Restore(ctx); err != nil {
+		if opErr != nil {
+			return lifted, fmt.Errorf("%+v\n\nadditionally %+v", opErr, err)
+		}
+		return lifted, err
+	}
+
+	return lifted, opErr
+}
+
+// LiftManagementLocks temporarily removes the `CanNotDelete` and `ReadOnly` Management Locks owned by the Application
+// ID which are defined on the parent scopes of the Resource ID (excluding the Subscription unless `includeSubscription`
+// is set), which are recreated by calling Restore. Management Locks which aren't owned by the Application ID are left
+// as-is. Management Locks lifted for multiple Resources at once are only recreated once the last of these has been
+// restored.
+func LiftManagementLocks(ctx context.Context, client *managementlocks.ManagementLocksClient, resourceId string, applicationId string, includeSubscription bool) (*LiftedManagementLocks, error) {
+	return liftManagementLocks(ctx, client, managementLockSelector{
+		resourceId:    resourceId,
+		applicationId: applicationId,
+		// `atScope()` returns the Management Locks defined on the scope and all of its parent scopes
+		filter: pointer.To("atScope()"),
+		covers: func(scope string) bool {
+			// a Management Lock on the Subscription protects every Resource within it, so is only lifted when opted into
+			if !includeSubscription && isSubscriptionScope(scope) {
+				return false
+			}
+			return isParentScopeOf(scope, resourceId)
+		},
+	})
+}
+
+// LiftManagementLocksWithin temporarily removes the `CanNotDelete` and `ReadOnly` Management Locks defined on the
+// scope and the Resources within it, which are recreated by calling Restore. Management Locks whose scope has since
+// been deleted aren't recreated. An error listing the Management Locks which aren't owned by the Application ID is
+// returned (without removing any Management Locks) when these exist, since these would block deleting the scope.
+func LiftManagementLocksWithin(ctx context.Context, client *managementlocks.ManagementLocksClient, scope string, applicationId string) (*LiftedManagementLocks, error) {
+	return liftManagementLocks(ctx, client, managementLockSelector{
+		resourceId:    scope,
+		applicationId: applicationId,
+		failIfUnowned: true,
+		covers: func(lockScope string) bool {
+			return strings.EqualFold(strings.TrimSuffix(lockScope, "/"), strings.TrimSuffix(scope, "/")) || isParentScopeOf(scope, lockScope)
+		},
+	})
+}
+
+// managementLockSelector determines which Management Locks are lifted to modify a Resource
+type managementLockSelector struct {
+	// resourceId is the Resource being modified, which is also the scope the Management Locks are listed at
+	resourceId string
+	filter     *string
+
+	// applicationId is the Owner recorded on the Management Locks which can be lifted
+	applicationId string
+
+	// failIfUnowned specifies whether an error is returned when a Management Lock which applies to the Resource
+	// isn't owned by the Application ID, rather than leaving it as-is
+	failIfUnowned bool
+
+	// covers returns whether a Management Lock defined on the scope applies to the Resource
+	covers func(scope string) bool
+}
+
+// applies returns whether the Management Lock prevents the Resource from being modified or deleted
+func (s managementLockSelector) applies(id managementlocks.ScopedLockId, properties managementlocks.ManagementLockProperties) bool {
+	if properties.Level != managementlocks.LockLevelCanNotDelete && properties.Level != managementlocks.LockLevelReadOnly {
+		return false
+	}
+
+	return s.covers(id.Scope)
+}
+
+// matches returns whether the Management Lock applies to the Resource and can be lifted
+func (s managementLockSelector) matches(id managementlocks.ScopedLockId, properties managementlocks.ManagementLockProperties) bool {
+	return s.applies(id, properties) && IsOwnedBy(properties, s.applicationId)
+}
+
+func liftManagementLocks(ctx context.Context, client *managementlocks.ManagementLocksClient, selector managementLockSelector) (*LiftedManagementLocks, error) {
+	resourceId := selector.resourceId
+
+	// the Management Locks referenced by this operation, which must be released when restoring
+	referenced := make(map[string]*liftedManagementLock)
+
+	for {
+		// Management Locks which are being recreated must be present before listing, else they'd be omitted
+		liftedManagementLocksMutex.Lock()
+		if restoring := restoringManagementLock(selector); restoring != nil {
+			liftedManagementLocksMutex.Unlock()
+			<-restoring
+			continue
+		}
+
+		// Management Locks which have already been lifted for another Resource won't be returned from the API
+		referenceLiftedManagementLocks(selector, referenced)
+		restoredBeforeListing := restoredManagementLocksCovering(selector)
+		liftedManagementLocksMutex.Unlock()
+
+		options := managementlocks.ListByScopeOperationOptions{
+			Filter: selector.filter,
+		}
+		existing, err := client.ListByScopeComplete(ctx, commonids.NewScopeID(resourceId), options)
+		if err != nil {
+			return nil, liftManagementLocksError(ctx, client, resourceId, referenced, fmt.Errorf("listing the Management Locks covering %q: %+v", resourceId, err))
+		}
+
+		candidates := make([]*liftedManagementLock, 0)
+		unowned := make([]string, 0)
+		for _, item := range existing.Items {
+			if item.Id == nil {
+				continue
+			}
+
+			lockId, err := managementlocks.ParseScopedLockIDInsensitively(*item.Id)
+			if err != nil {
+				return nil, liftManagementLocksError(ctx, client, resourceId, referenced, fmt.Errorf("parsing the Management Lock ID %q: %+v", *item.Id, err))
+			}
+			if !selector.applies(*lockId, item.Properties) {
+				continue
+			}
+			if !IsOwnedBy(item.Properties, selector.applicationId) {
+				log.Printf("[DEBUG] Leaving the %q Management Lock %q defined on %q as-is since it isn't owned by Terraform", string(item.Properties.Level), lockId.LockName, lockId.Scope)
+				unowned = append(unowned, fmt.Sprintf("* %s (Level %q, Notes %q)", lockId.ID(), string(item.Properties.Level), pointer.From(item.Properties.Notes)))
+				continue
+			}
+
+			candidates = append(candidates, &liftedManagementLock{
+				id:         *lockId,
+				properties: item.Properties,
+				references: 1,
+				removed:    make(chan struct{}),
+			})
+		}
+
+		if selector.failIfUnowned && len(unowned) > 0 {
+			sort.Strings(unowned)
+			err := fmt.Errorf("the following Management Locks covering %q weren't created using the `azurerm_management_lock` resource with `lift_during_nested_changes_enabled` set to `true` (by the same principal), so must be removed manually:\n\n%s", resourceId, strings.Join(unowned, "\n"))
+			return nil, liftManagementLocksError(ctx, client, resourceId, referenced, err)
+		}
+
+		liftedManagementLocksMutex.Lock()
+		// a Management Lock which was recreated whilst listing may have been omitted, so these must be listed again
+		if restoredManagementLocksCovering(selector) != restoredBeforeListing || restoringManagementLock(selector) != nil {
+			liftedManagementLocksMutex.Unlock()
+			continue
+		}
+
+		toRemove := make([]*liftedManagementLock, 0)
+		for _, v := range candidates {
+			key := strings.ToLower(v.id.ID())
+			if _, ok := liftedManagementLocks[key]; ok {
+				continue
+			}
+
+			liftedManagementLocks[key] = v
+			referenced[key] = v
+			toRemove = append(toRemove, v)
+		}
+
+		// Management Locks lifted for another Resource whilst listing
+		referenceLiftedManagementLocks(selector, referenced)
+		liftedManagementLocksMutex.Unlock()
+
+		for _, v := range toRemove {
+			// should the Management Lock not be recreated (for example if Terraform is interrupted) its definition is
+			// logged so that it can be recreated manually
+			definition, _ := json.Marshal(managementlocks.ManagementLockObject{Properties: v.properties})
+			log.Printf("[WARN] Temporarily removing the Management Lock %q to modify %q, which has the definition: %s", v.id.ID(), resourceId, string(definition))
+			if _, err := client.DeleteByScope(ctx, v.id); err != nil {
+				v.err = fmt.Errorf("temporarily removing %s: %+v", v.id, err)
+			}
+			close(v.removed)
+		}
+		break
+	}
+
+	// Management Locks lifted for another Resource may still be being removed
+	for _, v := range referenced {
+		<-v.removed
+		if v.err != nil {
+			return nil, liftManagementLocksError(ctx, client, resourceId, referenced, v.err)
+		}
+	}
+
+	return &LiftedManagementLocks{
+		client:     client,
+		resourceId: resourceId,
+		referenced: referenced,
+	}, nil
+}
+
+// restoringManagementLock returns the channel for a Management Lock matching the selector which is currently
+// being recreated (if any), the caller must hold the mutex
+func restoringManagementLock(selector managementLockSelector) chan struct{} {
+	for _, v := range liftedManagementLocks {
+		if v.restored != nil && selector.matches(v.id, v.properties) {
+			return v.restored
+		}
+	}
+	return nil
+}
+
+// restoredManagementLocksCovering returns the number of times a Management Lock defined on a scope covered by the
+// selector has been recreated, the caller must hold the mutex
+func restoredManagementLocksCovering(selector managementLockSelector) int {
+	count := 0
+	for scope, v := range restoredManagementLocks {
+		if selector.covers(scope) {
+			count += v
+		}
+	}
+	return count
+}
+
+// referenceLiftedManagementLocks references the lifted Management Locks matching the selector which aren't
+// already referenced, the caller must hold the mutex
+func referenceLiftedManagementLocks(selector managementLockSelector, referenced map[string]*liftedManagementLock) {
+	for key, v := range liftedManagementLocks {
+		if _, ok := referenced[key]; ok {
+			continue
+		}
+		if v.restored == nil && selector.matches(v.id, v.properties) {
+			v.references++
+			referenced[key] = v
+		}
+	}
+}
+
+// liftManagementLocksError restores the Management Locks lifted prior to an error
+func liftManagementLocksError(ctx context.Context, client *managementlocks.ManagementLocksClient, resourceId string, referenced map[string]*liftedManagementLock, err error) error {
+	if _, rErr := restoreManagementLocks(ctx, client, resourceId, referenced); rErr != nil {
+		return fmt.Errorf("%+v\n\nadditionally %+v", err, rErr)
+	}
+
+	return err
+}
+
+// restoreManagementLocks releases the referenced Management Locks, recreating those which are no longer needed and
+// returning the IDs of the Management Locks which were recreated
+func restoreManagementLocks(ctx context.Context, client *managementlocks.ManagementLocksClient, resourceId string, referenced map[string]*liftedManagementLock) ([]managementlocks.ScopedLockId, error) {
+	liftedManagementLocksMutex.Lock()
+	toRestore := make([]*liftedManagementLock, 0)
+	for key, v := range referenced {
+		v.references--
+		if v.references > 0 {
+			continue
+		}
+
+		// the operation which removed the Management Lock references it until this has completed - and when
+		// the Management Lock couldn't be removed it still exists, so there's nothing to recreate
+		if v.err != nil {
+			delete(liftedManagementLocks, key)
+			continue
+		}
+
+		v.restored = make(chan struct{})
+		toRestore = append(toRestore, v)
+	}
+	liftedManagementLocksMutex.Unlock()
+
+	// the Management Locks must be restored even when the operation timed out or was cancelled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), managementLockRestoreTimeout)
+	defer cancel()
+
+	restored := make([]managementlocks.ScopedLockId, 0)
+	errors := make([]string, 0)
+	for _, v := range toRestore {
+		log.Printf("[INFO] Restoring the %q Management Lock %q defined on %q after modifying %q", string(v.properties.Level), v.id.LockName, v.id.Scope, resourceId)
+		payload := managementlocks.ManagementLockObject{
+			Properties: v.properties,
+		}
+		if resp, err := client.CreateOrUpdateByScope(ctx, v.id, payload); err != nil {
+			// the scope has since been deleted (for example the Resource Group which contained it)
+			if response.WasNotFound(resp.HttpResponse) {
+				log.Printf("[DEBUG] Skipping restoring the Management Lock %q since %q no longer exists", v.id.LockName, v.id.Scope)
+				continue
+			}
+			errors = append(errors, fmt.Sprintf("* %s (Level %q, Notes %q): %+v", v.id, string(v.properties.Level), pointer.From(v.properties.Notes), err))
+			continue
+		}
+		restored = append(restored, v.id)
+	}
+	sortManagementLockIds(restored)
+
+	liftedManagementLocksMutex.Lock()
+	for _, v := range toRestore {
+		delete(liftedManagementLocks, strings.ToLower(v.id.ID()))
+		restoredManagementLocks[strings.ToLower(v.id.Scope)]++
+		close(v.restored)
+	}
+	liftedManagementLocksMutex.Unlock()
+
+	if len(errors) > 0 {
+		return restored, fmt.Errorf("restoring the following Management Locks, which must be recreated manually:\n\n%s", strings.Join(errors, "\n"))
+	}
+
+	return restored, nil
+}
+
+func isParentScopeOf(scope string, resourceId string) bool {
+	return strings.HasPrefix(strings.ToLower(resourceId), strings.TrimSuffix(strings.ToLower(scope), "/")+"/")
+}
+
+// isSubscriptionScope returns whether the scope is a Subscription, e.g. `/subscriptions/00000000-0000-0000-0000-000000000000`
+func isSubscriptionScope(scope string) bool {
+	segments := strings.Split(strings.Trim(scope, "/"), "/")
+	return len(segments) == 2 && strings.EqualFold(segments[0], "subscriptions")
+}
+
+func sortManagementLockIds(ids []managementlocks.ScopedLockId) {
+	sort.Slice(ids, func(i, j int) bool {
+		return strings.ToLower(ids[i].ID()) < strings.ToLower(ids[j].ID())
+	})
+}
@@ -0,0 +1,401 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/managementlocks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+)
+
+// NOTE: the lifted Management Locks are tracked across the Provider, so each test uses a distinct Subscription
+
+// testManagementLocksOwner is the Application ID recorded as an Owner of the Management Locks which can be lifted
+const testManagementLocksOwner = "11111111-1111-1111-1111-111111111111"
+
+func TestLiftManagementLocks_referenceCounted(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	subscriptionId := "/subscriptions/00000000-0000-0000-0000-000000000040"
+	resourceGroupId := subscriptionId + "/resourceGroups/group1"
+	first := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+	second := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network2"
+
+	subscriptionLock := testManagementLocksPutLock(server, subscriptionId, "subscription", managementlocks.LockLevelReadOnly, testManagementLocksOwner)
+	resourceGroupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	resourceLock := testManagementLocksPutLock(server, first, "resource", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	otherLock := testManagementLocksPutLock(server, subscriptionId+"/resourceGroups/group2", "other", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+
+	restoreFirst, err := locks.LiftManagementLocks(ctx, client, first, testManagementLocksOwner, true)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", first, err)
+	}
+	restoreSecond, err := locks.LiftManagementLocks(ctx, client, second, testManagementLocksOwner, true)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", second, err)
+	}
+
+	// the Management Locks on the Resource itself, or on unrelated scopes, are left as-is
+	testManagementLocksExpectExists(t, server, resourceLock, true)
+	testManagementLocksExpectExists(t, server, otherLock, true)
+	testManagementLocksExpectExists(t, server, subscriptionLock, false)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+
+	// the Management Locks are still needed for the second Resource
+	if err := restoreFirst.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", first, err)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, false)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+	testManagementLocksExpectIds(t, restoreFirst.Lifted(), subscriptionLock, resourceGroupLock)
+	testManagementLocksExpectIds(t, restoreFirst.Restored())
+
+	// restoring is idempotent, so this mustn't release the references held for the second Resource
+	if err := restoreFirst.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", first, err)
+	}
+	testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+
+	if err := restoreSecond.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", second, err)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, true)
+	testManagementLocksExpectIds(t, restoreSecond.Restored(), subscriptionLock, resourceGroupLock)
+
+	// each Management Lock should only have been removed and recreated once
+	for _, id := range []string{subscriptionLock, resourceGroupLock} {
+		if count := testManagementLocksCountRequests(server, http.MethodDelete, id); count != 1 {
+			t.Fatalf("expected %q to be removed once but got %d requests", id, count)
+		}
+		if count := testManagementLocksCountRequests(server, http.MethodPut, id); count != 1 {
+			t.Fatalf("expected %q to be recreated once but got %d requests", id, count)
+		}
+	}
+
+	lock, _ := server.Get(resourceGroupLock)
+	if notes := lock["properties"].(map[string]interface{})["notes"]; notes != "group" {
+		t.Fatalf("expected the Notes for %q to be restored but got %v", resourceGroupLock, notes)
+	}
+}
+
+func TestLiftManagementLocks_concurrent(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	resourceGroupId := "/subscriptions/00000000-0000-0000-0000-000000000140/resourceGroups/group1"
+	resourceGroupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+
+	const count = 10
+	lifted := &sync.WaitGroup{}
+	lifted.Add(count)
+	release := make(chan struct{})
+	errs := make(chan error, count)
+	done := &sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		done.Add(1)
+		go func(resourceId string) {
+			defer done.Done()
+
+			restore, err := locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, false)
+			lifted.Done()
+			if err != nil {
+				errs <- err
+				return
+			}
+
+			<-release
+			if err := restore.Restore(ctx); err != nil {
+				errs <- err
+			}
+		}(fmt.Sprintf("%s/providers/Microsoft.Network/virtualNetworks/network%d", resourceGroupId, i))
+	}
+
+	lifted.Wait()
+	testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+	close(release)
+	done.Wait()
+
+	close(errs)
+	for err := range errs {
+		t.Fatalf("lifting the Management Locks: %+v", err)
+	}
+	testManagementLocksExpectExists(t, server, resourceGroupLock, true)
+	if count := testManagementLocksCountRequests(server, http.MethodPut, resourceGroupLock); count != 1 {
+		t.Fatalf("expected %q to be recreated once but got %d requests", resourceGroupLock, count)
+	}
+}
+
+func TestLiftManagementLocks_restoresOnFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	subscriptionId := "/subscriptions/00000000-0000-0000-0000-000000000240"
+	resourceGroupId := subscriptionId + "/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+	subscriptionLock := testManagementLocksPutLock(server, subscriptionId, "subscription", managementlocks.LockLevelReadOnly, testManagementLocksOwner)
+	resourceGroupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+
+	// the Resource Group's Management Lock can't be removed, so the Subscription's Management Lock must be recreated
+	server.InjectError(http.MethodDelete, regexp.QuoteMeta(resourceGroupLock)+"$", 1, http.StatusForbidden, "AuthorizationFailed")
+
+	if _, err := locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, true); err == nil {
+		t.Fatalf("expected an error lifting the Management Locks for %q but didn't get one", resourceId)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, true)
+
+	// the Management Locks should no longer be tracked as lifted, so can be lifted again
+	restore, err := locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, true)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, false)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+	if err := restore.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+	testManagementLocksExpectExists(t, server, resourceGroupLock, true)
+}
+
+func TestWithLiftedManagementLocks_operationFailed(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	resourceGroupId := "/subscriptions/00000000-0000-0000-0000-000000000340/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+	resourceGroupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+
+	expected := errors.New("deleting the Resource")
+	lifted, err := locks.WithLiftedManagementLocks(ctx, client, resourceId, testManagementLocksOwner, false, func() error {
+		testManagementLocksExpectExists(t, server, resourceGroupLock, false)
+		return expected
+	})
+	if !errors.Is(err, expected) {
+		t.Fatalf("expected the error from the operation but got %+v", err)
+	}
+	testManagementLocksExpectExists(t, server, resourceGroupLock, true)
+	testManagementLocksExpectIds(t, lifted.Lifted(), resourceGroupLock)
+	testManagementLocksExpectIds(t, lifted.Restored(), resourceGroupLock)
+}
+
+func TestLiftManagementLocks_subscriptionOptIn(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	subscriptionId := "/subscriptions/00000000-0000-0000-0000-000000000540"
+	resourceGroupId := subscriptionId + "/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+
+	groupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	subscriptionLock := testManagementLocksPutLock(server, subscriptionId, "subscription", managementlocks.LockLevelReadOnly, testManagementLocksOwner)
+
+	// the Management Locks on the Subscription are only lifted when opted into
+	lifted, err := locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, false)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, groupLock, false)
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+	testManagementLocksExpectIds(t, lifted.Lifted(), groupLock)
+	if err := lifted.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, groupLock, true)
+
+	lifted, err = locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, true)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, groupLock, false)
+	testManagementLocksExpectExists(t, server, subscriptionLock, false)
+	testManagementLocksExpectIds(t, lifted.Lifted(), subscriptionLock, groupLock)
+	if err := lifted.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+	testManagementLocksExpectIds(t, lifted.Restored(), subscriptionLock, groupLock)
+}
+
+func TestLiftManagementLocksWithin(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	subscriptionId := "/subscriptions/00000000-0000-0000-0000-000000000440"
+	resourceGroupId := subscriptionId + "/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+
+	groupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, "44444444-4444-4444-4444-444444444444", testManagementLocksOwner)
+	resourceLock := testManagementLocksPutLock(server, resourceId, "resource", managementlocks.LockLevelReadOnly, testManagementLocksOwner)
+	otherGroupLock := testManagementLocksPutLock(server, subscriptionId+"/resourceGroups/group2", "other", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	subscriptionLock := testManagementLocksPutLock(server, subscriptionId, "subscription", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+
+	restore, err := locks.LiftManagementLocksWithin(ctx, client, resourceGroupId, testManagementLocksOwner)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks within %q: %+v", resourceGroupId, err)
+	}
+
+	// only the Management Locks within the Resource Group are lifted
+	testManagementLocksExpectExists(t, server, groupLock, false)
+	testManagementLocksExpectExists(t, server, resourceLock, false)
+	testManagementLocksExpectExists(t, server, otherGroupLock, true)
+	testManagementLocksExpectExists(t, server, subscriptionLock, true)
+
+	// the Virtual Network has since been deleted, so its Management Lock can't be recreated
+	server.InjectNotFound(http.MethodPut, regexp.QuoteMeta(resourceLock)+"$", 1)
+
+	if err := restore.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks within %q: %+v", resourceGroupId, err)
+	}
+	testManagementLocksExpectExists(t, server, groupLock, true)
+	testManagementLocksExpectExists(t, server, resourceLock, false)
+
+	lock, _ := server.Get(groupLock)
+	if !strings.Contains(fmt.Sprintf("%v", lock["properties"]), "44444444-4444-4444-4444-444444444444") {
+		t.Fatalf("expected the Owners for %q to be restored but got %+v", groupLock, lock["properties"])
+	}
+}
+
+func TestLiftManagementLocks_unowned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	resourceGroupId := "/subscriptions/00000000-0000-0000-0000-000000000640/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+
+	ownedLock := testManagementLocksPutLock(server, resourceGroupId, "owned", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	unownedLock := testManagementLocksPutLock(server, resourceGroupId, "unowned", managementlocks.LockLevelCanNotDelete)
+	otherOwnerLock := testManagementLocksPutLock(server, resourceGroupId, "other", managementlocks.LockLevelReadOnly, "44444444-4444-4444-4444-444444444444")
+
+	// only the Management Locks owned by Terraform are lifted, the others are left as-is
+	lifted, err := locks.LiftManagementLocks(ctx, client, resourceId, testManagementLocksOwner, false)
+	if err != nil {
+		t.Fatalf("lifting the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, ownedLock, false)
+	testManagementLocksExpectExists(t, server, unownedLock, true)
+	testManagementLocksExpectExists(t, server, otherOwnerLock, true)
+	testManagementLocksExpectIds(t, lifted.Lifted(), ownedLock)
+
+	if err := lifted.Restore(ctx); err != nil {
+		t.Fatalf("restoring the Management Locks for %q: %+v", resourceId, err)
+	}
+	testManagementLocksExpectExists(t, server, ownedLock, true)
+}
+
+func TestLiftManagementLocksWithin_unowned(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server, client := testManagementLocksServer(t)
+	resourceGroupId := "/subscriptions/00000000-0000-0000-0000-000000000740/resourceGroups/group1"
+	resourceId := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/network1"
+
+	groupLock := testManagementLocksPutLock(server, resourceGroupId, "group", managementlocks.LockLevelCanNotDelete, testManagementLocksOwner)
+	unownedLock := testManagementLocksPutLock(server, resourceId, "unowned", managementlocks.LockLevelCanNotDelete)
+
+	// a Management Lock which isn't owned by Terraform would block deleting the Resource Group, so nothing is lifted
+	_, err := locks.LiftManagementLocksWithin(ctx, client, resourceGroupId, testManagementLocksOwner)
+	if err == nil {
+		t.Fatalf("expected an error lifting the Management Locks within %q but didn't get one", resourceGroupId)
+	}
+	if !strings.Contains(err.Error(), unownedLock) || strings.Contains(err.Error(), groupLock) {
+		t.Fatalf("expected the error to list only %q but got %+v", unownedLock, err)
+	}
+	testManagementLocksExpectExists(t, server, groupLock, true)
+	testManagementLocksExpectExists(t, server, unownedLock, true)
+	if count := testManagementLocksCountRequests(server, http.MethodDelete, groupLock); count != 0 {
+		t.Fatalf("expected %q not to be removed but got %d requests", groupLock, count)
+	}
+}
+
+func testManagementLocksServer(t *testing.T) (*mockarm.Server, *managementlocks.ManagementLocksClient) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server := mockarm.NewServer(t)
+	client, err := server.Client(ctx, features.Default())
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	return server, client.Resource.LocksClient
+}
+
+func testManagementLocksPutLock(server *mockarm.Server, scope string, name string, level managementlocks.LockLevel, owners ...string) string {
+	properties := map[string]interface{}{
+		"level": string(level),
+		"notes": name,
+	}
+	if len(owners) > 0 {
+		items := make([]interface{}, 0)
+		for _, v := range owners {
+			items = append(items, map[string]interface{}{
+				"applicationId": v,
+			})
+		}
+		properties["owners"] = items
+	}
+
+	id := fmt.Sprintf("%s/providers/Microsoft.Authorization/locks/%s", scope, name)
+	server.Put(id, map[string]interface{}{
+		"properties": properties,
+	})
+	return id
+}
+
+func testManagementLocksExpectExists(t *testing.T, server *mockarm.Server, id string, expected bool) {
+	t.Helper()
+
+	if _, exists := server.Get(id); exists != expected {
+		t.Fatalf("expected %q to exist: %t but got %t", id, expected, exists)
+	}
+}
+
+func testManagementLocksCountRequests(server *mockarm.Server, method string, id string) int {
+	count := 0
+	for _, r := range server.Requests() {
+		if r.Method == method && strings.EqualFold(r.Path, id) {
+			count++
+		}
+	}
+	return count
+}
+
+func testManagementLocksExpectIds(t *testing.T, actual []managementlocks.ScopedLockId, expected ...string) {
+	t.Helper()
+
+	ids := make([]string, 0)
+	for _, v := range actual {
+		ids = append(ids, v.ID())
+	}
+	if len(ids) != len(expected) {
+		t.Fatalf("expected the Management Locks %+v but got %+v", expected, ids)
+	}
+	for i := range expected {
+		if !strings.EqualFold(ids[i], expected[i]) {
+			t.Fatalf("expected the Management Locks %+v but got %+v", expected, ids)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package locks
+
+import (
+	"testing"
+)
+
+func TestIsParentScopeOf(t *testing.T) {
+	resourceId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/network1"
+
+	cases := []struct {
+		Name   string
+		Scope  string
+		Result bool
+	}{
+		{
+			Name:   "subscription",
+			Scope:  "/subscriptions/00000000-0000-0000-0000-000000000000",
+			Result: true,
+		},
+		{
+			Name:   "resource group",
+			Scope:  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1",
+			Result: true,
+		},
+		{
+			Name:   "resource group different casing",
+			Scope:  "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/GROUP1",
+			Result: true,
+		},
+		{
+			Name:   "resource itself",
+			Scope:  resourceId,
+			Result: false,
+		},
+		{
+			Name:   "child resource",
+			Scope:  resourceId + "/subnets/subnet1",
+			Result: false,
+		},
+		{
+			Name:   "resource group with the same prefix",
+			Scope:  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group",
+			Result: false,
+		},
+		{
+			Name:   "different resource group",
+			Scope:  "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group2",
+			Result: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Name)
+		if actual := isParentScopeOf(tc.Scope, resourceId); actual != tc.Result {
+			t.Fatalf("Expected %t but got %t", tc.Result, actual)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// withDeletionProtection wraps the Delete function of an untyped Resource so that the `deletion_protection`
+// feature is enforced - Typed Resources are instead handled by the wrapper within the `sdk` package.
+func withDeletionProtection(resourceType string, resource *pluginsdk.Resource) *pluginsdk.Resource {
+	if deleteFunc := resource.Delete; deleteFunc != nil { //nolint:staticcheck
+		resource.Delete = func(d *pluginsdk.ResourceData, meta interface{}) error { //nolint:staticcheck
+			if err := sdk.CheckDeletionProtection(resourceType, resource.Schema, d, meta); err != nil {
+				return err
+			}
+			return deleteFunc(d, meta)
+		}
+	}
+
+	if deleteFunc := resource.DeleteContext; deleteFunc != nil {
+		resource.DeleteContext = func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			if err := sdk.CheckDeletionProtection(resourceType, resource.Schema, d, meta); err != nil {
+				return diag.FromErr(err)
+			}
+			return deleteFunc(ctx, d, meta)
+		}
+	}
+
+	return resource
+}
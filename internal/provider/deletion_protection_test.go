@@ -0,0 +1,89 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+func TestDeletionProtection(t *testing.T) {
+	provider := TestAzureProvider()
+
+	testData := []struct {
+		resourceType string
+		id           string
+		features     features.DeletionProtectionFeatures
+		tags         map[string]interface{}
+	}{
+		{
+			// untyped
+			resourceType: "azurerm_resource_group",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			features: features.DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			tags: map[string]interface{}{
+				"Lock": "true",
+			},
+		},
+		{
+			resourceType: "azurerm_resource_group",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			features: features.DeletionProtectionFeatures{
+				ResourceTypes: []string{"azurerm_resource_group"},
+			},
+		},
+		{
+			// typed
+			resourceType: "azurerm_aadb2c_directory",
+			id:           "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.AzureActiveDirectory/b2cDirectories/example.onmicrosoft.com",
+			features: features.DeletionProtectionFeatures{
+				TagKey: "lock",
+			},
+			tags: map[string]interface{}{
+				"lock": "",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q with %+v..", v.resourceType, v.features)
+
+		resource, ok := provider.ResourcesMap[v.resourceType]
+		if !ok {
+			t.Fatalf("the Resource %q was not found", v.resourceType)
+		}
+
+		raw := map[string]interface{}{}
+		if v.tags != nil {
+			raw["tags"] = v.tags
+		}
+		d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+		d.SetId(v.id)
+
+		// the deletion is prevented before any API requests are made, so no clients are required
+		meta := &clients.Client{
+			StopContext: context.Background(),
+			Features: features.UserFeatures{
+				DeletionProtection: v.features,
+			},
+		}
+
+		var err error
+		if resource.DeleteContext != nil {
+			err = diagnosticsError(resource.DeleteContext(context.Background(), d, meta))
+		} else {
+			err = resource.Delete(d, meta) //nolint:staticcheck
+		}
+		if err == nil || !strings.Contains(err.Error(), "`deletion_protection`") {
+			t.Fatalf("expected the deletion of %q to be prevented but got %+v", v.resourceType, err)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	// the tags assigned to the Subscription and Resource Groups are cached for the duration of the Terraform run,
+	// rather than being retrieved each time a Resource within them is read
+	effectiveTagsCache     = map[string]map[string]string{}
+	effectiveTagsCacheLock = &sync.RWMutex{}
+)
+
+// withEffectiveTags adds a computed `effective_tags` field to each Resource which supports tags (when opted into
+// using the `ARM_PROVIDER_EFFECTIVE_TAGS` Environment Variable), which contains the tags inherited from the
+// Subscription and Resource Group merged with the tags assigned to the Resource - as returned from the Tags API.
+func withEffectiveTags(resourceType string, resource *pluginsdk.Resource) *pluginsdk.Resource {
+	if !features.EffectiveTagsEnabled() {
+		return resource
+	}
+
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != pluginsdk.TypeMap || !(tagsSchema.Optional || tagsSchema.Required) {
+		return resource
+	}
+	if _, exists := resource.Schema["effective_tags"]; exists {
+		panic(fmt.Sprintf("the Resource %q already defines an `effective_tags` field", resourceType))
+	}
+
+	resource.Schema["effective_tags"] = &pluginsdk.Schema{
+		Type:     pluginsdk.TypeMap,
+		Computed: true,
+		Elem: &pluginsdk.Schema{
+			Type: pluginsdk.TypeString,
+		},
+	}
+
+	// Create and Update call the Resource's own (unwrapped) Read function, so the effective tags are populated once
+	// the operation has completed
+	wrap := func(f func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			diags := f(ctx, d, meta)
+			if diags.HasError() {
+				return diags
+			}
+
+			return append(diags, populateEffectiveTags(ctx, d, meta)...)
+		}
+	}
+	// the legacy functions can only return an error, so these are converted to their Context-aware equivalents
+	// in order that a failure to retrieve the effective tags can be surfaced as a Warning
+	legacy := func(f func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		return func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(f(d, meta))
+		}
+	}
+
+	if resource.Create != nil { //nolint:staticcheck
+		resource.CreateContext = legacy(resource.Create) //nolint:staticcheck
+		resource.Create = nil                            //nolint:staticcheck
+	}
+	if resource.Read != nil { //nolint:staticcheck
+		resource.ReadContext = legacy(resource.Read) //nolint:staticcheck
+		resource.Read = nil                          //nolint:staticcheck
+	}
+	if resource.Update != nil { //nolint:staticcheck
+		resource.UpdateContext = legacy(resource.Update) //nolint:staticcheck
+		resource.Update = nil                            //nolint:staticcheck
+	}
+	resource.CreateContext = wrap(resource.CreateContext)
+	resource.ReadContext = wrap(resource.ReadContext)
+	resource.UpdateContext = wrap(resource.UpdateContext)
+
+	existing := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		// changing the tags assigned to the Resource changes the effective tags, which are only known after apply
+		if d.Id() != "" && d.HasChange("tags") {
+			if err := d.SetNewComputed("effective_tags"); err != nil {
+				return fmt.Errorf("setting `effective_tags` to computed: %+v", err)
+			}
+		}
+
+		if existing != nil {
+			return existing(ctx, d, meta)
+		}
+
+		return nil
+	}
+
+	return resource
+}
+
+// populateEffectiveTags sets the `effective_tags` field for the Resource - when the tags can't be retrieved (for
+// example as the Tags API requires further permissions) the effective tags are cleared and a Warning is returned,
+// rather than failing the operation.
+func populateEffectiveTags(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+	client, ok := meta.(*clients.Client)
+	if !ok {
+		return nil
+	}
+
+	// the Resource has been removed from the State, e.g. since it no longer exists
+	if d.Id() == "" {
+		return nil
+	}
+
+	// a Computed map which isn't present in the State is planned as unknown, so the effective tags are set to an
+	// empty map for Resources which don't inherit tags - otherwise there'd be a diff on every plan
+	scopes := effectiveTagScopes(d.Id())
+	if len(scopes) == 0 {
+		if err := d.Set("effective_tags", map[string]interface{}{}); err != nil {
+			return diag.Errorf("setting `effective_tags`: %+v", err)
+		}
+		return nil
+	}
+
+	scopeTags := make([]map[string]string, 0)
+	for i, scope := range scopes {
+		// the tags assigned to the Resource itself are always retrieved, since these are what's being refreshed
+		isResource := i == len(scopes)-1 && strings.EqualFold(scope, d.Id())
+		v, err := effectiveTagsAtScope(ctx, client, scope, !isResource)
+		if err != nil {
+			if setErr := d.Set("effective_tags", map[string]interface{}{}); setErr != nil {
+				return diag.Errorf("setting `effective_tags`: %+v", setErr)
+			}
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "Unable to populate `effective_tags`",
+					Detail:   fmt.Sprintf("retrieving the tags at the scope %q: %+v\n\nThe `effective_tags` field has been left empty.", scope, err),
+				},
+			}
+		}
+		scopeTags = append(scopeTags, v)
+	}
+
+	if err := d.Set("effective_tags", tags.Flatten(mergeEffectiveTags(scopeTags))); err != nil {
+		return diag.Errorf("setting `effective_tags`: %+v", err)
+	}
+	return nil
+}
+
+// effectiveTagsAtScope returns the tags assigned at the specified scope, using the cached tags when `useCache` is
+// true. The cache is always updated, such that refreshing a Resource Group updates the tags inherited from it.
+func effectiveTagsAtScope(ctx context.Context, client *clients.Client, scope string, useCache bool) (map[string]string, error) {
+	cacheKey := strings.ToLower(scope)
+	if useCache {
+		effectiveTagsCacheLock.RLock()
+		v, ok := effectiveTagsCache[cacheKey]
+		effectiveTagsCacheLock.RUnlock()
+		if ok {
+			return v, nil
+		}
+	}
+
+	resp, err := client.Resource.TagsClient.GetAtScope(ctx, commonids.NewScopeID(scope))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]string)
+	if resp.Model != nil && resp.Model.Properties.Tags != nil {
+		result = *resp.Model.Properties.Tags
+	}
+
+	effectiveTagsCacheLock.Lock()
+	effectiveTagsCache[cacheKey] = result
+	effectiveTagsCacheLock.Unlock()
+
+	return result, nil
+}
+
+// mergeEffectiveTags merges the tags assigned at each scope, which are in order of precedence (see
+// effectiveTagScopes) - such that a tag at a more specific scope replaces any inherited tag.
+func mergeEffectiveTags(scopeTags []map[string]string) map[string]*string {
+	effective := make(map[string]*string)
+	for _, v := range scopeTags {
+		for key, value := range v {
+			// tag names are case-insensitive, so a tag at a more specific scope replaces any inherited tag
+			for existing := range effective {
+				if strings.EqualFold(existing, key) {
+					delete(effective, existing)
+				}
+			}
+			value := value
+			effective[key] = &value
+		}
+	}
+
+	return effective
+}
+
+// effectiveTagScopes returns the scopes from which the tags for the specified Resource ID are inherited, in order
+// of precedence (that is, the Subscription, then the Resource Group, and finally the Resource itself).
+func effectiveTagScopes(id string) []string {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return nil
+	}
+
+	scopes := []string{
+		"/" + strings.Join(segments[0:2], "/"),
+	}
+	if len(segments) >= 4 && strings.EqualFold(segments[2], "resourceGroups") {
+		scopes = append(scopes, "/"+strings.Join(segments[0:4], "/"))
+	}
+
+	resourceId := "/" + strings.Join(segments, "/")
+	if !strings.EqualFold(resourceId, scopes[len(scopes)-1]) {
+		scopes = append(scopes, resourceId)
+	}
+
+	return scopes
+}
@@ -0,0 +1,313 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestEffectiveTagScopes(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected []string
+	}{
+		{
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			// tenant-level resources don't inherit tags
+			Input:    "/providers/Microsoft.Management/managementGroups/example",
+			Expected: nil,
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000",
+			Expected: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000",
+			},
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			},
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+			Expected: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+			},
+		},
+		{
+			Input: "/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Resources/templateSpecs/spec1",
+			Expected: []string{
+				"/subscriptions/00000000-0000-0000-0000-000000000000",
+				"/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Resources/templateSpecs/spec1",
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		actual := effectiveTagScopes(v.Input)
+		if !reflect.DeepEqual(actual, v.Expected) {
+			t.Fatalf("expected %+v but got %+v", v.Expected, actual)
+		}
+	}
+}
+
+func TestMergeEffectiveTags(t *testing.T) {
+	actual := mergeEffectiveTags([]map[string]string{
+		{
+			// Subscription
+			"CostCenter":  "subscription",
+			"environment": "subscription",
+			"owner":       "subscription",
+		},
+		{
+			// Resource Group
+			"costcenter": "resource-group",
+			"team":       "resource-group",
+		},
+		{
+			// Resource
+			"Environment": "resource",
+			"team":        "resource",
+		},
+	})
+
+	expected := map[string]string{
+		"costcenter":  "resource-group",
+		"Environment": "resource",
+		"owner":       "subscription",
+		"team":        "resource",
+	}
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d tags but got %d: %+v", len(expected), len(actual), actual)
+	}
+	for k, v := range expected {
+		if actual[k] == nil || *actual[k] != v {
+			t.Fatalf("expected the tag %q to be %q but got %+v", k, v, actual[k])
+		}
+	}
+}
+
+func TestWithEffectiveTags(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Setenv("ARM_PROVIDER_EFFECTIVE_TAGS", "true")
+	resetEffectiveTagsCache()
+
+	server := mockarm.NewServer(t)
+	client, err := server.Client(ctx, features.Default())
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	subscriptionId := "/subscriptions/" + mockarm.SubscriptionId
+	resourceGroupId := subscriptionId + "/resourceGroups/example"
+	id := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/example"
+	for scope, tags := range map[string]map[string]interface{}{
+		subscriptionId:  {"environment": "production", "owner": "platform"},
+		resourceGroupId: {"owner": "networking"},
+		id:              {"Environment": "staging"},
+	} {
+		server.Put(scope+"/providers/Microsoft.Resources/tags/default", map[string]interface{}{
+			"properties": map[string]interface{}{
+				"tags": tags,
+			},
+		})
+	}
+
+	reads := 0
+	read := func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		reads++
+		return nil
+	}
+	resource := withEffectiveTags("azurerm_example", &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+		// as with the Resources, the Create function calls the Resource's own Read function
+		CreateContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			d.SetId(id)
+			return read(ctx, d, meta)
+		},
+		ReadContext: read,
+		DeleteContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	})
+
+	tagRequests := func() int {
+		count := 0
+		for _, r := range server.Requests() {
+			if r.Method == http.MethodGet && strings.HasSuffix(r.Path, "/providers/Microsoft.Resources/tags/default") {
+				count++
+			}
+		}
+		return count
+	}
+	expected := map[string]interface{}{
+		"Environment": "staging",
+		"owner":       "networking",
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	if diags := resource.CreateContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("creating: %+v", diags)
+	}
+	if reads != 1 {
+		t.Fatalf("expected the Read function to be called once but got %d", reads)
+	}
+	// the tags should be retrieved once for each of the 3 scopes
+	if count := tagRequests(); count != 3 {
+		t.Fatalf("expected the tags to be retrieved for 3 scopes but got %d requests", count)
+	}
+	if actual := d.Get("effective_tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the effective tags to be %+v but got %+v", expected, actual)
+	}
+
+	// whereas a Read on its own should populate the effective tags, retrieving only the tags for the Resource
+	// since those for the Subscription and Resource Group are cached
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId(id)
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+	if count := tagRequests(); count != 4 {
+		t.Fatalf("expected the tags to be retrieved for 1 further scope but got %d requests in total", count)
+	}
+	if actual := d.Get("effective_tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the effective tags to be %+v but got %+v", expected, actual)
+	}
+
+	// refreshing the Resource Group itself retrieves (and caches) its current tags
+	server.Put(resourceGroupId+"/providers/Microsoft.Resources/tags/default", map[string]interface{}{
+		"properties": map[string]interface{}{
+			"tags": map[string]interface{}{"owner": "security"},
+		},
+	})
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId(resourceGroupId)
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+	if count := tagRequests(); count != 5 {
+		t.Fatalf("expected the tags to be retrieved for the Resource Group but got %d requests in total", count)
+	}
+
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId(id)
+	if diags := resource.ReadContext(ctx, d, client); diags.HasError() {
+		t.Fatalf("reading: %+v", diags)
+	}
+	expected["owner"] = "security"
+	if actual := d.Get("effective_tags").(map[string]interface{}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected the effective tags to be %+v but got %+v", expected, actual)
+	}
+}
+
+func TestWithEffectiveTags_tagsUnavailable(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Setenv("ARM_PROVIDER_EFFECTIVE_TAGS", "true")
+	resetEffectiveTagsCache()
+
+	server := mockarm.NewServer(t)
+	client, err := server.Client(ctx, features.Default())
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	// the Tags API requires permissions which aren't necessarily granted to the Resource Group
+	server.InjectError(http.MethodGet, "/resourceGroups/example/providers/Microsoft.Resources/tags/default$", 1, http.StatusForbidden, "AuthorizationFailed")
+
+	resource := withEffectiveTags("azurerm_example", &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			return nil
+		},
+	})
+	if resource.Read != nil { //nolint:staticcheck
+		t.Fatalf("expected the legacy Read function to be replaced by a Context-aware Read function")
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId("/subscriptions/" + mockarm.SubscriptionId + "/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/example")
+	if err := d.Set("effective_tags", map[string]interface{}{"environment": "stale"}); err != nil {
+		t.Fatalf("setting `effective_tags`: %+v", err)
+	}
+
+	diags := resource.ReadContext(ctx, d, client)
+	if diags.HasError() {
+		t.Fatalf("expected reading to succeed but got %+v", diags)
+	}
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single Warning but got %+v", diags)
+	}
+	if actual := d.Get("effective_tags").(map[string]interface{}); len(actual) != 0 {
+		t.Fatalf("expected the effective tags to be empty but got %+v", actual)
+	}
+}
+
+func TestWithEffectiveTags_disabled(t *testing.T) {
+	t.Setenv("ARM_PROVIDER_EFFECTIVE_TAGS", "")
+
+	resource := withEffectiveTags("azurerm_example", &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{
+			"tags": {
+				Type:     pluginsdk.TypeMap,
+				Optional: true,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+		ReadContext: func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			return nil
+		},
+	})
+
+	if _, ok := resource.Schema["effective_tags"]; ok {
+		t.Fatalf("expected `effective_tags` not to be added to the schema unless opted into")
+	}
+}
+
+func resetEffectiveTagsCache() {
+	effectiveTagsCacheLock.Lock()
+	defer effectiveTagsCacheLock.Unlock()
+	effectiveTagsCache = map[string]map[string]string{}
+}
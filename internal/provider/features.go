@@ -4,11 +4,13 @@
 package provider
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
 
 func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
@@ -88,6 +90,32 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"deletion_protection": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"tag_key": {
+						Description:  "When specified, resources which have a tag with this name (in the State) cannot be deleted until this tag has been removed",
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"resource_types": {
+						Description: "A list of Resource Types (e.g. `azurerm_key_vault`) which cannot be deleted",
+						Type:        pluginsdk.TypeSet,
+						Optional:    true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+		},
+
 		"key_vault": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -199,6 +227,11 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Type:     pluginsdk.TypeBool,
 						Required: true,
 					},
+					"what_if_during_plan": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
 				},
 			},
 		},
@@ -270,6 +303,16 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 						Optional: true,
 						Default:  os.Getenv("TF_ACC") == "",
 					},
+					"delete_nested_resources_in_order": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"remove_management_locks_during_delete": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
 				},
 			},
 		},
@@ -289,6 +332,21 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"kubernetes_cluster": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"pause_node_pool_upgrade_on_failure": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+
 		"managed_disk": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -304,6 +362,26 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 			},
 		},
 
+		"management_lock": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"lift_parent_locks_during_update_and_delete": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+					"lift_subscription_locks": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+
 		"subscription": {
 			Type:     pluginsdk.TypeList,
 			Optional: true,
@@ -392,6 +470,18 @@ func schemaFeatures(supportLegacyTestSuite bool) *pluginsdk.Schema {
 	}
 }
 
+// validateFeatures validates the combinations of features which can't be expressed within the schema
+func validateFeatures(input features.UserFeatures) error {
+	if input.ResourceGroup.RemoveManagementLocksDuringDelete && !input.ResourceGroup.DeleteNestedResourcesInOrder {
+		return fmt.Errorf("`remove_management_locks_during_delete` within the `resource_group` block requires that `delete_nested_resources_in_order` is set to `true`")
+	}
+	if input.ManagementLock.LiftSubscriptionLocks && !input.ManagementLock.LiftParentLocksDuringUpdateAndDelete {
+		return fmt.Errorf("`lift_subscription_locks` within the `management_lock` block requires that `lift_parent_locks_during_update_and_delete` is set to `true`")
+	}
+
+	return nil
+}
+
 func expandFeatures(input []interface{}) features.UserFeatures {
 	// these are the defaults if omitted from the config
 	featuresMap := features.Default()
@@ -448,6 +538,21 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["deletion_protection"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			deletionProtectionRaw := items[0].(map[string]interface{})
+			if v, ok := deletionProtectionRaw["tag_key"]; ok {
+				featuresMap.DeletionProtection.TagKey = v.(string)
+			}
+			if v, ok := deletionProtectionRaw["resource_types"]; ok {
+				for _, resourceType := range v.(*pluginsdk.Set).List() {
+					featuresMap.DeletionProtection.ResourceTypes = append(featuresMap.DeletionProtection.ResourceTypes, resourceType.(string))
+				}
+			}
+		}
+	}
+
 	if raw, ok := val["key_vault"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 && items[0] != nil {
@@ -505,6 +610,9 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := templateRaw["delete_nested_items_during_deletion"]; ok {
 				featuresMap.TemplateDeployment.DeleteNestedItemsDuringDeletion = v.(bool)
 			}
+			if v, ok := templateRaw["what_if_during_plan"]; ok {
+				featuresMap.TemplateDeployment.WhatIfDuringPlan = v.(bool)
+			}
 		}
 	}
 
@@ -550,6 +658,12 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 			if v, ok := resourceGroupRaw["prevent_deletion_if_contains_resources"]; ok {
 				featuresMap.ResourceGroup.PreventDeletionIfContainsResources = v.(bool)
 			}
+			if v, ok := resourceGroupRaw["delete_nested_resources_in_order"]; ok {
+				featuresMap.ResourceGroup.DeleteNestedResourcesInOrder = v.(bool)
+			}
+			if v, ok := resourceGroupRaw["remove_management_locks_during_delete"]; ok {
+				featuresMap.ResourceGroup.RemoveManagementLocksDuringDelete = v.(bool)
+			}
 		}
 	}
 
@@ -563,6 +677,16 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["kubernetes_cluster"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			kubernetesClusterRaw := items[0].(map[string]interface{})
+			if v, ok := kubernetesClusterRaw["pause_node_pool_upgrade_on_failure"]; ok {
+				featuresMap.KubernetesCluster.PauseNodePoolUpgradeOnFailure = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["managed_disk"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
@@ -583,6 +707,19 @@ func expandFeatures(input []interface{}) features.UserFeatures {
 		}
 	}
 
+	if raw, ok := val["management_lock"]; ok {
+		items := raw.([]interface{})
+		if len(items) > 0 && items[0] != nil {
+			managementLockRaw := items[0].(map[string]interface{})
+			if v, ok := managementLockRaw["lift_parent_locks_during_update_and_delete"]; ok {
+				featuresMap.ManagementLock.LiftParentLocksDuringUpdateAndDelete = v.(bool)
+			}
+			if v, ok := managementLockRaw["lift_subscription_locks"]; ok {
+				featuresMap.ManagementLock.LiftSubscriptionLocks = v.(bool)
+			}
+		}
+	}
+
 	if raw, ok := val["postgresql_flexible_server"]; ok {
 		items := raw.([]interface{})
 		if len(items) > 0 {
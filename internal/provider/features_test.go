@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 )
 
 func TestExpandFeatures(t *testing.T) {
@@ -115,6 +116,12 @@ func TestExpandFeatures(t *testing.T) {
 							"purge_soft_delete_on_destroy": true,
 						},
 					},
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"tag_key":        "lock",
+							"resource_types": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"azurerm_key_vault"}),
+						},
+					},
 					"key_vault": []interface{}{
 						map[string]interface{}{
 							"purge_soft_deleted_certificates_on_destroy":                  true,
@@ -148,6 +155,8 @@ func TestExpandFeatures(t *testing.T) {
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": true,
+							"delete_nested_resources_in_order":       true,
+							"remove_management_locks_during_delete":  true,
 						},
 					},
 					"recovery_services_vaults": []interface{}{
@@ -160,9 +169,21 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_cancellation_on_destroy": true,
 						},
 					},
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"lift_parent_locks_during_update_and_delete": true,
+							"lift_subscription_locks":                    true,
+						},
+					},
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"pause_node_pool_upgrade_on_failure": true,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": true,
+							"what_if_during_plan":                 true,
 						},
 					},
 					"virtual_machine": []interface{}{
@@ -208,6 +229,10 @@ func TestExpandFeatures(t *testing.T) {
 				CognitiveAccount: features.CognitiveAccountFeatures{
 					PurgeSoftDeleteOnDestroy: true,
 				},
+				DeletionProtection: features.DeletionProtectionFeatures{
+					TagKey:        "lock",
+					ResourceTypes: []string{"azurerm_key_vault"},
+				},
 				KeyVault: features.KeyVaultFeatures{
 					PurgeSoftDeletedCertsOnDestroy:   true,
 					PurgeSoftDeletedKeysOnDestroy:    true,
@@ -229,6 +254,8 @@ func TestExpandFeatures(t *testing.T) {
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: true,
+					DeleteNestedResourcesInOrder:       true,
+					RemoveManagementLocksDuringDelete:  true,
 				},
 				RecoveryServicesVault: features.RecoveryServicesVault{
 					RecoverSoftDeletedBackupProtectedVM: true,
@@ -236,8 +263,16 @@ func TestExpandFeatures(t *testing.T) {
 				Subscription: features.SubscriptionFeatures{
 					PreventCancellationOnDestroy: true,
 				},
+				ManagementLock: features.ManagementLockFeatures{
+					LiftParentLocksDuringUpdateAndDelete: true,
+					LiftSubscriptionLocks:                true,
+				},
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PauseNodePoolUpgradeOnFailure: true,
+				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: true,
+					WhatIfDuringPlan:                true,
 				},
 				VirtualMachine: features.VirtualMachineFeatures{
 					DeleteOSDiskOnDeletion:     true,
@@ -321,6 +356,8 @@ func TestExpandFeatures(t *testing.T) {
 					"resource_group": []interface{}{
 						map[string]interface{}{
 							"prevent_deletion_if_contains_resources": false,
+							"delete_nested_resources_in_order":       false,
+							"remove_management_locks_during_delete":  false,
 						},
 					},
 					"recovery_services_vaults": []interface{}{
@@ -333,9 +370,21 @@ func TestExpandFeatures(t *testing.T) {
 							"prevent_cancellation_on_destroy": false,
 						},
 					},
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"lift_parent_locks_during_update_and_delete": false,
+							"lift_subscription_locks":                    false,
+						},
+					},
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"pause_node_pool_upgrade_on_failure": false,
+						},
+					},
 					"template_deployment": []interface{}{
 						map[string]interface{}{
 							"delete_nested_items_during_deletion": false,
+							"what_if_during_plan":                 false,
 						},
 					},
 					"virtual_machine": []interface{}{
@@ -402,6 +451,8 @@ func TestExpandFeatures(t *testing.T) {
 				},
 				ResourceGroup: features.ResourceGroupFeatures{
 					PreventDeletionIfContainsResources: false,
+					DeleteNestedResourcesInOrder:       false,
+					RemoveManagementLocksDuringDelete:  false,
 				},
 				RecoveryServicesVault: features.RecoveryServicesVault{
 					RecoverSoftDeletedBackupProtectedVM: false,
@@ -411,6 +462,7 @@ func TestExpandFeatures(t *testing.T) {
 				},
 				TemplateDeployment: features.TemplateDeploymentFeatures{
 					DeleteNestedItemsDuringDeletion: false,
+					WhatIfDuringPlan:                false,
 				},
 				VirtualMachine: features.VirtualMachineFeatures{
 					DeleteOSDiskOnDeletion:     false,
@@ -716,6 +768,71 @@ func TestExpandFeaturesCognitiveServices(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesDeletionProtection(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"deletion_protection": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				DeletionProtection: features.DeletionProtectionFeatures{},
+			},
+		},
+		{
+			Name: "Tag Key",
+			Input: []interface{}{
+				map[string]interface{}{
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"tag_key":        "lock",
+							"resource_types": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				DeletionProtection: features.DeletionProtectionFeatures{
+					TagKey: "lock",
+				},
+			},
+		},
+		{
+			Name: "Resource Types",
+			Input: []interface{}{
+				map[string]interface{}{
+					"deletion_protection": []interface{}{
+						map[string]interface{}{
+							"tag_key":        "",
+							"resource_types": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"azurerm_key_vault"}),
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				DeletionProtection: features.DeletionProtectionFeatures{
+					ResourceTypes: []string{"azurerm_key_vault"},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.DeletionProtection, testCase.Expected.DeletionProtection) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.DeletionProtection, result.DeletionProtection)
+		}
+	}
+}
+
 func TestExpandFeaturesKeyVault(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -885,6 +1002,44 @@ func TestExpandFeaturesTemplateDeployment(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "What If During Plan Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"template_deployment": []interface{}{
+						map[string]interface{}{
+							"delete_nested_items_during_deletion": true,
+							"what_if_during_plan":                 true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: true,
+					WhatIfDuringPlan:                true,
+				},
+			},
+		},
+		{
+			Name: "What If During Plan Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"template_deployment": []interface{}{
+						map[string]interface{}{
+							"delete_nested_items_during_deletion": true,
+							"what_if_during_plan":                 false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				TemplateDeployment: features.TemplateDeploymentFeatures{
+					DeleteNestedItemsDuringDeletion: true,
+					WhatIfDuringPlan:                false,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testData {
@@ -1252,6 +1407,48 @@ func TestExpandFeaturesResourceGroup(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name: "Delete Nested Resources In Order Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_if_contains_resources": false,
+							"delete_nested_resources_in_order":       true,
+							"remove_management_locks_during_delete":  false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					PreventDeletionIfContainsResources: false,
+					DeleteNestedResourcesInOrder:       true,
+					RemoveManagementLocksDuringDelete:  false,
+				},
+			},
+		},
+		{
+			Name: "Remove Management Locks During Delete Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"resource_group": []interface{}{
+						map[string]interface{}{
+							"prevent_deletion_if_contains_resources": false,
+							"delete_nested_resources_in_order":       true,
+							"remove_management_locks_during_delete":  true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ResourceGroup: features.ResourceGroupFeatures{
+					PreventDeletionIfContainsResources: false,
+					DeleteNestedResourcesInOrder:       true,
+					RemoveManagementLocksDuringDelete:  true,
+				},
+			},
+		},
 	}
 
 	for _, testCase := range testData {
@@ -1441,6 +1638,155 @@ func TestExpandFeaturesSubscription(t *testing.T) {
 	}
 }
 
+func TestExpandFeaturesManagementLock(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					LiftParentLocksDuringUpdateAndDelete: false,
+				},
+			},
+		},
+		{
+			Name: "Lift Parent Locks During Update And Delete Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"lift_parent_locks_during_update_and_delete": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					LiftParentLocksDuringUpdateAndDelete: true,
+				},
+			},
+		},
+		{
+			Name: "Lift Subscription Locks Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"lift_parent_locks_during_update_and_delete": true,
+							"lift_subscription_locks":                    true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					LiftParentLocksDuringUpdateAndDelete: true,
+					LiftSubscriptionLocks:                true,
+				},
+			},
+		},
+		{
+			Name: "Lift Parent Locks During Update And Delete Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"management_lock": []interface{}{
+						map[string]interface{}{
+							"lift_parent_locks_during_update_and_delete": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				ManagementLock: features.ManagementLockFeatures{
+					LiftParentLocksDuringUpdateAndDelete: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.ManagementLock, testCase.Expected.ManagementLock) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.ManagementLock, result.ManagementLock)
+		}
+	}
+}
+
+func TestExpandFeaturesKubernetesCluster(t *testing.T) {
+	testData := []struct {
+		Name     string
+		Input    []interface{}
+		EnvVars  map[string]interface{}
+		Expected features.UserFeatures
+	}{
+		{
+			Name: "Empty Block",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PauseNodePoolUpgradeOnFailure: false,
+				},
+			},
+		},
+		{
+			Name: "Pause Node Pool Upgrade On Failure Enabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"pause_node_pool_upgrade_on_failure": true,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PauseNodePoolUpgradeOnFailure: true,
+				},
+			},
+		},
+		{
+			Name: "Pause Node Pool Upgrade On Failure Disabled",
+			Input: []interface{}{
+				map[string]interface{}{
+					"kubernetes_cluster": []interface{}{
+						map[string]interface{}{
+							"pause_node_pool_upgrade_on_failure": false,
+						},
+					},
+				},
+			},
+			Expected: features.UserFeatures{
+				KubernetesCluster: features.KubernetesClusterFeatures{
+					PauseNodePoolUpgradeOnFailure: false,
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		result := expandFeatures(testCase.Input)
+		if !reflect.DeepEqual(result.KubernetesCluster, testCase.Expected.KubernetesCluster) {
+			t.Fatalf("Expected %+v but got %+v", testCase.Expected.KubernetesCluster, result.KubernetesCluster)
+		}
+	}
+}
+
 func TestExpandFeaturesPosgresqlFlexibleServer(t *testing.T) {
 	testData := []struct {
 		Name     string
@@ -1640,3 +1986,61 @@ func TestExpandFeaturesRecoveryService(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateFeatures(t *testing.T) {
+	testData := []struct {
+		Name                                 string
+		DeleteNestedResourcesInOrder         bool
+		RemoveManagementLocksDuringDelete    bool
+		LiftParentLocksDuringUpdateAndDelete bool
+		LiftSubscriptionLocks                bool
+		ExpectError                          bool
+	}{
+		{
+			Name: "Defaults",
+		},
+		{
+			Name:                         "Delete Nested Resources In Order",
+			DeleteNestedResourcesInOrder: true,
+		},
+		{
+			Name:                              "Remove Management Locks During Delete",
+			DeleteNestedResourcesInOrder:      true,
+			RemoveManagementLocksDuringDelete: true,
+		},
+		{
+			// the Management Locks are only removed when deleting the nested resources in order
+			Name:                              "Remove Management Locks During Delete Without Deleting In Order",
+			RemoveManagementLocksDuringDelete: true,
+			ExpectError:                       true,
+		},
+		{
+			Name:                                 "Lift Subscription Locks",
+			LiftParentLocksDuringUpdateAndDelete: true,
+			LiftSubscriptionLocks:                true,
+		},
+		{
+			// the Management Locks on the Subscription are only lifted alongside those on the other parent scopes
+			Name:                  "Lift Subscription Locks Without Lifting Parent Locks",
+			LiftSubscriptionLocks: true,
+			ExpectError:           true,
+		},
+	}
+
+	for _, testCase := range testData {
+		t.Logf("[DEBUG] Test Case: %q", testCase.Name)
+		input := features.Default()
+		input.ResourceGroup.DeleteNestedResourcesInOrder = testCase.DeleteNestedResourcesInOrder
+		input.ResourceGroup.RemoveManagementLocksDuringDelete = testCase.RemoveManagementLocksDuringDelete
+		input.ManagementLock.LiftParentLocksDuringUpdateAndDelete = testCase.LiftParentLocksDuringUpdateAndDelete
+		input.ManagementLock.LiftSubscriptionLocks = testCase.LiftSubscriptionLocks
+
+		err := validateFeatures(input)
+		if testCase.ExpectError && err == nil {
+			t.Fatalf("expected an error but didn't get one")
+		}
+		if !testCase.ExpectError && err != nil {
+			t.Fatalf("expected no error but got %+v", err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// withManagementLockHandling wraps the Update and Delete functions for each Resource so that (when the
+// `lift_parent_locks_during_update_and_delete` feature is enabled) the Management Locks defined on the parent scopes
+// of the Resource which have opted into this (using `lift_during_nested_changes_enabled` within the
+// `azurerm_management_lock` resource) are temporarily removed whilst the Resource is modified, and then recreated.
+func withManagementLockHandling(resourceType string, resource *pluginsdk.Resource) *pluginsdk.Resource {
+	// the Management Locks themselves are what's being modified here
+	if resourceType == "azurerm_management_lock" {
+		return resource
+	}
+
+	wrap := func(f func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		if f == nil {
+			return nil
+		}
+		return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			client, ok := meta.(*clients.Client)
+			if !ok || !client.Features.ManagementLock.LiftParentLocksDuringUpdateAndDelete || !isLockableResourceId(d.Id()) {
+				return f(ctx, d, meta)
+			}
+
+			resourceId := d.Id()
+			var diags diag.Diagnostics
+			lifted, err := locks.WithLiftedManagementLocks(ctx, client.Resource.LocksClient, resourceId, client.Account.ClientId, client.Features.ManagementLock.LiftSubscriptionLocks, func() error {
+				diags = f(ctx, d, meta)
+				return nil
+			})
+			diags = append(diags, liftedManagementLocksWarning(resourceId, lifted)...)
+			if err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			}
+			return diags
+		}
+	}
+	// the legacy functions can only return an error, so these are converted to their Context-aware equivalents
+	// in order that the Management Locks which were lifted can be surfaced as a Warning
+	legacy := func(f func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+		return func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			return diag.FromErr(f(d, meta))
+		}
+	}
+
+	if resource.Update != nil { //nolint:staticcheck
+		resource.UpdateContext = legacy(resource.Update) //nolint:staticcheck
+		resource.Update = nil                            //nolint:staticcheck
+	}
+	if resource.Delete != nil { //nolint:staticcheck
+		resource.DeleteContext = legacy(resource.Delete) //nolint:staticcheck
+		resource.Delete = nil                            //nolint:staticcheck
+	}
+	resource.UpdateContext = wrap(resource.UpdateContext)
+	resource.DeleteContext = wrap(resource.DeleteContext)
+
+	return resource
+}
+
+// liftedManagementLocksWarning returns a Warning listing the Management Locks which were temporarily removed to
+// modify the Resource, and which of these have been recreated
+func liftedManagementLocksWarning(resourceId string, lifted *locks.LiftedManagementLocks) diag.Diagnostics {
+	if lifted == nil || len(lifted.Lifted()) == 0 {
+		return nil
+	}
+
+	restored := make(map[string]struct{})
+	for _, id := range lifted.Restored() {
+		restored[strings.ToLower(id.ID())] = struct{}{}
+	}
+
+	lines := make([]string, 0)
+	for _, id := range lifted.Lifted() {
+		status := "not yet recreated, since this is still needed by another operation in progress or its scope no longer exists"
+		if _, ok := restored[strings.ToLower(id.ID())]; ok {
+			status = "recreated"
+		}
+		lines = append(lines, fmt.Sprintf("* %s (%s)", id.ID(), status))
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Warning,
+			Summary:  "Management Locks were temporarily removed",
+			Detail:   fmt.Sprintf("The following Management Locks were temporarily removed to modify %q, since the `lift_parent_locks_during_update_and_delete` feature is enabled:\n\n%s", resourceId, strings.Join(lines, "\n")),
+		},
+	}
+}
+
+// isLockableResourceId returns whether the ID is an Azure Resource Manager ID (rather than a Data Plane URI or
+// a composite ID for an association) which Management Locks can be defined above.
+func isLockableResourceId(id string) bool {
+	return strings.HasPrefix(strings.ToLower(id), "/subscriptions/") && !strings.Contains(id, "|")
+}
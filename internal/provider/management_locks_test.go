@@ -0,0 +1,141 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestIsLockableResourceId(t *testing.T) {
+	testData := []struct {
+		Input    string
+		Expected bool
+	}{
+		{
+			Input:    "",
+			Expected: false,
+		},
+		{
+			Input:    "/providers/Microsoft.Management/managementGroups/example",
+			Expected: false,
+		},
+		{
+			Input:    "https://example.vault.azure.net/secrets/secret1/00000000000000000000000000000000",
+			Expected: false,
+		},
+		{
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+			Expected: true,
+		},
+		{
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1",
+			Expected: true,
+		},
+		{
+			Input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/virtualNetworks/network1/subnets/subnet1|/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Network/routeTables/table1",
+			Expected: false,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q", v.Input)
+
+		if actual := isLockableResourceId(v.Input); actual != v.Expected {
+			t.Fatalf("expected %t but got %t", v.Expected, actual)
+		}
+	}
+}
+
+func TestWithManagementLockHandling(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server := mockarm.NewServer(t)
+	userFeatures := features.Default()
+	userFeatures.ManagementLock.LiftParentLocksDuringUpdateAndDelete = true
+	client, err := server.Client(ctx, userFeatures)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	subscriptionId := "/subscriptions/" + mockarm.SubscriptionId
+	resourceGroupId := subscriptionId + "/resourceGroups/example"
+	id := resourceGroupId + "/providers/Microsoft.Network/virtualNetworks/example"
+	putLock := func(scope string, name string, owned bool) string {
+		lockId := scope + "/providers/Microsoft.Authorization/locks/" + name
+		properties := map[string]interface{}{
+			"level": "CanNotDelete",
+		}
+		// the Management Locks which can be lifted record the principal Terraform is running as as an Owner
+		if owned {
+			properties["owners"] = []interface{}{
+				map[string]interface{}{
+					"applicationId": client.Account.ClientId,
+				},
+			}
+		}
+		server.Put(lockId, map[string]interface{}{
+			"properties": properties,
+		})
+		return lockId
+	}
+	groupLock := putLock(resourceGroupId, "group", true)
+	unownedGroupLock := putLock(resourceGroupId, "unowned", false)
+	resourceLock := putLock(id, "resource", true)
+	subscriptionLock := putLock(subscriptionId, "subscription", true)
+
+	var existsDuringDelete map[string]bool
+	resource := withManagementLockHandling("azurerm_example", &pluginsdk.Resource{
+		Schema: map[string]*pluginsdk.Schema{},
+		Delete: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			existsDuringDelete = make(map[string]bool)
+			for _, lockId := range []string{groupLock, unownedGroupLock, resourceLock, subscriptionLock} {
+				_, existsDuringDelete[lockId] = server.Get(lockId)
+			}
+			return nil
+		},
+	})
+	if resource.Delete != nil { //nolint:staticcheck
+		t.Fatalf("expected the legacy Delete function to be replaced by a Context-aware Delete function")
+	}
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	d.SetId(id)
+	diags := resource.DeleteContext(ctx, d, client)
+	if diags.HasError() {
+		t.Fatalf("deleting: %+v", diags)
+	}
+
+	// only the Management Lock on the Resource Group owned by Terraform is lifted, since the Management Locks on the
+	// Subscription are only lifted when the `lift_subscription_locks` feature is enabled
+	expected := map[string]bool{
+		groupLock:        false,
+		unownedGroupLock: true,
+		resourceLock:     true,
+		subscriptionLock: true,
+	}
+	if !reflect.DeepEqual(existsDuringDelete, expected) {
+		t.Fatalf("expected the Management Locks to exist during the Delete: %+v but got %+v", expected, existsDuringDelete)
+	}
+	if _, exists := server.Get(groupLock); !exists {
+		t.Fatalf("expected %q to be recreated", groupLock)
+	}
+
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single Warning but got %+v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "/locks/group (recreated)") || strings.Contains(diags[0].Detail, "/locks/resource") || strings.Contains(diags[0].Detail, "/locks/unowned") {
+		t.Fatalf("expected the Warning to list the lifted Management Lock but got %q", diags[0].Detail)
+	}
+}
@@ -125,7 +125,7 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Data Source exists for %q", k))
 			}
 
-			dataSources[k] = v
+			dataSources[k] = withTracing(k, v)
 		}
 
 		logEntry("[DEBUG] Registering Resources for %q..", service.Name())
@@ -134,10 +134,20 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 				panic(fmt.Sprintf("An existing Resource exists for %q", k))
 			}
 
-			resources[k] = v
+			resources[k] = withTracing(k, withDeletionProtection(k, v))
 		}
 	}
 
+	// the `tag_policy` and `effective_tags` apply to every Resource (both typed and untyped) which supports tags
+	for k, v := range resources {
+		resources[k] = withEffectiveTags(k, withTagPolicy(k, v))
+	}
+
+	// the Management Locks on the parent scopes can optionally be lifted whilst any Resource is updated or deleted
+	for k, v := range resources {
+		resources[k] = withManagementLockHandling(k, v)
+	}
+
 	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"subscription_id": {
@@ -319,6 +329,8 @@ func azureProvider(supportLegacyTestSuite bool) *schema.Provider {
 
 			"features": schemaFeatures(supportLegacyTestSuite),
 
+			"tag_policy": schemaTagPolicy(),
+
 			// Advanced feature flags
 			"skip_provider_registration": {
 				Type:        schema.TypeBool,
@@ -443,11 +455,16 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData, authConfig *auth.Credentials) (*clients.Client, diag.Diagnostics) {
 	skipProviderRegistration := d.Get("skip_provider_registration").(bool)
 
+	userFeatures := expandFeatures(d.Get("features").([]interface{}))
+	if err := validateFeatures(userFeatures); err != nil {
+		return nil, diag.Errorf("validating the `features` block: %+v", err)
+	}
+
 	clientBuilder := clients.ClientBuilder{
 		AuthConfig:                  authConfig,
 		DisableCorrelationRequestID: d.Get("disable_correlation_request_id").(bool),
 		DisableTerraformPartnerID:   d.Get("disable_terraform_partner_id").(bool),
-		Features:                    expandFeatures(d.Get("features").([]interface{})),
+		Features:                    userFeatures,
 		MetadataHost:                d.Get("metadata_host").(string),
 		PartnerID:                   d.Get("partner_id").(string),
 		SkipProviderRegistration:    skipProviderRegistration,
@@ -460,6 +477,11 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 		CustomCorrelationRequestID: os.Getenv("ARM_CORRELATION_REQUEST_ID"),
 	}
 
+	tagPolicy, err := expandTagPolicy(d.Get("tag_policy").([]interface{}))
+	if err != nil {
+		return nil, diag.Errorf("expanding `tag_policy`: %+v", err)
+	}
+
 	//lint:ignore SA1019 SDKv2 migration - staticcheck's own linter directives are currently being ignored under golanci-lint
 	stopCtx, ok := schema.StopContext(ctx) //nolint:staticcheck
 	if !ok {
@@ -472,6 +494,7 @@ func buildClient(ctx context.Context, p *schema.Provider, d *schema.ResourceData
 	}
 
 	client.StopContext = stopCtx
+	client.TagPolicy = tagPolicy
 
 	if !skipProviderRegistration {
 		subscriptionId := commonids.NewSubscriptionID(client.Account.SubscriptionId)
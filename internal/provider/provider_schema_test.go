@@ -318,6 +318,10 @@ func TestResourcesDoNotContainANameFieldWithADefaultOfDefault(t *testing.T) {
 		},
 
 		// 2: False Positives?
+		"azurerm_kubernetes_cluster_maintenance_configuration": {
+			// `default` is one of the fixed set of Maintenance Configuration names supported by the API
+			"name": {},
+		},
 		"azurerm_redis_enterprise_database": {
 			"name": {},
 		},
@@ -61,6 +61,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/healthcare"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/hsm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/hybridcompute"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/imagebuilder"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iotcentral"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iothub"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/iottimeseriesinsights"
@@ -175,6 +176,7 @@ func SupportedTypedServices() []sdk.TypedServiceRegistration {
 		graphservices.Registration{},
 		storagecache.Registration{},
 		hybridcompute.Registration{},
+		imagebuilder.Registration{},
 		iothub.Registration{},
 		iotcentral.Registration{},
 		keyvault.Registration{},
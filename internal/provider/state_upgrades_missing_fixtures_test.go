@@ -0,0 +1,240 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+// resourcesMissingStateUpgradeFixtures are the Resources (and their prior Schema Versions) which predate the
+// State Upgrade Fixtures and so don't yet have a fixture for each prior Schema Version.
+//
+// NOTE: this list must only shrink - new Resources (or new Schema Versions of the Resources below) must include
+// a fixture, and entries must be removed as the fixtures are added, which is enforced by TestResourcesUpgradeStateFixtures.
+var resourcesMissingStateUpgradeFixtures = map[string][]int{
+	"azurerm_advanced_threat_protection":                                 {0},
+	"azurerm_api_management_api":                                         {0},
+	"azurerm_api_management_api_operation_policy":                        {0, 1},
+	"azurerm_api_management_api_policy":                                  {0, 1},
+	"azurerm_api_management_api_version_set":                             {0},
+	"azurerm_api_management_gateway_api":                                 {0},
+	"azurerm_api_management_policy":                                      {0, 1, 2},
+	"azurerm_api_management_product_policy":                              {0, 1},
+	"azurerm_app_configuration_feature":                                  {0},
+	"azurerm_app_service_certificate_order":                              {0},
+	"azurerm_app_service_plan":                                           {0},
+	"azurerm_application_insights":                                       {0, 1},
+	"azurerm_application_insights_analytics_item":                        {0},
+	"azurerm_application_insights_api_key":                               {0, 1},
+	"azurerm_application_insights_smart_detection_rule":                  {0, 1},
+	"azurerm_application_insights_web_test":                              {0},
+	"azurerm_automanage_configuration":                                   {0},
+	"azurerm_automation_source_control":                                  {0},
+	"azurerm_automation_webhook":                                         {0},
+	"azurerm_cdn_endpoint":                                               {0},
+	"azurerm_cdn_profile":                                                {0},
+	"azurerm_communication_service":                                      {0},
+	"azurerm_consumption_budget_subscription":                            {0, 1},
+	"azurerm_container_registry":                                         {0, 1},
+	"azurerm_container_registry_webhook":                                 {0},
+	"azurerm_cosmosdb_cassandra_keyspace":                                {0},
+	"azurerm_cosmosdb_gremlin_database":                                  {0},
+	"azurerm_cosmosdb_gremlin_graph":                                     {0},
+	"azurerm_cosmosdb_mongo_collection":                                  {0},
+	"azurerm_cosmosdb_mongo_database":                                    {0},
+	"azurerm_cosmosdb_sql_container":                                     {0},
+	"azurerm_cosmosdb_sql_database":                                      {0},
+	"azurerm_cosmosdb_table":                                             {0},
+	"azurerm_data_factory":                                               {0, 1},
+	"azurerm_databox_edge_order":                                         {0},
+	"azurerm_databricks_workspace_customer_managed_key":                  {0},
+	"azurerm_dev_test_lab":                                               {0},
+	"azurerm_dev_test_linux_virtual_machine":                             {0},
+	"azurerm_dev_test_policy":                                            {0},
+	"azurerm_dev_test_schedule":                                          {0},
+	"azurerm_dev_test_virtual_network":                                   {0},
+	"azurerm_dev_test_windows_virtual_machine":                           {0},
+	"azurerm_dns_aaaa_record":                                            {0},
+	"azurerm_dns_caa_record":                                             {0},
+	"azurerm_dns_cname_record":                                           {0},
+	"azurerm_dns_mx_record":                                              {0},
+	"azurerm_dns_ns_record":                                              {0},
+	"azurerm_dns_ptr_record":                                             {0},
+	"azurerm_dns_srv_record":                                             {0},
+	"azurerm_dns_txt_record":                                             {0},
+	"azurerm_dns_zone":                                                   {0},
+	"azurerm_eventhub_authorization_rule":                                {0},
+	"azurerm_eventhub_consumer_group":                                    {0},
+	"azurerm_eventhub_namespace_authorization_rule":                      {0, 1},
+	"azurerm_frontdoor":                                                  {0, 1},
+	"azurerm_frontdoor_custom_https_configuration":                       {0},
+	"azurerm_frontdoor_firewall_policy":                                  {0},
+	"azurerm_frontdoor_rules_engine":                                     {0, 1},
+	"azurerm_healthcare_dicom_service":                                   {0},
+	"azurerm_healthcare_fhir_service":                                    {0},
+	"azurerm_healthcare_medtech_service":                                 {0},
+	"azurerm_healthcare_medtech_service_fhir_destination":                {0},
+	"azurerm_iot_security_solution":                                      {0},
+	"azurerm_iot_time_series_insights_access_policy":                     {0},
+	"azurerm_iotcentral_application":                                     {0, 1},
+	"azurerm_iothub":                                                     {0},
+	"azurerm_iothub_certificate":                                         {0},
+	"azurerm_iothub_consumer_group":                                      {0},
+	"azurerm_iothub_endpoint_eventhub":                                   {0},
+	"azurerm_iothub_endpoint_servicebus_queue":                           {0},
+	"azurerm_iothub_endpoint_servicebus_topic":                           {0},
+	"azurerm_iothub_endpoint_storage_container":                          {0},
+	"azurerm_iothub_enrichment":                                          {0},
+	"azurerm_iothub_fallback_route":                                      {0},
+	"azurerm_iothub_route":                                               {0},
+	"azurerm_iothub_shared_access_policy":                                {0},
+	"azurerm_key_vault":                                                  {0, 1},
+	"azurerm_key_vault_managed_hardware_security_module_role_assignment": {0},
+	"azurerm_key_vault_managed_hardware_security_module_role_definition": {0},
+	"azurerm_kubernetes_cluster":                                         {0, 1},
+	"azurerm_kubernetes_cluster_node_pool":                               {0},
+	"azurerm_kusto_attached_database_configuration":                      {0},
+	"azurerm_kusto_cluster":                                              {0},
+	"azurerm_kusto_cluster_customer_managed_key":                         {0},
+	"azurerm_kusto_cluster_managed_private_endpoint":                     {0, 1},
+	"azurerm_kusto_cluster_principal_assignment":                         {0},
+	"azurerm_kusto_database":                                             {0},
+	"azurerm_kusto_database_principal_assignment":                        {0},
+	"azurerm_kusto_eventgrid_data_connection":                            {0},
+	"azurerm_kusto_eventhub_data_connection":                             {0},
+	"azurerm_kusto_iothub_data_connection":                               {0},
+	"azurerm_kusto_script":                                               {0},
+	"azurerm_linux_function_app":                                         {0},
+	"azurerm_linux_function_app_slot":                                    {0},
+	"azurerm_linux_web_app":                                              {0},
+	"azurerm_linux_web_app_slot":                                         {0},
+	"azurerm_log_analytics_cluster_customer_managed_key":                 {0},
+	"azurerm_log_analytics_data_export_rule":                             {0},
+	"azurerm_log_analytics_datasource_windows_event":                     {0},
+	"azurerm_log_analytics_datasource_windows_performance_counter":       {0},
+	"azurerm_log_analytics_linked_storage_account":                       {0},
+	"azurerm_log_analytics_saved_search":                                 {0},
+	"azurerm_log_analytics_solution":                                     {0},
+	"azurerm_log_analytics_workspace":                                    {0, 1, 2},
+	"azurerm_maintenance_assignment_dedicated_host":                      {0},
+	"azurerm_maintenance_assignment_virtual_machine":                     {0},
+	"azurerm_maintenance_assignment_virtual_machine_scale_set":           {0},
+	"azurerm_maintenance_configuration":                                  {0},
+	"azurerm_managed_disk":                                               {0},
+	"azurerm_media_asset":                                                {0},
+	"azurerm_media_asset_filter":                                         {0},
+	"azurerm_media_content_key_policy":                                   {0},
+	"azurerm_media_job":                                                  {0},
+	"azurerm_media_live_event":                                           {0},
+	"azurerm_media_live_event_output":                                    {0},
+	"azurerm_media_services_account":                                     {0},
+	"azurerm_media_streaming_endpoint":                                   {0},
+	"azurerm_media_streaming_locator":                                    {0},
+	"azurerm_media_streaming_policy":                                     {0},
+	"azurerm_media_transform":                                            {0},
+	"azurerm_monitor_activity_log_alert":                                 {0},
+	"azurerm_monitor_autoscale_setting":                                  {0, 1},
+	"azurerm_monitor_log_profile":                                        {0},
+	"azurerm_monitor_metric_alert":                                       {0},
+	"azurerm_monitor_scheduled_query_rules_alert":                        {0},
+	"azurerm_monitor_scheduled_query_rules_log":                          {0},
+	"azurerm_monitor_smart_detector_alert_rule":                          {0},
+	"azurerm_mssql_database":                                             {0},
+	"azurerm_mssql_server_transparent_data_encryption":                   {0},
+	"azurerm_network_interface_application_security_group_association":   {0},
+	"azurerm_network_packet_capture":                                     {0},
+	"azurerm_network_watcher_flow_log":                                   {0},
+	"azurerm_notification_hub":                                           {0},
+	"azurerm_notification_hub_authorization_rule":                        {0},
+	"azurerm_notification_hub_namespace":                                 {0},
+	"azurerm_postgresql_active_directory_administrator":                  {0},
+	"azurerm_postgresql_database":                                        {0},
+	"azurerm_postgresql_server":                                          {0},
+	"azurerm_redis_cache":                                                {0},
+	"azurerm_redis_firewall_rule":                                        {0},
+	"azurerm_redis_linked_server":                                        {0},
+	"azurerm_role_definition":                                            {0},
+	"azurerm_security_center_auto_provisioning":                          {0},
+	"azurerm_security_center_setting":                                    {0},
+	"azurerm_security_center_subscription_pricing":                       {0},
+	"azurerm_sentinel_automation_rule":                                   {0},
+	"azurerm_service_plan":                                               {0},
+	"azurerm_servicebus_namespace":                                       {0},
+	"azurerm_servicebus_namespace_authorization_rule":                    {0},
+	"azurerm_servicebus_namespace_network_rule_set":                      {0},
+	"azurerm_servicebus_subscription":                                    {0},
+	"azurerm_signalr_service":                                            {0},
+	"azurerm_signalr_service_network_acl":                                {0},
+	"azurerm_snapshot":                                                   {0},
+	"azurerm_spring_cloud_accelerator":                                   {0},
+	"azurerm_spring_cloud_active_deployment":                             {0},
+	"azurerm_spring_cloud_api_portal":                                    {0},
+	"azurerm_spring_cloud_api_portal_custom_domain":                      {0},
+	"azurerm_spring_cloud_app":                                           {0},
+	"azurerm_spring_cloud_app_cosmosdb_association":                      {0},
+	"azurerm_spring_cloud_app_mysql_association":                         {0},
+	"azurerm_spring_cloud_app_redis_association":                         {0},
+	"azurerm_spring_cloud_build_deployment":                              {0},
+	"azurerm_spring_cloud_build_pack_binding":                            {0},
+	"azurerm_spring_cloud_builder":                                       {0},
+	"azurerm_spring_cloud_certificate":                                   {0},
+	"azurerm_spring_cloud_configuration_service":                         {0},
+	"azurerm_spring_cloud_container_deployment":                          {0},
+	"azurerm_spring_cloud_custom_domain":                                 {0},
+	"azurerm_spring_cloud_customized_accelerator":                        {0},
+	"azurerm_spring_cloud_gateway":                                       {0},
+	"azurerm_spring_cloud_gateway_custom_domain":                         {0},
+	"azurerm_spring_cloud_gateway_route_config":                          {0},
+	"azurerm_spring_cloud_java_deployment":                               {0},
+	"azurerm_spring_cloud_service":                                       {0},
+	"azurerm_spring_cloud_storage":                                       {0},
+	"azurerm_sql_active_directory_administrator":                         {0},
+	"azurerm_storage_account":                                            {0, 1, 2, 3},
+	"azurerm_storage_blob":                                               {0},
+	"azurerm_storage_blob_inventory_policy":                              {0},
+	"azurerm_storage_container":                                          {0},
+	"azurerm_storage_queue":                                              {0},
+	"azurerm_storage_share":                                              {0, 1},
+	"azurerm_storage_table":                                              {0, 1},
+	"azurerm_stream_analytics_cluster":                                   {0},
+	"azurerm_stream_analytics_function_javascript_uda":                   {0},
+	"azurerm_stream_analytics_function_javascript_udf":                   {0},
+	"azurerm_stream_analytics_job":                                       {0},
+	"azurerm_stream_analytics_job_schedule":                              {0},
+	"azurerm_stream_analytics_managed_private_endpoint":                  {0},
+	"azurerm_stream_analytics_output_blob":                               {0},
+	"azurerm_stream_analytics_output_cosmosdb":                           {0},
+	"azurerm_stream_analytics_output_eventhub":                           {0},
+	"azurerm_stream_analytics_output_function":                           {0},
+	"azurerm_stream_analytics_output_mssql":                              {0},
+	"azurerm_stream_analytics_output_powerbi":                            {0},
+	"azurerm_stream_analytics_output_servicebus_queue":                   {0},
+	"azurerm_stream_analytics_output_servicebus_topic":                   {0},
+	"azurerm_stream_analytics_output_synapse":                            {0},
+	"azurerm_stream_analytics_output_table":                              {0},
+	"azurerm_stream_analytics_reference_input_blob":                      {0},
+	"azurerm_stream_analytics_reference_input_mssql":                     {0},
+	"azurerm_stream_analytics_stream_input_blob":                         {0},
+	"azurerm_stream_analytics_stream_input_eventhub":                     {0},
+	"azurerm_stream_analytics_stream_input_eventhub_v2":                  {0},
+	"azurerm_stream_analytics_stream_input_iothub":                       {0},
+	"azurerm_synapse_integration_runtime_azure":                          {0},
+	"azurerm_synapse_integration_runtime_self_hosted":                    {0},
+	"azurerm_synapse_linked_service":                                     {0},
+	"azurerm_synapse_role_assignment":                                    {0},
+	"azurerm_template_deployment":                                        {0},
+	"azurerm_user_assigned_identity":                                     {0},
+	"azurerm_virtual_desktop_application_group":                          {0},
+	"azurerm_virtual_desktop_host_pool":                                  {0},
+	"azurerm_virtual_desktop_workspace":                                  {0},
+	"azurerm_virtual_desktop_workspace_application_group_association":    {0},
+	"azurerm_virtual_machine_scale_set":                                  {0},
+	"azurerm_web_application_firewall_policy":                            {0},
+	"azurerm_web_pubsub":                                                 {0},
+	"azurerm_web_pubsub_hub":                                             {0},
+	"azurerm_windows_function_app":                                       {0},
+	"azurerm_windows_function_app_slot":                                  {0},
+	"azurerm_windows_web_app":                                            {0},
+	"azurerm_windows_web_app_slot":                                       {0},
+}
+
+// resourcesMissingStateUpgradeFixturesLimit is the number of entries in resourcesMissingStateUpgradeFixtures when
+// this was introduced, which prevents new Resources from being added - this should be reduced, and never increased.
+const resourcesMissingStateUpgradeFixturesLimit = 224
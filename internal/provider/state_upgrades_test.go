@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/environments"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// stateUpgradeFixturesDirectory contains the recorded State for Resources at prior Schema Versions, within
+// a directory per Resource, e.g. `testdata/state-upgrades/azurerm_example/v0.json` - a fixture is required for
+// each prior Schema Version of every Resource, other than those in resourcesMissingStateUpgradeFixtures
+const stateUpgradeFixturesDirectory = "testdata/state-upgrades"
+
+func TestResourcesHaveStateUpgradersForEachSchemaVersion(t *testing.T) {
+	provider := TestAzureProvider()
+
+	// intentionally sorting these so the output is consistent
+	resourceNames := make([]string, 0)
+	for resourceName := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	for _, resourceName := range resourceNames {
+		resource := provider.ResourcesMap[resourceName]
+		if err := pluginsdk.VerifyStateUpgraders(resource); err != nil {
+			t.Errorf("the Resource %q has invalid State Upgraders: %+v", resourceName, err)
+		}
+	}
+}
+
+func TestResourcesUpgradeStateFixtures(t *testing.T) {
+	provider := TestAzureProvider()
+
+	// some State Upgraders use the Environment to build the new Resource ID, however no credentials are available
+	meta := &clients.Client{
+		Account: &clients.ResourceManagerAccount{
+			Environment: *environments.AzurePublic(),
+		},
+	}
+
+	if len(resourcesMissingStateUpgradeFixtures) > resourcesMissingStateUpgradeFixturesLimit {
+		t.Fatalf("%d Resources are allowed to be missing State Upgrade Fixtures but the limit is %d - new Resources must include a State Upgrade Fixture for each prior Schema Version", len(resourcesMissingStateUpgradeFixtures), resourcesMissingStateUpgradeFixturesLimit)
+	}
+
+	entries, err := os.ReadDir(stateUpgradeFixturesDirectory)
+	if err != nil {
+		t.Fatalf("reading %q: %+v", stateUpgradeFixturesDirectory, err)
+	}
+	for _, entry := range entries {
+		if _, ok := provider.ResourcesMap[entry.Name()]; !ok {
+			t.Errorf("State Upgrade Fixtures exist for %q but this Resource isn't registered", entry.Name())
+		}
+	}
+	for resourceName := range resourcesMissingStateUpgradeFixtures {
+		if _, ok := provider.ResourcesMap[resourceName]; !ok {
+			t.Errorf("%q is allowed to be missing State Upgrade Fixtures but this Resource isn't registered - remove it from `resourcesMissingStateUpgradeFixtures`", resourceName)
+		}
+	}
+
+	// intentionally sorting these so the output is consistent
+	resourceNames := make([]string, 0)
+	for resourceName := range provider.ResourcesMap {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	for _, resourceName := range resourceNames {
+		resource := provider.ResourcesMap[resourceName]
+
+		fixtures, err := pluginsdk.LoadStateUpgradeFixtures(filepath.Join(stateUpgradeFixturesDirectory, resourceName))
+		if err != nil {
+			t.Errorf("loading the State Upgrade Fixtures for %q: %+v", resourceName, err)
+			continue
+		}
+
+		missing := pluginsdk.MissingStateUpgradeFixtureVersions(resource, fixtures)
+		if allowed := resourcesMissingStateUpgradeFixtures[resourceName]; !reflect.DeepEqual(missing, append(make([]int, 0), allowed...)) {
+			if len(allowed) == 0 {
+				t.Errorf("the Resource %q has no State Upgrade Fixture for the Schema Version(s) %v - add these to %q", resourceName, missing, filepath.Join(stateUpgradeFixturesDirectory, resourceName))
+			} else {
+				t.Errorf("the Resource %q is missing State Upgrade Fixtures for the Schema Version(s) %v but %v are allowed to be missing - update `resourcesMissingStateUpgradeFixtures`", resourceName, missing, allowed)
+			}
+		}
+
+		for _, fixture := range fixtures {
+			t.Logf("[DEBUG] Verifying the State Upgrade Fixture %q for %q..", fixture.Name, resourceName)
+			if err := pluginsdk.VerifyStateUpgradeFixture(context.TODO(), resource, fixture, meta); err != nil {
+				t.Errorf("the State Upgrade Fixture %q for %q failed: %+v", fixture.Name, resourceName, err)
+			}
+		}
+	}
+}
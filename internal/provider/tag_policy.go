@@ -0,0 +1,169 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+func schemaTagPolicy() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:     pluginsdk.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &pluginsdk.Resource{
+			Schema: map[string]*pluginsdk.Schema{
+				"required_tags": {
+					Type:        pluginsdk.TypeSet,
+					Optional:    true,
+					Description: "A list of the tags which must be specified for each resource which supports tags.",
+					Elem: &pluginsdk.Schema{
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+
+				"allowed_value": {
+					Type:        pluginsdk.TypeList,
+					Optional:    true,
+					Description: "A regular expression which the value of a tag must match, when this tag is specified.",
+					Elem: &pluginsdk.Resource{
+						Schema: map[string]*pluginsdk.Schema{
+							"tag": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+
+							"pattern": {
+								Type:         pluginsdk.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringIsValidRegExp,
+							},
+						},
+					},
+				},
+
+				"case_sensitive_keys": {
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Should tag names be compared case-sensitively? Defaults to `false`, since tag names are case-insensitive in Azure.",
+				},
+
+				"enforce_lower_case_keys": {
+					Type:        pluginsdk.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Should all tag names be required to be lower-case?",
+				},
+			},
+		},
+	}
+}
+
+func expandTagPolicy(input []interface{}) (*tags.Policy, error) {
+	if len(input) == 0 || input[0] == nil {
+		return nil, nil
+	}
+
+	raw := input[0].(map[string]interface{})
+	policy := tags.Policy{
+		RequiredKeys:         make([]string, 0),
+		AllowedValues:        make(map[string]*regexp.Regexp),
+		CaseSensitiveKeys:    raw["case_sensitive_keys"].(bool),
+		EnforceLowerCaseKeys: raw["enforce_lower_case_keys"].(bool),
+	}
+
+	for _, v := range raw["required_tags"].(*pluginsdk.Set).List() {
+		policy.RequiredKeys = append(policy.RequiredKeys, v.(string))
+	}
+
+	for _, item := range raw["allowed_value"].([]interface{}) {
+		if item == nil {
+			continue
+		}
+		v := item.(map[string]interface{})
+		tag := v["tag"].(string)
+		if _, exists := policy.AllowedValues[tag]; exists {
+			return nil, fmt.Errorf("`allowed_value` is specified more than once for the tag %q", tag)
+		}
+
+		pattern, err := regexp.Compile(v["pattern"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("parsing the `pattern` for the tag %q: %+v", tag, err)
+		}
+		policy.AllowedValues[tag] = pattern
+	}
+
+	return &policy, nil
+}
+
+// withTagPolicy adds a CustomizeDiff to each Resource which supports tags (that is, any Resource with a
+// configurable `tags` field, as defined by `tags.Schema()`) which enforces the `tag_policy` during the plan.
+func withTagPolicy(resourceType string, resource *pluginsdk.Resource) *pluginsdk.Resource {
+	tagsSchema, ok := resource.Schema["tags"]
+	if !ok || tagsSchema.Type != pluginsdk.TypeMap || !(tagsSchema.Optional || tagsSchema.Required) {
+		return resource
+	}
+
+	existing := resource.CustomizeDiff
+	resource.CustomizeDiff = func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		if err := validateTagPolicy(resourceType, resource, d, meta); err != nil {
+			return err
+		}
+
+		if existing != nil {
+			return existing(ctx, d, meta)
+		}
+
+		return nil
+	}
+
+	return resource
+}
+
+func validateTagPolicy(resourceType string, resource *pluginsdk.Resource, d *pluginsdk.ResourceDiff, meta interface{}) error {
+	client, ok := meta.(*clients.Client)
+	if !ok || client.TagPolicy == nil {
+		return nil
+	}
+
+	// tags which reference other resources may not be known until apply
+	if !d.NewValueKnown("tags") {
+		return nil
+	}
+
+	input, ok := d.Get("tags").(map[string]interface{})
+	if !ok {
+		input = make(map[string]interface{})
+	}
+
+	errors := client.TagPolicy.Validate(input)
+	if len(errors) == 0 {
+		return nil
+	}
+
+	resourceName := resourceType
+	if _, ok := resource.Schema["name"]; ok {
+		if name, ok := d.Get("name").(string); ok && name != "" {
+			resourceName = fmt.Sprintf("%s %q", resourceType, name)
+		}
+	}
+
+	messages := make([]string, 0, len(errors))
+	for _, err := range errors {
+		messages = append(messages, fmt.Sprintf("* %s", err))
+	}
+
+	return fmt.Errorf("the tags for the %s don't comply with the `tag_policy` configured in the Provider:\n\n%s", resourceName, strings.Join(messages, "\n"))
+}
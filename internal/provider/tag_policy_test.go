@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+func TestTagPolicy(t *testing.T) {
+	provider := TestAzureProvider()
+	meta := &clients.Client{
+		StopContext: context.Background(),
+		TagPolicy: &tags.Policy{
+			RequiredKeys: []string{"cost-center", "owner"},
+			AllowedValues: map[string]*regexp.Regexp{
+				"environment": regexp.MustCompile("^(dev|test|prod)$"),
+			},
+		},
+	}
+
+	testData := []struct {
+		name          string
+		resourceType  string
+		config        map[string]interface{}
+		expectedError string
+	}{
+		{
+			name:         "untyped compliant",
+			resourceType: "azurerm_resource_group",
+			config: map[string]interface{}{
+				"name":     "example",
+				"location": "westeurope",
+				"tags": map[string]interface{}{
+					"cost-center": "1234",
+					"owner":       "platform",
+					"environment": "prod",
+				},
+			},
+		},
+		{
+			name:         "untyped non-compliant",
+			resourceType: "azurerm_resource_group",
+			config: map[string]interface{}{
+				"name":     "example",
+				"location": "westeurope",
+				"tags": map[string]interface{}{
+					"owner":       "platform",
+					"environment": "staging",
+				},
+			},
+			expectedError: `the tags for the azurerm_resource_group "example" don't comply with the ` + "`tag_policy`" + ` configured in the Provider:
+
+* the tag "cost-center" is required
+* the value "staging" for the tag "environment" doesn't match the pattern "^(dev|test|prod)$"`,
+		},
+		{
+			name:         "untyped without tags",
+			resourceType: "azurerm_resource_group",
+			config: map[string]interface{}{
+				"name":     "example",
+				"location": "westeurope",
+			},
+			expectedError: `* the tag "owner" is required`,
+		},
+		{
+			name:         "typed non-compliant",
+			resourceType: "azurerm_aadb2c_directory",
+			config: map[string]interface{}{
+				"domain_name":         "example.onmicrosoft.com",
+				"resource_group_name": "example",
+				"sku_name":            "PremiumP1",
+				"tags": map[string]interface{}{
+					"cost-center": "1234",
+				},
+			},
+			expectedError: `* the tag "owner" is required`,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		resource, ok := provider.ResourcesMap[v.resourceType]
+		if !ok {
+			t.Fatalf("the Resource %q was not found", v.resourceType)
+		}
+
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(v.config), meta)
+		if v.expectedError == "" {
+			if err != nil {
+				t.Fatalf("expected no error but got: %+v", err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), v.expectedError) {
+			t.Fatalf("expected an error containing:\n\n%s\n\nbut got:\n\n%+v", v.expectedError, err)
+		}
+	}
+}
+
+func TestTagPolicy_NotConfigured(t *testing.T) {
+	resource := TestAzureProvider().ResourcesMap["azurerm_resource_group"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "example",
+		"location": "westeurope",
+	})
+
+	if _, err := resource.Diff(context.Background(), nil, config, &clients.Client{}); err != nil {
+		t.Fatalf("expected no error when no `tag_policy` is configured but got: %+v", err)
+	}
+}
+
+func TestExpandTagPolicy(t *testing.T) {
+	if policy, err := expandTagPolicy([]interface{}{}); err != nil || policy != nil {
+		t.Fatalf("expected no policy when the block is omitted but got %+v / %+v", policy, err)
+	}
+
+	policy, err := expandTagPolicy([]interface{}{
+		map[string]interface{}{
+			"required_tags": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{"owner"}),
+			"allowed_value": []interface{}{
+				map[string]interface{}{
+					"tag":     "environment",
+					"pattern": "^(dev|prod)$",
+				},
+			},
+			"case_sensitive_keys":     true,
+			"enforce_lower_case_keys": true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("expanding the policy: %+v", err)
+	}
+	if len(policy.RequiredKeys) != 1 || policy.RequiredKeys[0] != "owner" {
+		t.Fatalf("expected the required tags to be [owner] but got %+v", policy.RequiredKeys)
+	}
+	if v, ok := policy.AllowedValues["environment"]; !ok || v.String() != "^(dev|prod)$" {
+		t.Fatalf("expected an allowed value for `environment` but got %+v", policy.AllowedValues)
+	}
+	if !policy.CaseSensitiveKeys || !policy.EnforceLowerCaseKeys {
+		t.Fatalf("expected `case_sensitive_keys` and `enforce_lower_case_keys` to be enabled")
+	}
+
+	_, err = expandTagPolicy([]interface{}{
+		map[string]interface{}{
+			"required_tags": pluginsdk.NewSet(pluginsdk.HashString, []interface{}{}),
+			"allowed_value": []interface{}{
+				map[string]interface{}{
+					"tag":     "environment",
+					"pattern": "^dev$",
+				},
+				map[string]interface{}{
+					"tag":     "environment",
+					"pattern": "^prod$",
+				},
+			},
+			"case_sensitive_keys":     false,
+			"enforce_lower_case_keys": false,
+		},
+	})
+	if err == nil {
+		t.Fatalf("expected an error when `allowed_value` is specified more than once for a tag")
+	}
+}
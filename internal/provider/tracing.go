@@ -0,0 +1,87 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withTracing wraps the CRUD functions of an untyped Resource or Data Source so that each is run within a
+// Span - Typed Resources and Data Sources are instead traced by the wrappers within the `sdk` package.
+func withTracing(resourceType string, resource *pluginsdk.Resource) *pluginsdk.Resource {
+	resource.Create = traceFunc(resourceType, "Create", resource.Create) //nolint:staticcheck
+	resource.Read = traceFunc(resourceType, "Read", resource.Read)       //nolint:staticcheck
+	resource.Update = traceFunc(resourceType, "Update", resource.Update) //nolint:staticcheck
+	resource.Delete = traceFunc(resourceType, "Delete", resource.Delete) //nolint:staticcheck
+
+	resource.CreateContext = traceContextFunc(resourceType, "Create", resource.CreateContext)
+	resource.ReadContext = traceContextFunc(resourceType, "Read", resource.ReadContext)
+	resource.UpdateContext = traceContextFunc(resourceType, "Update", resource.UpdateContext)
+	resource.DeleteContext = traceContextFunc(resourceType, "Delete", resource.DeleteContext)
+
+	return resource
+}
+
+func traceFunc(resourceType string, operation string, fn func(*pluginsdk.ResourceData, interface{}) error) func(*pluginsdk.ResourceData, interface{}) error {
+	if fn == nil {
+		return nil
+	}
+
+	return func(d *pluginsdk.ResourceData, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok {
+			return fn(d, meta)
+		}
+
+		ctx, span := tracing.StartOperation(client.StopContext, resourceType, operation, d.Id())
+		err := fn(d, clientForOperation(client, ctx))
+		tracing.EndOperation(span, d.Id(), err)
+		return err
+	}
+}
+
+func traceContextFunc(resourceType string, operation string, fn func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	if fn == nil {
+		return nil
+	}
+
+	return func(ctx context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, span := tracing.StartOperation(ctx, resourceType, operation, d.Id())
+
+		// these functions can also wrap a function which derives its Context from the StopContext of the Client
+		if client, ok := meta.(*clients.Client); ok {
+			meta = clientForOperation(client, trace.ContextWithSpan(client.StopContext, span))
+		}
+
+		diags := fn(ctx, d, meta)
+		tracing.EndOperation(span, d.Id(), diagnosticsError(diags))
+		return diags
+	}
+}
+
+// clientForOperation returns a copy of the Client whose StopContext is the specified Context containing the Span for
+// the operation - since the untyped CRUD functions derive their Context from the StopContext of the Client (via the
+// `timeouts` package) rather than accepting one, this allows the API requests made by the operation to be traced.
+func clientForOperation(client *clients.Client, ctx context.Context) *clients.Client {
+	operationClient := *client
+	operationClient.StopContext = ctx
+	return &operationClient
+}
+
+func diagnosticsError(diags diag.Diagnostics) error {
+	for _, v := range diags {
+		if v.Severity == diag.Error {
+			return fmt.Errorf("%s", v.Summary)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestWithTracing_legacyContext(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+	})
+
+	// the Context used by the operation is derived from the StopContext of the Client, via the `timeouts` package
+	spanFromTimeoutContext := func(d *pluginsdk.ResourceData, meta interface{}) trace.Span {
+		ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+		defer cancel()
+		return trace.SpanFromContext(ctx)
+	}
+
+	var legacySpan, contextSpan trace.Span
+	resource := withTracing("azurerm_example", &pluginsdk.Resource{
+		Read: func(d *pluginsdk.ResourceData, meta interface{}) error {
+			legacySpan = spanFromTimeoutContext(d, meta)
+			return nil
+		},
+		UpdateContext: func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+			contextSpan = spanFromTimeoutContext(d, meta)
+			return nil
+		},
+		Schema: map[string]*pluginsdk.Schema{},
+	})
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	meta := &clients.Client{
+		StopContext: context.Background(),
+	}
+
+	if err := resource.Read(d, meta); err != nil { //nolint:staticcheck
+		t.Fatalf("reading: %+v", err)
+	}
+	if !legacySpan.SpanContext().IsValid() {
+		t.Fatalf("expected the Context used by the Read function to contain the Span for the operation")
+	}
+
+	if diags := resource.UpdateContext(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("updating: %+v", diags)
+	}
+	if !contextSpan.SpanContext().IsValid() {
+		t.Fatalf("expected the Context derived from the StopContext within the Update function to contain the Span for the operation")
+	}
+	if legacySpan.SpanContext().SpanID() == contextSpan.SpanContext().SpanID() {
+		t.Fatalf("expected each operation to have a distinct Span")
+	}
+
+	// the shared Client shouldn't be modified
+	if trace.SpanFromContext(meta.StopContext).SpanContext().IsValid() {
+		t.Fatalf("expected the StopContext of the shared Client not to contain a Span")
+	}
+}
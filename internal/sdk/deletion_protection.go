@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+)
+
+// CheckDeletionProtection returns an error when the deletion of this resource is prevented by the
+// `deletion_protection` block within the `features` block of the Provider.
+//
+// The tags are read from the State, as such a resource which has the protected tag can only be
+// deleted once the tag has been removed in a prior apply.
+func CheckDeletionProtection(resourceType string, resourceSchema map[string]*schema.Schema, d *schema.ResourceData, meta interface{}) error {
+	client, ok := meta.(*clients.Client)
+	if !ok {
+		return nil
+	}
+
+	tags := make(map[string]interface{})
+	if _, ok := resourceSchema["tags"]; ok {
+		if v, ok := d.Get("tags").(map[string]interface{}); ok {
+			tags = v
+		}
+	}
+
+	return client.Features.DeletionProtection.PreventsDeletion(resourceType, d.Id(), tags)
+}
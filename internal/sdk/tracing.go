@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package sdk
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+)
+
+// runWithTracing invokes the specified ResourceRunFunc within a Span for this operation, so that any
+// API requests made using the Context are recorded as children of this Span
+func runWithTracing(ctx context.Context, resourceType string, operation string, fn ResourceRunFunc, metaData ResourceMetaData) error {
+	ctx, span := tracing.StartOperation(ctx, resourceType, operation, metaData.ResourceData.Id())
+	err := fn(ctx, metaData)
+	tracing.EndOperation(span, metaData.ResourceData.Id(), err)
+	return err
+}
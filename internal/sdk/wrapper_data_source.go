@@ -48,7 +48,7 @@ func (dw *DataSourceWrapper) DataSource() (*schema.Resource, error) {
 		Schema: *resourceSchema,
 		ReadContext: dw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, dw.logger)
-			return dw.dataSource.Read().Func(ctx, metaData)
+			return runWithTracing(ctx, dw.dataSource.ResourceType(), "Read", dw.dataSource.Read().Func, metaData)
 		}),
 		Timeouts: &schema.ResourceTimeout{
 			Read: d(dw.dataSource.Read().Timeout),
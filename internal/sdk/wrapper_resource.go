@@ -52,24 +52,27 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 
 		CreateContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			err := rw.resource.Create().Func(ctx, metaData)
+			err := runWithTracing(ctx, rw.resource.ResourceType(), "Create", rw.resource.Create().Func, metaData)
 			if err != nil {
 				return err
 			}
 			// NOTE: whilst this may look like we should use the Read
 			// functions timeout here, we're still /technically/ in the
 			// Create function so reusing that timeout should be sufficient
-			return rw.resource.Read().Func(ctx, metaData)
+			return runWithTracing(ctx, rw.resource.ResourceType(), "Read", rw.resource.Read().Func, metaData)
 		}),
 
 		// looks like these could be reused, easiest if they're not
 		ReadContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Read().Func(ctx, metaData)
+			return runWithTracing(ctx, rw.resource.ResourceType(), "Read", rw.resource.Read().Func, metaData)
 		}),
 		DeleteContext: rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
-			return rw.resource.Delete().Func(ctx, metaData)
+			if err := CheckDeletionProtection(rw.resource.ResourceType(), *resourceSchema, d, meta); err != nil {
+				return err
+			}
+			return runWithTracing(ctx, rw.resource.ResourceType(), "Delete", rw.resource.Delete().Func, metaData)
 		}),
 
 		Timeouts: &schema.ResourceTimeout{
@@ -118,14 +121,14 @@ func (rw *ResourceWrapper) Resource() (*schema.Resource, error) {
 		resource.UpdateContext = rw.diagnosticsWrapper(func(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 			metaData := runArgs(d, meta, rw.logger)
 
-			err := v.Update().Func(ctx, metaData)
+			err := runWithTracing(ctx, rw.resource.ResourceType(), "Update", v.Update().Func, metaData)
 			if err != nil {
 				return err
 			}
 			// whilst this may look like we should use the Update timeout here
 			// we're still "technically" in the update method, so reusing the
 			// Update's timeout should be fine
-			return rw.resource.Read().Func(ctx, metaData)
+			return runWithTracing(ctx, rw.resource.ResourceType(), "Read", rw.resource.Read().Func, metaData)
 		})
 		resource.Timeouts.Update = d(v.Update().Timeout)
 	}
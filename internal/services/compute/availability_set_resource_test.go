@@ -6,12 +6,15 @@ package compute_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/response"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -68,6 +71,50 @@ func TestAccAvailabilitySet_disappears(t *testing.T) {
 	})
 }
 
+func TestAvailabilitySet_mockWithTags(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_availability_set", "test")
+	data.UseMockServer(t, mockarm.NewServer(t))
+	r := AvailabilitySetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("managed").HasValue("true"),
+				check.That(data.ResourceName).Key("platform_update_domain_count").HasValue("5"),
+				check.That(data.ResourceName).Key("platform_fault_domain_count").HasValue("3"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withUpdatedTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("1"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("staging"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAvailabilitySet_mockCreateError(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_availability_set", "test")
+	server := mockarm.NewServer(t)
+	server.InjectError(http.MethodPut, "/availabilitySets/", 1, http.StatusBadRequest, "InvalidParameter")
+	data.UseMockServer(t, server)
+	r := AvailabilitySetResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.basic(data),
+			ExpectError: regexp.MustCompile("InvalidParameter"),
+		},
+	})
+}
+
 func TestAccAvailabilitySet_withTags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_availability_set", "test")
 	r := AvailabilitySetResource{}
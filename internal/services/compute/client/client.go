@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -50,6 +51,7 @@ type Client struct {
 	DedicatedHostsClient                        *dedicatedhosts.DedicatedHostsClient
 	DedicatedHostGroupsClient                   *dedicatedhostgroups.DedicatedHostGroupsClient
 	DisksClient                                 *disks.DisksClient
+	DiskUploadHTTPClient                        *http.Client
 	DiskAccessClient                            *diskaccesses.DiskAccessesClient
 	DiskEncryptionSetsClient                    *diskencryptionsets.DiskEncryptionSetsClient
 	GalleriesClient                             *galleries.GalleriesClient
@@ -250,6 +252,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		DedicatedHostsClient:                        dedicatedHostsClient,
 		DedicatedHostGroupsClient:                   dedicatedHostGroupsClient,
 		DisksClient:                                 disksClient,
+		DiskUploadHTTPClient:                        o.HTTPClient(),
 		DiskAccessClient:                            diskAccessClient,
 		DiskEncryptionSetsClient:                    diskEncryptionSetsClient,
 		GalleriesClient:                             galleriesClient,
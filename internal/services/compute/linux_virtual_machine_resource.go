@@ -54,6 +54,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineOSManagedDiskIdCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -380,6 +382,8 @@ func resourceLinuxVirtualMachine() *pluginsdk.Resource {
 
 			"os_image_notification": virtualMachineOsImageNotificationSchema(),
 
+			"os_managed_disk_id": virtualMachineOSManagedDiskIdSchema(),
+
 			"termination_notification": virtualMachineTerminationNotificationSchema(),
 
 			"user_data": {
@@ -977,6 +981,10 @@ func resourceLinuxVirtualMachineRead(d *pluginsdk.ResourceData, meta interface{}
 				if err := d.Set("os_disk", flattenedOSDisk); err != nil {
 					return fmt.Errorf("settings `os_disk`: %+v", err)
 				}
+				// this is only tracked once the OS Disk has been swapped, since it otherwise refers to the OS Disk the Virtual Machine was created with
+				if d.Get("os_managed_disk_id").(string) != "" {
+					d.Set("os_managed_disk_id", flattenVirtualMachineOSManagedDiskId(profile.OsDisk))
+				}
 
 				var storageImageId string
 				if profile.ImageReference != nil && profile.ImageReference.Id != nil {
@@ -1254,6 +1262,47 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 		update.Properties.StorageProfile.OsDisk = osDisk
 	}
 
+	shouldSwapOSDisk := false
+	if d.HasChange("os_managed_disk_id") && d.Get("os_managed_disk_id").(string) != "" {
+		var existingOSDisk *virtualmachines.OSDisk
+		if model := existing.Model; model != nil && model.Properties != nil && model.Properties.StorageProfile != nil {
+			existingOSDisk = model.Properties.StorageProfile.OsDisk
+		}
+
+		// there's nothing to swap when the Managed Disk is already the OS Disk (e.g. following an import)
+		shouldSwapOSDisk = !virtualMachineUsesOSManagedDisk(existingOSDisk, d.Get("os_managed_disk_id").(string))
+	}
+
+	if shouldSwapOSDisk {
+		if hasEphemeralOSDisk {
+			return fmt.Errorf("the OS Disk of Linux %s cannot be swapped since it uses an Ephemeral OS Disk", id)
+		}
+
+		// the OS Disk can only be swapped whilst the Virtual Machine is deallocated
+		shouldUpdate = true
+		shouldShutDown = true
+		shouldDeallocate = true
+
+		var osDisk *virtualmachines.OSDisk
+		if model := existing.Model; model != nil && model.Properties != nil && model.Properties.StorageProfile != nil {
+			osDisk = model.Properties.StorageProfile.OsDisk
+		}
+		if update.Properties.StorageProfile != nil && update.Properties.StorageProfile.OsDisk != nil {
+			osDisk = update.Properties.StorageProfile.OsDisk
+		}
+
+		swappedOSDisk, err := expandVirtualMachineOSDiskSwap(ctx, meta.(*clients.Client).Compute.DisksClient, d, osDisk, virtualmachines.OperatingSystemTypesLinux)
+		if err != nil {
+			return fmt.Errorf("swapping the OS Disk of Linux %s: %+v", id, err)
+		}
+
+		if update.Properties.StorageProfile == nil {
+			update.Properties.StorageProfile = &virtualmachines.StorageProfile{}
+		}
+
+		update.Properties.StorageProfile.OsDisk = swappedOSDisk
+	}
+
 	if d.HasChange("virtual_machine_scale_set_id") {
 		shouldUpdate = true
 
@@ -1546,7 +1595,12 @@ func resourceLinuxVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interface
 	if shouldShutDown {
 		log.Printf("[DEBUG] Shutting Down Linux %s", id)
 
-		if err := client.PowerOffThenPoll(ctx, *id, virtualmachines.DefaultPowerOffOperationOptions()); err != nil {
+		options := virtualmachines.DefaultPowerOffOperationOptions()
+		if shouldSwapOSDisk {
+			// the OS Disk is being detached, so shut down the guest OS first when `graceful_shutdown` is enabled - as when deleting
+			options.SkipShutdown = pointer.To(!meta.(*clients.Client).Features.VirtualMachine.GracefulShutdown)
+		}
+		if err := client.PowerOffThenPoll(ctx, *id, options); err != nil {
 			return fmt.Errorf("sending Power Off to Linux %s: %+v", id, err)
 		}
 
@@ -1716,6 +1770,12 @@ func resourceLinuxVirtualMachineDelete(d *pluginsdk.ResourceData, meta interface
 	log.Printf("[DEBUG] Deleted Linux %s", id)
 
 	deleteOSDisk := meta.(*clients.Client).Features.VirtualMachine.DeleteOSDiskOnDeletion
+	if v := d.Get("os_managed_disk_id").(string); deleteOSDisk && v != "" {
+		// the OS Disk has been swapped for a Managed Disk which isn't the one the Virtual Machine was created with,
+		// and which is likely managed elsewhere (e.g. by an `azurerm_managed_disk` resource)
+		log.Printf("[DEBUG] Skipping Deleting OS Disk %q from Linux %s since it was swapped using `os_managed_disk_id`", v, id)
+		deleteOSDisk = false
+	}
 	if deleteOSDisk {
 		log.Printf("[DEBUG] Deleting OS Disk from Linux %s", id)
 		disksClient := meta.(*clients.Client).Compute.DisksClient
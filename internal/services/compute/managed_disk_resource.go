@@ -138,6 +138,19 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				ForceNew: true,
 			},
 
+			"source_file": {
+				Type:          pluginsdk.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"source_resource_id", "source_uri"},
+			},
+
+			"source_file_hash": {
+				Type:     pluginsdk.TypeString,
+				Computed: true,
+			},
+
 			"storage_account_id": {
 				Type:         pluginsdk.TypeString,
 				Optional:     true,
@@ -179,6 +192,7 @@ func resourceManagedDisk() *pluginsdk.Resource {
 			"upload_size_bytes": {
 				Type:         pluginsdk.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
 				ValidateFunc: validation.IntAtLeast(1),
 			},
@@ -313,6 +327,7 @@ func resourceManagedDisk() *pluginsdk.Resource {
 				}
 				return len(old.([]interface{})) > 0 && len(new.([]interface{})) == 0
 			}),
+			managedDiskSourceFileCustomizeDiff,
 		),
 	}
 }
@@ -445,12 +460,32 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 		}
 	}
 
+	sourceFile := d.Get("source_file").(string)
 	if createOption == disks.DiskCreateOptionUpload {
 		if uploadSizeBytes := d.Get("upload_size_bytes").(int); uploadSizeBytes != 0 {
 			props.CreationData.UploadSizeBytes = utils.Int64(int64(uploadSizeBytes))
+		} else if sourceFile != "" {
+			fileSize, err := validateManagedDiskSourceFile(sourceFile)
+			if err != nil {
+				return fmt.Errorf("`source_file`: %+v", err)
+			}
+			props.CreationData.UploadSizeBytes = pointer.To(fileSize)
 		} else {
-			return fmt.Errorf("`upload_size_bytes` must be specified when `create_option` is set to `Upload`")
+			return fmt.Errorf("`upload_size_bytes` or `source_file` must be specified when `create_option` is set to `Upload`")
+		}
+
+		if sourceFile != "" {
+			// the file is hashed during the plan but is read again to upload it, so confirm it hasn't changed in the meantime
+			hash, err := managedDiskSourceFileHash(sourceFile)
+			if err != nil {
+				return fmt.Errorf("`source_file`: %+v", err)
+			}
+			if planned := d.Get("source_file_hash").(string); planned != "" && hash != planned {
+				return fmt.Errorf("the contents of `source_file` %q have changed since the plan was created (expected the SHA256 hash %q but got %q) - please plan again", sourceFile, planned, hash)
+			}
 		}
+	} else if sourceFile != "" {
+		return fmt.Errorf("`source_file` can only be specified when `create_option` is set to `Upload`")
 	}
 
 	if v, ok := d.GetOk("encryption_settings"); ok {
@@ -602,6 +637,14 @@ func resourceManagedDiskCreate(d *pluginsdk.ResourceData, meta interface{}) erro
 
 	d.SetId(id.ID())
 
+	if sourceFile != "" {
+		// the ID is set prior to uploading so that a failed upload taints the Managed Disk
+		httpClient := meta.(*clients.Client).Compute.DiskUploadHTTPClient
+		if err := uploadManagedDiskSourceFile(ctx, client, httpClient, id, sourceFile, d.Timeout(pluginsdk.TimeoutCreate)); err != nil {
+			return err
+		}
+	}
+
 	return resourceManagedDiskRead(d, meta)
 }
 
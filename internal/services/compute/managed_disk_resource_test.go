@@ -5,7 +5,10 @@ package compute_test
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -144,6 +147,59 @@ func TestAccManagedDisk_upload(t *testing.T) {
 	})
 }
 
+func TestAccManagedDisk_uploadFromSourceFile(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
+	r := ManagedDiskResource{}
+	sourceFile := filepath.Join(t.TempDir(), "disk.vhd")
+	writeManagedDiskTestVHD(t, sourceFile, "first")
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.uploadFromSourceFile(data, sourceFile),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upload_size_bytes").HasValue("33554944"),
+				check.That(data.ResourceName).Key("source_file_hash").IsNotEmpty(),
+			),
+		},
+		data.ImportStep("source_file", "source_file_hash"),
+		{
+			PreConfig: func() {
+				writeManagedDiskTestVHD(t, sourceFile, "second")
+			},
+			Config: r.uploadFromSourceFile(data, sourceFile),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("source_file_hash").IsNotEmpty(),
+			),
+		},
+		data.ImportStep("source_file", "source_file_hash"),
+	})
+}
+
+// writeManagedDiskTestVHD writes a 32 MiB fixed size VHD containing content to path
+func writeManagedDiskTestVHD(t *testing.T, path string, content string) {
+	virtualSize := 32 * 1024 * 1024
+	vhd := make([]byte, virtualSize+512)
+	copy(vhd, content)
+
+	footer := vhd[virtualSize:]
+	copy(footer[0:8], "conectix")
+	binary.BigEndian.PutUint32(footer[12:16], 0x00010000)
+	binary.BigEndian.PutUint64(footer[40:48], uint64(virtualSize))
+	binary.BigEndian.PutUint64(footer[48:56], uint64(virtualSize))
+	binary.BigEndian.PutUint32(footer[60:64], 2)
+	checksum := uint32(0)
+	for _, b := range footer {
+		checksum += uint32(b)
+	}
+	binary.BigEndian.PutUint32(footer[64:68], ^checksum)
+
+	if err := os.WriteFile(path, vhd, 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", path, err)
+	}
+}
+
 func TestAccManagedDisk_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_managed_disk", "test")
 	r := ManagedDiskResource{}
@@ -1313,6 +1369,28 @@ resource "azurerm_managed_disk" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (ManagedDiskResource) uploadFromSourceFile(data acceptance.TestData, sourceFile string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestd-%d"
+  location             = azurerm_resource_group.test.location
+  resource_group_name  = azurerm_resource_group.test.name
+  create_option        = "Upload"
+  storage_account_type = "Standard_LRS"
+  source_file          = "%s"
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, filepath.ToSlash(sourceFile))
+}
+
 func (ManagedDiskResource) encryptionTemplate(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
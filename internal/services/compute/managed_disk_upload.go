@@ -0,0 +1,378 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2023-04-02/disks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+const (
+	// a VHD is terminated by a 512 byte footer, see https://learn.microsoft.com/windows/win32/vstor/about-vhd
+	vhdFooterSize    int64 = 512
+	vhdFooterCookie        = "conectix"
+	vhdDiskTypeFixed       = 2
+
+	// Managed Disks require the virtual size of an uploaded VHD to be aligned to 1 MiB
+	vhdVirtualSizeAlignment int64 = 1024 * 1024
+
+	managedDiskUploadMinPageSize int64 = 4 * 1024
+	managedDiskUploadMaxPageSize int64 = 4 * 1024 * 1024
+
+	managedDiskUploadParallelism = 8
+	managedDiskUploadAPIVersion  = "2020-12-06"
+)
+
+// validateManagedDiskSourceFile confirms that the file at path is a fixed size VHD which can be uploaded
+// into a Managed Disk, returning the size in bytes which should be used for `upload_size_bytes`.
+func validateManagedDiskSourceFile(path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("opening %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("retrieving information about %q: %+v", path, err)
+	}
+
+	fileSize := info.Size()
+	if fileSize < vhdFooterSize {
+		return 0, fmt.Errorf("%q is not a valid VHD: the file is smaller than a VHD footer", path)
+	}
+
+	footer := make([]byte, vhdFooterSize)
+	if _, err := file.ReadAt(footer, fileSize-vhdFooterSize); err != nil {
+		return 0, fmt.Errorf("reading the VHD footer of %q: %+v", path, err)
+	}
+
+	if err := validateVHDFooter(footer, fileSize); err != nil {
+		return 0, fmt.Errorf("%q is not a valid VHD: %+v", path, err)
+	}
+
+	return fileSize, nil
+}
+
+func validateVHDFooter(footer []byte, fileSize int64) error {
+	if int64(len(footer)) != vhdFooterSize {
+		return fmt.Errorf("expected a footer of %d bytes but got %d", vhdFooterSize, len(footer))
+	}
+
+	if cookie := string(footer[0:8]); cookie != vhdFooterCookie {
+		return fmt.Errorf("expected the footer cookie to be %q but got %q", vhdFooterCookie, cookie)
+	}
+
+	expectedChecksum := binary.BigEndian.Uint32(footer[64:68])
+	checksum := uint32(0)
+	for i, b := range footer {
+		if i >= 64 && i < 68 {
+			continue
+		}
+		checksum += uint32(b)
+	}
+	if checksum = ^checksum; checksum != expectedChecksum {
+		return fmt.Errorf("the footer checksum %d does not match the calculated checksum %d", expectedChecksum, checksum)
+	}
+
+	if diskType := binary.BigEndian.Uint32(footer[60:64]); diskType != vhdDiskTypeFixed {
+		return fmt.Errorf("only fixed size VHDs can be uploaded but the disk type was %d", diskType)
+	}
+
+	virtualSize := int64(binary.BigEndian.Uint64(footer[48:56]))
+	if virtualSize%vhdVirtualSizeAlignment != 0 {
+		return fmt.Errorf("the virtual size of the VHD (%d bytes) must be a multiple of 1 MiB", virtualSize)
+	}
+	if virtualSize+vhdFooterSize != fileSize {
+		return fmt.Errorf("the virtual size of the VHD (%d bytes) plus the footer does not match the file size (%d bytes)", virtualSize, fileSize)
+	}
+
+	return nil
+}
+
+// managedDiskSourceFileHash returns the hex encoded SHA256 hash of the file at path, which is used to detect changes to `source_file`
+func managedDiskSourceFileHash(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening %q: %+v", path, err)
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("hashing %q: %+v", path, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// managedDiskSourceFileCustomizeDiff validates `source_file` at plan time and, since the file contents can't be
+// retrieved from Azure, replaces the Managed Disk when the hash of the file changes
+func managedDiskSourceFileCustomizeDiff(ctx context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("source_file") {
+		return nil
+	}
+	sourceFile := diff.Get("source_file").(string)
+	if sourceFile == "" {
+		return nil
+	}
+
+	if createOption := diff.Get("create_option").(string); createOption != string(disks.DiskCreateOptionUpload) {
+		return fmt.Errorf("`source_file` can only be specified when `create_option` is set to `Upload`")
+	}
+
+	if _, err := os.Stat(sourceFile); os.IsNotExist(err) && diff.Id() != "" {
+		// the file has already been uploaded, so there's nothing to compare against
+		log.Printf("[DEBUG] `source_file` %q no longer exists - skipping the change detection for %s", sourceFile, diff.Id())
+		return nil
+	}
+
+	hash, err := managedDiskSourceFileHash(sourceFile)
+	if err != nil {
+		return fmt.Errorf("`source_file`: %+v", err)
+	}
+	if hash == diff.Get("source_file_hash").(string) {
+		return nil
+	}
+
+	fileSize, err := validateManagedDiskSourceFile(sourceFile)
+	if err != nil {
+		return fmt.Errorf("`source_file`: %+v", err)
+	}
+
+	if v := diff.GetRawConfig().AsValueMap()["upload_size_bytes"]; v.IsNull() {
+		if err := diff.SetNew("upload_size_bytes", int(fileSize)); err != nil {
+			return fmt.Errorf("setting `upload_size_bytes`: %+v", err)
+		}
+	} else if uploadSizeBytes := diff.Get("upload_size_bytes").(int); diff.NewValueKnown("upload_size_bytes") && int64(uploadSizeBytes) != fileSize {
+		return fmt.Errorf("`upload_size_bytes` (%d) must match the size of `source_file` (%d bytes)", uploadSizeBytes, fileSize)
+	}
+
+	if err := diff.SetNew("source_file_hash", hash); err != nil {
+		return fmt.Errorf("setting `source_file_hash`: %+v", err)
+	}
+	if diff.Id() != "" {
+		return diff.ForceNew("source_file_hash")
+	}
+
+	return nil
+}
+
+// uploadManagedDiskSourceFile grants write access to a Managed Disk in the `ReadyToUpload` state, uploads the
+// contents of sourceFile using httpClient and then revokes access - which is required before the Disk can be used
+func uploadManagedDiskSourceFile(ctx context.Context, client *disks.DisksClient, httpClient *http.Client, id commonids.ManagedDiskId, sourceFile string, duration time.Duration) error {
+	grantAccessData := disks.GrantAccessData{
+		Access:            disks.AccessLevelWrite,
+		DurationInSeconds: int64(duration.Seconds()),
+	}
+	future, err := client.GrantAccess(ctx, id, grantAccessData)
+	if err != nil {
+		return fmt.Errorf("granting write access to %s: %+v", id, err)
+	}
+	if err := future.Poller.PollUntilDone(ctx); err != nil {
+		return fmt.Errorf("waiting for write access to be granted to %s: %+v", id, err)
+	}
+
+	lastResponse := future.Poller.LatestResponse()
+	if lastResponse == nil {
+		return fmt.Errorf("waiting for write access to be granted to %s: last response was nil", id)
+	}
+	var result Result
+	if err := lastResponse.Unmarshal(&result); err != nil {
+		return fmt.Errorf("retrieving the SAS URL for %s: %+v", id, err)
+	}
+
+	upload := managedDiskUpload{
+		AccessSAS:  result.Properties.Output.AccessSAS,
+		HTTPClient: httpClient,
+		Source:     sourceFile,
+	}
+	uploadErr := upload.Upload(ctx)
+
+	// access must be revoked regardless of whether the upload succeeded, otherwise the Disk can't be used or deleted
+	if err := client.RevokeAccessThenPoll(ctx, id); err != nil {
+		if uploadErr != nil {
+			return fmt.Errorf("uploading to %s: %+v (additionally revoking access failed: %+v)", id, uploadErr, err)
+		}
+		return fmt.Errorf("revoking write access to %s: %+v", id, err)
+	}
+
+	if uploadErr != nil {
+		return fmt.Errorf("uploading to %s: %+v", id, uploadErr)
+	}
+
+	return nil
+}
+
+type managedDiskUpload struct {
+	// AccessSAS is the writable SAS URL returned when granting access to a Managed Disk in the `ReadyToUpload` state
+	AccessSAS string
+	// HTTPClient is used to write the pages, which should retry failed requests (see common.ClientOptions.HTTPClient)
+	HTTPClient *http.Client
+	Source     string
+}
+
+type managedDiskUploadPage struct {
+	offset int64
+	length int64
+}
+
+func (u managedDiskUpload) Upload(ctx context.Context) error {
+	file, err := os.Open(u.Source)
+	if err != nil {
+		return fmt.Errorf("opening source file for upload %q: %+v", u.Source, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("retrieving information about %q: %+v", u.Source, err)
+	}
+
+	pageList, err := managedDiskUploadPageSplit(file, info.Size())
+	if err != nil {
+		return fmt.Errorf("splitting source file %q into pages: %+v", u.Source, err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pages := make(chan managedDiskUploadPage, len(pageList))
+	for _, page := range pageList {
+		pages <- page
+	}
+	close(pages)
+
+	errors := make(chan error, len(pageList))
+	wg := &sync.WaitGroup{}
+	for i := 0; i < managedDiskUploadParallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				if ctx.Err() != nil {
+					return
+				}
+				if err := u.uploadPage(ctx, file, page); err != nil {
+					errors <- err
+					// there's no point continuing once a page has failed, since the Disk will be incomplete
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(errors) > 0 {
+		return fmt.Errorf("uploading source file %q: %+v", u.Source, <-errors)
+	}
+
+	return ctx.Err()
+}
+
+func (u managedDiskUpload) uploadPage(ctx context.Context, file io.ReaderAt, page managedDiskUploadPage) error {
+	chunk := make([]byte, page.length)
+	if _, err := file.ReadAt(chunk, page.offset); err != nil && err != io.EOF {
+		return fmt.Errorf("reading source file %q at offset %d: %+v", u.Source, page.offset, err)
+	}
+
+	uri, err := url.Parse(u.AccessSAS)
+	if err != nil {
+		return fmt.Errorf("parsing the SAS URL: %+v", err)
+	}
+	query := uri.Query()
+	query.Set("comp", "page")
+	uri.RawQuery = query.Encode()
+
+	// the page isn't logged, since the body is binary data of up to 4 MiB and the URL contains the SAS token
+	req, err := http.NewRequestWithContext(common.WithCredentialRequest(ctx), http.MethodPut, uri.String(), bytes.NewReader(chunk))
+	if err != nil {
+		return fmt.Errorf("building request for page at offset %d: %+v", page.offset, err)
+	}
+	req.ContentLength = page.length
+	req.Header.Set("x-ms-page-write", "update")
+	req.Header.Set("x-ms-range", fmt.Sprintf("bytes=%d-%d", page.offset, page.offset+page.length-1))
+	req.Header.Set("x-ms-version", managedDiskUploadAPIVersion)
+
+	httpClient := u.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("writing page at offset %d: %+v", page.offset, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("writing page at offset %d: unexpected status %d: %s", page.offset, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// managedDiskUploadPageSplit splits the file into pages of up to 4 MiB, omitting any ranges which are entirely
+// zero since a newly created Managed Disk is already zero-filled
+func managedDiskUploadPageSplit(file io.ReaderAt, fileSize int64) ([]managedDiskUploadPage, error) {
+	if fileSize%512 != 0 {
+		return nil, fmt.Errorf("the file size (%d bytes) must be a multiple of 512 bytes", fileSize)
+	}
+
+	emptyPage := make([]byte, managedDiskUploadMinPageSize)
+	buf := make([]byte, managedDiskUploadMinPageSize)
+
+	var pages []managedDiskUploadPage
+	current := managedDiskUploadPage{}
+	for offset := int64(0); offset < fileSize; offset += managedDiskUploadMinPageSize {
+		length := managedDiskUploadMinPageSize
+		if remaining := fileSize - offset; remaining < length {
+			length = remaining
+		}
+
+		if _, err := file.ReadAt(buf[:length], offset); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("reading chunk at %d: %+v", offset, err)
+		}
+
+		if bytes.Equal(buf[:length], emptyPage[:length]) {
+			if current.length != 0 {
+				pages = append(pages, current)
+			}
+			current = managedDiskUploadPage{}
+			continue
+		}
+
+		if current.length == 0 {
+			current.offset = offset
+		}
+		current.length += length
+		if current.length == managedDiskUploadMaxPageSize {
+			pages = append(pages, current)
+			current = managedDiskUploadPage{}
+		}
+	}
+	if current.length != 0 {
+		pages = append(pages, current)
+	}
+
+	return pages, nil
+}
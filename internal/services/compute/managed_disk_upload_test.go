@@ -0,0 +1,302 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package compute
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// testVHDFooter builds a valid footer for a fixed size VHD with the specified virtual size
+func testVHDFooter(virtualSize int64) []byte {
+	footer := make([]byte, vhdFooterSize)
+	copy(footer[0:8], vhdFooterCookie)
+	binary.BigEndian.PutUint32(footer[12:16], 0x00010000)
+	binary.BigEndian.PutUint64(footer[40:48], uint64(virtualSize))
+	binary.BigEndian.PutUint64(footer[48:56], uint64(virtualSize))
+	binary.BigEndian.PutUint32(footer[60:64], vhdDiskTypeFixed)
+
+	checksum := uint32(0)
+	for _, b := range footer {
+		checksum += uint32(b)
+	}
+	binary.BigEndian.PutUint32(footer[64:68], ^checksum)
+
+	return footer
+}
+
+// testVHD returns a 2 MiB fixed size VHD with data in the first and last 4 KiB of the first MiB
+func testVHD() []byte {
+	virtualSize := 2 * vhdVirtualSizeAlignment
+	data := make([]byte, virtualSize)
+	copy(data[0:], bytes.Repeat([]byte("a"), 4096))
+	copy(data[vhdVirtualSizeAlignment-4096:], bytes.Repeat([]byte("b"), 4096))
+
+	return append(data, testVHDFooter(virtualSize)...)
+}
+
+func TestValidateVHDFooter(t *testing.T) {
+	virtualSize := vhdVirtualSizeAlignment
+	fileSize := virtualSize + vhdFooterSize
+
+	testData := []struct {
+		name     string
+		footer   func() []byte
+		fileSize int64
+		error    string
+	}{
+		{
+			name:     "valid",
+			footer:   func() []byte { return testVHDFooter(virtualSize) },
+			fileSize: fileSize,
+		},
+		{
+			name: "invalid cookie",
+			footer: func() []byte {
+				footer := testVHDFooter(virtualSize)
+				copy(footer[0:8], "notavhd!")
+				return footer
+			},
+			fileSize: fileSize,
+			error:    "cookie",
+		},
+		{
+			name: "invalid checksum",
+			footer: func() []byte {
+				footer := testVHDFooter(virtualSize)
+				footer[100] = 1
+				return footer
+			},
+			fileSize: fileSize,
+			error:    "checksum",
+		},
+		{
+			name: "dynamic disk",
+			footer: func() []byte {
+				footer := testVHDFooter(virtualSize)
+				binary.BigEndian.PutUint32(footer[60:64], 3)
+				binary.BigEndian.PutUint32(footer[64:68], binary.BigEndian.Uint32(footer[64:68])-1)
+				return footer
+			},
+			fileSize: fileSize,
+			error:    "fixed size",
+		},
+		{
+			name:     "unaligned virtual size",
+			footer:   func() []byte { return testVHDFooter(virtualSize + 512) },
+			fileSize: fileSize + 512,
+			error:    "multiple of 1 MiB",
+		},
+		{
+			name:     "truncated file",
+			footer:   func() []byte { return testVHDFooter(virtualSize) },
+			fileSize: fileSize - 512,
+			error:    "does not match the file size",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := validateVHDFooter(v.footer(), v.fileSize)
+		if v.error == "" && err != nil {
+			t.Fatalf("expected %q to be valid but got: %+v", v.name, err)
+		}
+		if v.error != "" && (err == nil || !strings.Contains(err.Error(), v.error)) {
+			t.Fatalf("expected %q to fail with %q but got: %+v", v.name, v.error, err)
+		}
+	}
+}
+
+func TestValidateManagedDiskSourceFile(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.vhd")
+	if err := os.WriteFile(valid, testVHD(), 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", valid, err)
+	}
+	size, err := validateManagedDiskSourceFile(valid)
+	if err != nil {
+		t.Fatalf("expected %q to be valid but got: %+v", valid, err)
+	}
+	if expected := 2*vhdVirtualSizeAlignment + vhdFooterSize; size != expected {
+		t.Fatalf("expected the size to be %d but got %d", expected, size)
+	}
+
+	tooSmall := filepath.Join(dir, "small.vhd")
+	if err := os.WriteFile(tooSmall, []byte("conectix"), 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", tooSmall, err)
+	}
+	if _, err := validateManagedDiskSourceFile(tooSmall); err == nil {
+		t.Fatalf("expected %q to be invalid", tooSmall)
+	}
+
+	if _, err := validateManagedDiskSourceFile(filepath.Join(dir, "missing.vhd")); err == nil {
+		t.Fatalf("expected a missing file to be invalid")
+	}
+}
+
+func TestManagedDiskUploadPageSplit(t *testing.T) {
+	data := testVHD()
+
+	pages, err := managedDiskUploadPageSplit(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("splitting pages: %+v", err)
+	}
+
+	expected := []managedDiskUploadPage{
+		{offset: 0, length: 4096},
+		{offset: vhdVirtualSizeAlignment - 4096, length: 4096},
+		{offset: 2 * vhdVirtualSizeAlignment, length: vhdFooterSize},
+	}
+	if len(pages) != len(expected) {
+		t.Fatalf("expected %d pages but got %d: %+v", len(expected), len(pages), pages)
+	}
+	for i := range expected {
+		if pages[i] != expected[i] {
+			t.Fatalf("expected page %d to be %+v but got %+v", i, expected[i], pages[i])
+		}
+	}
+
+	full := bytes.Repeat([]byte("c"), int(managedDiskUploadMaxPageSize+4096))
+	pages, err = managedDiskUploadPageSplit(bytes.NewReader(full), int64(len(full)))
+	if err != nil {
+		t.Fatalf("splitting pages: %+v", err)
+	}
+	if len(pages) != 2 || pages[0].length != managedDiskUploadMaxPageSize || pages[1].offset != managedDiskUploadMaxPageSize {
+		t.Fatalf("expected contiguous data to be split at %d bytes but got %+v", managedDiskUploadMaxPageSize, pages)
+	}
+
+	if _, err := managedDiskUploadPageSplit(bytes.NewReader([]byte("abc")), 3); err == nil {
+		t.Fatalf("expected a file which isn't 512 byte aligned to be rejected")
+	}
+}
+
+// testPageBlobServer is a minimal stand-in for the Put Page API of a Managed Disk's SAS URL
+type testPageBlobServer struct {
+	sync.Mutex
+
+	blob   []byte
+	writes int
+	fail   bool
+	// throttle is the number of subsequent writes which should fail with a transient error
+	throttle int
+}
+
+func (s *testPageBlobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut || r.URL.Query().Get("comp") != "page" || r.URL.Query().Get("sig") == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if r.Header.Get("x-ms-page-write") != "update" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var start, end int64
+	if _, err := fmt.Sscanf(r.Header.Get("x-ms-range"), "bytes=%d-%d", &start, &end); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil || int64(len(body)) != end-start+1 || start%512 != 0 || (end+1)%512 != 0 || end >= int64(len(s.blob)) {
+		w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	if s.fail {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("AuthenticationFailed"))
+		return
+	}
+	if s.throttle > 0 {
+		s.throttle--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte("ServerBusy"))
+		return
+	}
+	copy(s.blob[start:], body)
+	s.writes++
+	w.WriteHeader(http.StatusCreated)
+}
+
+func TestManagedDiskUpload(t *testing.T) {
+	data := testVHD()
+	source := filepath.Join(t.TempDir(), "disk.vhd")
+	if err := os.WriteFile(source, data, 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", source, err)
+	}
+
+	blob := &testPageBlobServer{
+		blob: make([]byte, len(data)),
+	}
+	server := httptest.NewServer(blob)
+	defer server.Close()
+
+	upload := managedDiskUpload{
+		AccessSAS:  server.URL + "/disk/abcd?sv=2018-03-28&sr=b&sig=signature",
+		HTTPClient: server.Client(),
+		Source:     source,
+	}
+	if err := upload.Upload(context.Background()); err != nil {
+		t.Fatalf("uploading: %+v", err)
+	}
+
+	if !bytes.Equal(blob.blob, data) {
+		t.Fatalf("expected the uploaded blob to match the source file")
+	}
+	if blob.writes != 3 {
+		t.Fatalf("expected the empty ranges to be skipped with 3 pages written but got %d", blob.writes)
+	}
+
+	blob.fail = true
+	if err := upload.Upload(context.Background()); err == nil || !strings.Contains(err.Error(), "AuthenticationFailed") {
+		t.Fatalf("expected the upload to fail but got: %+v", err)
+	}
+}
+
+func TestManagedDiskUpload_retriesTransientErrors(t *testing.T) {
+	data := testVHD()
+	source := filepath.Join(t.TempDir(), "disk.vhd")
+	if err := os.WriteFile(source, data, 0o600); err != nil {
+		t.Fatalf("writing %q: %+v", source, err)
+	}
+
+	blob := &testPageBlobServer{
+		blob:     make([]byte, len(data)),
+		throttle: 2,
+	}
+	server := httptest.NewServer(blob)
+	defer server.Close()
+
+	upload := managedDiskUpload{
+		AccessSAS:  server.URL + "/disk/abcd?sv=2018-03-28&sr=b&sig=signature",
+		HTTPClient: common.ClientOptions{}.HTTPClient(),
+		Source:     source,
+	}
+	if err := upload.Upload(context.Background()); err != nil {
+		t.Fatalf("expected the throttled pages to be retried but got: %+v", err)
+	}
+
+	if !bytes.Equal(blob.blob, data) {
+		t.Fatalf("expected the uploaded blob to match the source file")
+	}
+	if blob.throttle != 0 {
+		t.Fatalf("expected the throttled writes to have been attempted")
+	}
+}
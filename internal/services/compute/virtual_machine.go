@@ -6,6 +6,7 @@ package compute
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
@@ -394,6 +395,103 @@ func flattenVirtualMachineOSDisk(ctx context.Context, disksClient *disks.DisksCl
 	}, nil
 }
 
+func virtualMachineOSManagedDiskIdSchema() *pluginsdk.Schema {
+	return &pluginsdk.Schema{
+		Type:         pluginsdk.TypeString,
+		Optional:     true,
+		ValidateFunc: commonids.ValidateManagedDiskID,
+		// once swapped the Virtual Machine no longer uses the OS Disk it was created with, so this is retained in the state
+		// when it's removed from the config to ensure the swapped Managed Disk isn't deleted along with the Virtual Machine
+		DiffSuppressFunc: func(_, old, new string, _ *pluginsdk.ResourceData) bool {
+			return old != "" && new == ""
+		},
+	}
+}
+
+// virtualMachineOSManagedDiskIdCustomizeDiff raises at plan time that `os_managed_disk_id` can't be used when the Virtual
+// Machine is created (or replaced), rather than failing part way through the apply
+func virtualMachineOSManagedDiskIdCustomizeDiff(_ context.Context, diff *pluginsdk.ResourceDiff, _ interface{}) error {
+	if diff.Id() != "" {
+		return nil
+	}
+
+	if v := diff.GetRawConfig().GetAttr("os_managed_disk_id"); !v.IsNull() {
+		return fmt.Errorf("`os_managed_disk_id` can only be specified to swap the OS Disk of an existing Virtual Machine - it must be removed when the Virtual Machine is created or replaced")
+	}
+
+	return nil
+}
+
+// virtualMachineUsesOSManagedDisk returns whether the Managed Disk `diskId` is already the OS Disk of the Virtual Machine
+func virtualMachineUsesOSManagedDisk(input *virtualmachines.OSDisk, diskId string) bool {
+	return diskId != "" && strings.EqualFold(flattenVirtualMachineOSManagedDiskId(input), diskId)
+}
+
+func flattenVirtualMachineOSManagedDiskId(input *virtualmachines.OSDisk) string {
+	if input == nil || input.ManagedDisk == nil || input.ManagedDisk.Id == nil {
+		return ""
+	}
+
+	// the Compute/VM API returns the Resource Group name in UPPERCASE
+	id, err := commonids.ParseManagedDiskIDInsensitively(*input.ManagedDisk.Id)
+	if err != nil {
+		return *input.ManagedDisk.Id
+	}
+
+	return id.ID()
+}
+
+// expandVirtualMachineOSDiskSwap returns the OS Disk which swaps the existing OS Disk of a deallocated Virtual Machine for
+// the Managed Disk `diskId`, after confirming that the Virtual Machine won't need to be replaced once it's been swapped
+func expandVirtualMachineOSDiskSwap(ctx context.Context, disksClient *disks.DisksClient, d *pluginsdk.ResourceData, existing *virtualmachines.OSDisk, osType virtualmachines.OperatingSystemTypes) (*virtualmachines.OSDisk, error) {
+	if existing == nil {
+		return nil, fmt.Errorf("the existing OS Disk was nil")
+	}
+	if d.HasChanges("os_disk.0.disk_size_gb", "os_disk.0.disk_encryption_set_id") {
+		return nil, fmt.Errorf("`os_disk.0.disk_size_gb` and `os_disk.0.disk_encryption_set_id` cannot be changed whilst changing `os_managed_disk_id`")
+	}
+
+	id, err := commonids.ParseManagedDiskID(d.Get("os_managed_disk_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := disksClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+	if disk.Model == nil || disk.Model.Properties == nil {
+		return nil, fmt.Errorf("retrieving %s: `model` or `properties` was nil", *id)
+	}
+
+	if managedBy := pointer.From(disk.Model.ManagedBy); managedBy != "" {
+		return nil, fmt.Errorf("%s is already attached to %q", *id, managedBy)
+	}
+	if diskOSType := pointer.From(disk.Model.Properties.OsType); !strings.EqualFold(string(diskOSType), string(osType)) {
+		return nil, fmt.Errorf("the OS Type of %s must be %q but got %q", *id, string(osType), string(diskOSType))
+	}
+
+	// the OS Disk's storage account type and name can't be updated, so any mismatch would replace the Virtual Machine after swapping
+	storageAccountType := d.Get("os_disk.0.storage_account_type").(string)
+	if disk.Model.Sku != nil && disk.Model.Sku.Name != nil && !strings.EqualFold(string(*disk.Model.Sku.Name), storageAccountType) {
+		return nil, fmt.Errorf("the storage account type of %s (%q) must match `os_disk.0.storage_account_type` (%q)", *id, string(*disk.Model.Sku.Name), storageAccountType)
+	}
+	if v := d.GetRawConfig().GetAttr("os_disk"); v.IsKnown() && !v.IsNull() && v.LengthInt() > 0 {
+		if name := v.AsValueSlice()[0].GetAttr("name"); name.IsKnown() && !name.IsNull() && name.AsString() != id.DiskName {
+			return nil, fmt.Errorf("`os_disk.0.name` (%q) must be removed or match the name of `os_managed_disk_id` (%q)", name.AsString(), id.DiskName)
+		}
+	}
+
+	swapped := *existing
+	swapped.Name = pointer.To(id.DiskName)
+	swapped.DiskSizeGB = nil
+	swapped.ManagedDisk = &virtualmachines.ManagedDiskParameters{
+		Id: pointer.To(id.ID()),
+	}
+
+	return &swapped, nil
+}
+
 func virtualMachineOsImageNotificationSchema() *pluginsdk.Schema {
 	return &pluginsdk.Schema{
 		Type:     pluginsdk.TypeList,
@@ -54,6 +54,8 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 			Delete: pluginsdk.DefaultTimeout(45 * time.Minute),
 		},
 
+		CustomizeDiff: pluginsdk.CustomizeDiffShim(virtualMachineOSManagedDiskIdCustomizeDiff),
+
 		Schema: map[string]*pluginsdk.Schema{
 			"name": {
 				Type:         pluginsdk.TypeString,
@@ -358,6 +360,8 @@ func resourceWindowsVirtualMachine() *pluginsdk.Resource {
 
 			"os_image_notification": virtualMachineOsImageNotificationSchema(),
 
+			"os_managed_disk_id": virtualMachineOSManagedDiskIdSchema(),
+
 			"termination_notification": virtualMachineTerminationNotificationSchema(),
 
 			"timezone": {
@@ -1013,6 +1017,10 @@ func resourceWindowsVirtualMachineRead(d *pluginsdk.ResourceData, meta interface
 				if err := d.Set("os_disk", flattenedOSDisk); err != nil {
 					return fmt.Errorf("settings `os_disk`: %+v", err)
 				}
+				// this is only tracked once the OS Disk has been swapped, since it otherwise refers to the OS Disk the Virtual Machine was created with
+				if d.Get("os_managed_disk_id").(string) != "" {
+					d.Set("os_managed_disk_id", flattenVirtualMachineOSManagedDiskId(profile.OsDisk))
+				}
 
 				var storageImageId string
 				if profile.ImageReference != nil && profile.ImageReference.Id != nil {
@@ -1425,6 +1433,47 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 		update.Properties.StorageProfile.OsDisk = osDisk
 	}
 
+	shouldSwapOSDisk := false
+	if d.HasChange("os_managed_disk_id") && d.Get("os_managed_disk_id").(string) != "" {
+		var existingOSDisk *virtualmachines.OSDisk
+		if model := existing.Model; model != nil && model.Properties != nil && model.Properties.StorageProfile != nil {
+			existingOSDisk = model.Properties.StorageProfile.OsDisk
+		}
+
+		// there's nothing to swap when the Managed Disk is already the OS Disk (e.g. following an import)
+		shouldSwapOSDisk = !virtualMachineUsesOSManagedDisk(existingOSDisk, d.Get("os_managed_disk_id").(string))
+	}
+
+	if shouldSwapOSDisk {
+		if hasEphemeralOSDisk {
+			return fmt.Errorf("the OS Disk of Windows %s cannot be swapped since it uses an Ephemeral OS Disk", id)
+		}
+
+		// the OS Disk can only be swapped whilst the Virtual Machine is deallocated
+		shouldUpdate = true
+		shouldShutDown = true
+		shouldDeallocate = true
+
+		var osDisk *virtualmachines.OSDisk
+		if model := existing.Model; model != nil && model.Properties != nil && model.Properties.StorageProfile != nil {
+			osDisk = model.Properties.StorageProfile.OsDisk
+		}
+		if update.Properties.StorageProfile != nil && update.Properties.StorageProfile.OsDisk != nil {
+			osDisk = update.Properties.StorageProfile.OsDisk
+		}
+
+		swappedOSDisk, err := expandVirtualMachineOSDiskSwap(ctx, meta.(*clients.Client).Compute.DisksClient, d, osDisk, virtualmachines.OperatingSystemTypesWindows)
+		if err != nil {
+			return fmt.Errorf("swapping the OS Disk of Windows %s: %+v", id, err)
+		}
+
+		if update.Properties.StorageProfile == nil {
+			update.Properties.StorageProfile = &virtualmachines.StorageProfile{}
+		}
+
+		update.Properties.StorageProfile.OsDisk = swappedOSDisk
+	}
+
 	if d.HasChange("virtual_machine_scale_set_id") {
 		shouldUpdate = true
 
@@ -1603,7 +1652,12 @@ func resourceWindowsVirtualMachineUpdate(d *pluginsdk.ResourceData, meta interfa
 
 	if shouldShutDown {
 		log.Printf("[DEBUG] Shutting Down Windows %s", id)
-		if err := client.PowerOffThenPoll(ctx, *id, virtualmachines.DefaultPowerOffOperationOptions()); err != nil {
+		options := virtualmachines.DefaultPowerOffOperationOptions()
+		if shouldSwapOSDisk {
+			// the OS Disk is being detached, so shut down the guest OS first when `graceful_shutdown` is enabled - as when deleting
+			options.SkipShutdown = pointer.To(!meta.(*clients.Client).Features.VirtualMachine.GracefulShutdown)
+		}
+		if err := client.PowerOffThenPoll(ctx, *id, options); err != nil {
 			return fmt.Errorf("sending Power Off to Windows %s: %+v", id, err)
 		}
 
@@ -1764,6 +1818,12 @@ func resourceWindowsVirtualMachineDelete(d *pluginsdk.ResourceData, meta interfa
 	log.Printf("[DEBUG] Deleted Windows %s", id)
 
 	deleteOSDisk := meta.(*clients.Client).Features.VirtualMachine.DeleteOSDiskOnDeletion
+	if v := d.Get("os_managed_disk_id").(string); deleteOSDisk && v != "" {
+		// the OS Disk has been swapped for a Managed Disk which isn't the one the Virtual Machine was created with,
+		// and which is likely managed elsewhere (e.g. by an `azurerm_managed_disk` resource)
+		log.Printf("[DEBUG] Skipping Deleting OS Disk %q from Windows %s since it was swapped using `os_managed_disk_id`", v, id)
+		deleteOSDisk = false
+	}
 	if deleteOSDisk {
 		log.Printf("[DEBUG] Deleting OS Disk from Windows %s", id)
 		disksClient := meta.(*clients.Client).Compute.DisksClient
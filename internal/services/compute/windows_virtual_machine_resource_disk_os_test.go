@@ -5,6 +5,7 @@ package compute_test
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
@@ -406,6 +407,106 @@ func TestAccWindowsVirtualMachine_diskOSConfidentialVmWithDiskAndVMGuestStateCMK
 	})
 }
 
+func TestAccWindowsVirtualMachine_diskOSManagedDiskSwap(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_windows_virtual_machine", "test")
+	r := WindowsVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.diskOSBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("os_managed_disk_id").IsEmpty(),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.diskOSManagedDiskSwap(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("os_managed_disk_id").MatchesOtherKey(check.That("azurerm_managed_disk.swap").Key("id")),
+				check.That(data.ResourceName).Key("os_disk.0.name").HasValue(fmt.Sprintf("acctestswap-%d", data.RandomInteger)),
+			),
+		},
+		// the swapped OS Disk can't be identified when importing the Virtual Machine
+		data.ImportStep("os_managed_disk_id"),
+		{
+			// destroying the Virtual Machine mustn't delete the swapped Managed Disk
+			Config: r.diskOSManagedDiskSwapTemplate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That("azurerm_managed_disk.swap").ExistsInAzure(ManagedDiskResource{}),
+			),
+		},
+	})
+}
+
+func TestAccWindowsVirtualMachine_diskOSManagedDiskOnCreate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_windows_virtual_machine", "test")
+	r := WindowsVirtualMachineResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.diskOSManagedDiskSwap(data),
+			ExpectError: regexp.MustCompile("`os_managed_disk_id` can only be specified to swap the OS Disk of an existing Virtual Machine"),
+		},
+	})
+}
+
+func (r WindowsVirtualMachineResource) diskOSManagedDiskSwap(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_windows_virtual_machine" "test" {
+  name                = local.vm_name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  size                = "Standard_F2"
+  admin_username      = "adminuser"
+  admin_password      = "P@$$w0rd1234!"
+  network_interface_ids = [
+    azurerm_network_interface.test.id,
+  ]
+
+  os_disk {
+    caching              = "ReadWrite"
+    storage_account_type = "Standard_LRS"
+  }
+
+  os_managed_disk_id = azurerm_managed_disk.swap.id
+
+  source_image_reference {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2016-Datacenter"
+    version   = "latest"
+  }
+}
+`, r.diskOSManagedDiskSwapTemplate(data))
+}
+
+func (r WindowsVirtualMachineResource) diskOSManagedDiskSwapTemplate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_platform_image" "test" {
+  location  = azurerm_resource_group.test.location
+  publisher = "MicrosoftWindowsServer"
+  offer     = "WindowsServer"
+  sku       = "2016-Datacenter"
+}
+
+resource "azurerm_managed_disk" "swap" {
+  name                 = "acctestswap-%d"
+  location             = azurerm_resource_group.test.location
+  resource_group_name  = azurerm_resource_group.test.name
+  os_type              = "Windows"
+  create_option        = "FromImage"
+  image_reference_id   = data.azurerm_platform_image.test.id
+  storage_account_type = "Standard_LRS"
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r WindowsVirtualMachineResource) diskOSBasic(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
@@ -9,6 +9,8 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerinstance/2023-05-01/containerinstance"
 	containerregistry_v2019_06_01_preview "github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2019-06-01-preview"
 	containerregistry_v2021_08_01_preview "github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-07-01/cacherules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-07-01/credentialsets"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2019-08-01/containerservices"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/agentpools"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/maintenanceconfigurations"
@@ -21,6 +23,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
 	"github.com/hashicorp/go-azure-sdk/sdk/environments"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/registry"
 )
 
 type Client struct {
@@ -29,6 +32,9 @@ type Client struct {
 	ContainerRegistryClient_v2021_08_01_preview *containerregistry_v2021_08_01_preview.Client
 	// v2019_06_01_preview is needed for container registry agent pools and tasks
 	ContainerRegistryClient_v2019_06_01_preview *containerregistry_v2019_06_01_preview.Client
+	ContainerRegistryCacheRulesClient           *cacherules.CacheRulesClient
+	ContainerRegistryCredentialSetsClient       *credentialsets.CredentialSetsClient
+	ContainerRegistryDataPlaneClient            *registry.Client
 	FleetUpdateRunsClient                       *updateruns.UpdateRunsClient
 	FleetUpdateStrategiesClient                 *fleetupdatestrategies.FleetUpdateStrategiesClient
 	KubernetesClustersClient                    *managedclusters.ManagedClustersClient
@@ -61,6 +67,20 @@ func NewContainersClient(o *common.ClientOptions) (*Client, error) {
 		return nil, err
 	}
 
+	containerRegistryCacheRulesClient, err := cacherules.NewCacheRulesClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Container Registry Cache Rules Client: %+v", err)
+	}
+	o.Configure(containerRegistryCacheRulesClient.Client, o.Authorizers.ResourceManager)
+
+	containerRegistryCredentialSetsClient, err := credentialsets.NewCredentialSetsClientWithBaseURI(o.Environment.ResourceManager)
+	if err != nil {
+		return nil, fmt.Errorf("building Container Registry Credential Sets Client: %+v", err)
+	}
+	o.Configure(containerRegistryCredentialSetsClient.Client, o.Authorizers.ResourceManager)
+
+	containerRegistryDataPlaneClient := registry.NewClient(o.HTTPClient(), o.Authorizers.ResourceManager, o.TenantId)
+
 	// AKS
 	fleetUpdateRunsClient, err := updateruns.NewUpdateRunsClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
@@ -121,6 +141,9 @@ func NewContainersClient(o *common.ClientOptions) (*Client, error) {
 		ContainerInstanceClient:                     containerInstanceClient,
 		ContainerRegistryClient_v2021_08_01_preview: containerRegistryClient_v2021_08_01_preview,
 		ContainerRegistryClient_v2019_06_01_preview: containerRegistryClient_v2019_06_01_preview,
+		ContainerRegistryCacheRulesClient:           containerRegistryCacheRulesClient,
+		ContainerRegistryCredentialSetsClient:       containerRegistryCredentialSetsClient,
+		ContainerRegistryDataPlaneClient:            containerRegistryDataPlaneClient,
 		FleetUpdateRunsClient:                       fleetUpdateRunsClient,
 		FleetUpdateStrategiesClient:                 fleetUpdateStrategiesClient,
 		KubernetesClustersClient:                    kubernetesClustersClient,
@@ -5,6 +5,7 @@ import (
 
 	containerserviceV20230302Preview "github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-03-02-preview"
 	containerserviceV20231015 "github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-10-15"
+	containerserviceV20240401 "github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01"
 	"github.com/hashicorp/go-azure-sdk/sdk/client/resourcemanager"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 )
@@ -12,6 +13,7 @@ import (
 type AutoClient struct {
 	V20230302Preview containerserviceV20230302Preview.Client
 	V20231015        containerserviceV20231015.Client
+	V20240401        containerserviceV20240401.Client
 }
 
 func NewClient(o *common.ClientOptions) (*AutoClient, error) {
@@ -30,8 +32,16 @@ func NewClient(o *common.ClientOptions) (*AutoClient, error) {
 		return nil, fmt.Errorf("building client for containerservice V20231015: %+v", err)
 	}
 
+	v20240401Client, err := containerserviceV20240401.NewClientWithBaseURI(o.Environment.ResourceManager, func(c *resourcemanager.Client) {
+		o.Configure(c, o.Authorizers.ResourceManager)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building client for containerservice V20240401: %+v", err)
+	}
+
 	return &AutoClient{
 		V20230302Preview: *v20230302PreviewClient,
 		V20231015:        *v20231015Client,
+		V20240401:        *v20240401Client,
 	}, nil
 }
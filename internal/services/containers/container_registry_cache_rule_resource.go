@@ -0,0 +1,279 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/registries"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-07-01/cacherules"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2023-07-01/credentialsets"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	_ sdk.ResourceWithUpdate        = ContainerRegistryCacheRuleResource{}
+	_ sdk.ResourceWithCustomizeDiff = ContainerRegistryCacheRuleResource{}
+)
+
+type ContainerRegistryCacheRuleResource struct{}
+
+type ContainerRegistryCacheRuleModel struct {
+	Name                string `tfschema:"name"`
+	ContainerRegistryId string `tfschema:"container_registry_id"`
+	CredentialSetId     string `tfschema:"credential_set_id"`
+	SourceRepo          string `tfschema:"source_repo"`
+	TargetRepo          string `tfschema:"target_repo"`
+}
+
+func (r ContainerRegistryCacheRuleResource) ResourceType() string {
+	return "azurerm_container_registry_cache_rule"
+}
+
+func (r ContainerRegistryCacheRuleResource) ModelObject() interface{} {
+	return &ContainerRegistryCacheRuleModel{}
+}
+
+func (r ContainerRegistryCacheRuleResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return cacherules.ValidateCacheRuleID
+}
+
+func (r ContainerRegistryCacheRuleResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ContainerRegistryCacheRuleName,
+		},
+
+		"container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: registries.ValidateRegistryID,
+		},
+
+		"source_repo": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ContainerRegistryCacheRuleSourceRepository,
+		},
+
+		"target_repo": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ContainerRegistryCacheRuleTargetRepository,
+		},
+
+		"credential_set_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: credentialsets.ValidateCredentialSetID,
+		},
+	}
+}
+
+func (r ContainerRegistryCacheRuleResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r ContainerRegistryCacheRuleResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryCacheRulesClient
+
+			var config ContainerRegistryCacheRuleModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registryId, err := registries.ParseRegistryID(config.ContainerRegistryId)
+			if err != nil {
+				return err
+			}
+
+			id := cacherules.NewCacheRuleID(registryId.SubscriptionId, registryId.ResourceGroupName, registryId.RegistryName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil && !response.WasNotFound(existing.HttpResponse) {
+				return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := cacherules.CacheRule{
+				Properties: &cacherules.CacheRuleProperties{
+					SourceRepository: pointer.To(config.SourceRepo),
+					TargetRepository: pointer.To(config.TargetRepo),
+				},
+			}
+
+			if config.CredentialSetId != "" {
+				payload.Properties.CredentialSetResourceId = pointer.To(config.CredentialSetId)
+			}
+
+			if err := client.CreateThenPoll(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryCacheRuleResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryCacheRulesClient
+
+			id, err := cacherules.ParseCacheRuleID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := ContainerRegistryCacheRuleModel{
+				Name:                id.CacheRuleName,
+				ContainerRegistryId: registries.NewRegistryID(id.SubscriptionId, id.ResourceGroupName, id.RegistryName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.SourceRepo = pointer.From(props.SourceRepository)
+					state.TargetRepo = pointer.From(props.TargetRepository)
+
+					if v := pointer.From(props.CredentialSetResourceId); v != "" {
+						credentialSetId, err := credentialsets.ParseCredentialSetIDInsensitively(v)
+						if err != nil {
+							return err
+						}
+						state.CredentialSetId = credentialSetId.ID()
+					}
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContainerRegistryCacheRuleResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryCacheRulesClient
+
+			id, err := cacherules.ParseCacheRuleID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config ContainerRegistryCacheRuleModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			payload := cacherules.CacheRuleUpdateParameters{
+				Properties: &cacherules.CacheRuleUpdateProperties{},
+			}
+
+			if metadata.ResourceData.HasChange("credential_set_id") {
+				payload.Properties.CredentialSetResourceId = pointer.To(config.CredentialSetId)
+			}
+
+			if err := client.UpdateThenPoll(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryCacheRuleResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryCacheRulesClient
+
+			id, err := cacherules.ParseCacheRuleID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if err := client.DeleteThenPoll(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryCacheRuleResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config ContainerRegistryCacheRuleModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the API doesn't support removing the Credential Set from an existing Cache Rule
+			if oldVal, newVal := metadata.ResourceDiff.GetChange("credential_set_id"); oldVal.(string) != "" && newVal.(string) == "" {
+				if err := metadata.ResourceDiff.ForceNew("credential_set_id"); err != nil {
+					return err
+				}
+			}
+
+			if config.SourceRepo != "" && config.TargetRepo != "" {
+				sourceIsWildcard := validate.ContainerRegistryCacheRuleRepositoryIsWildcard(config.SourceRepo)
+				targetIsWildcard := validate.ContainerRegistryCacheRuleRepositoryIsWildcard(config.TargetRepo)
+				if sourceIsWildcard != targetIsWildcard {
+					return fmt.Errorf("`source_repo` and `target_repo` must either both end with a `/*` wildcard or both refer to a single repository, got %q and %q", config.SourceRepo, config.TargetRepo)
+				}
+			}
+
+			// the Registry and Credential Set IDs won't be known until apply when they're being created
+			if config.ContainerRegistryId != "" && config.CredentialSetId != "" {
+				registryId, err := registries.ParseRegistryID(config.ContainerRegistryId)
+				if err != nil {
+					return err
+				}
+				credentialSetId, err := credentialsets.ParseCredentialSetID(config.CredentialSetId)
+				if err != nil {
+					return err
+				}
+
+				credentialSetRegistryId := registries.NewRegistryID(credentialSetId.SubscriptionId, credentialSetId.ResourceGroupName, credentialSetId.RegistryName)
+				if !strings.EqualFold(registryId.ID(), credentialSetRegistryId.ID()) {
+					return fmt.Errorf("`credential_set_id` must be a Credential Set within the Container Registry %q, got %q", registryId.ID(), config.CredentialSetId)
+				}
+			}
+
+			return nil
+		},
+	}
+}
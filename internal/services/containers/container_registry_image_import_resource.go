@@ -0,0 +1,520 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/registries"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/registry"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var (
+	_ sdk.ResourceWithUpdate        = ContainerRegistryImageImportResource{}
+	_ sdk.ResourceWithCustomizeDiff = ContainerRegistryImageImportResource{}
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+type ContainerRegistryImageImportModel struct {
+	ContainerRegistryId string                               `tfschema:"container_registry_id"`
+	Source              []ContainerRegistryImageImportSource `tfschema:"source"`
+	TargetTags          []string                             `tfschema:"target_tags"`
+	Mode                string                               `tfschema:"mode"`
+	DeleteOnDestroy     bool                                 `tfschema:"delete_on_destroy"`
+	ImportedDigest      string                               `tfschema:"imported_digest"`
+}
+
+type ContainerRegistryImageImportSource struct {
+	Image               string `tfschema:"image"`
+	RegistryUri         string `tfschema:"registry_uri"`
+	ContainerRegistryId string `tfschema:"container_registry_id"`
+	Username            string `tfschema:"username"`
+	Password            string `tfschema:"password"`
+}
+
+func (r ContainerRegistryImageImportResource) ResourceType() string {
+	return "azurerm_container_registry_image_import"
+}
+
+func (r ContainerRegistryImageImportResource) ModelObject() interface{} {
+	return &ContainerRegistryImageImportModel{}
+}
+
+func (r ContainerRegistryImageImportResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ContainerRegistryImageImportID
+}
+
+func (r ContainerRegistryImageImportResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"container_registry_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: registries.ValidateRegistryID,
+		},
+
+		"source": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"image": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: registry.ValidateImageReference,
+					},
+
+					"registry_uri": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: registry.ValidateLoginServer,
+						ExactlyOneOf: []string{"source.0.registry_uri", "source.0.container_registry_id"},
+					},
+
+					"container_registry_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: registries.ValidateRegistryID,
+						ExactlyOneOf: []string{"source.0.registry_uri", "source.0.container_registry_id"},
+					},
+
+					"username": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						RequiredWith: []string{"source.0.password"},
+					},
+
+					"password": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Sensitive:    true,
+						ValidateFunc: validation.StringIsNotEmpty,
+						RequiredWith: []string{"source.0.username"},
+					},
+				},
+			},
+		},
+
+		"target_tags": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			ForceNew: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: registry.ValidateTaggedImageReference,
+			},
+		},
+
+		"mode": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      string(registries.ImportModeNoForce),
+			ValidateFunc: validation.StringInSlice(registries.PossibleValuesForImportMode(), false),
+		},
+
+		"delete_on_destroy": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"imported_digest": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config ContainerRegistryImageImportModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registryId, err := registries.ParseRegistryID(config.ContainerRegistryId)
+			if err != nil {
+				return err
+			}
+
+			// the first Target Tag is used to identify the imported image
+			id := parse.NewContainerRegistryImageImportID(registryId.SubscriptionId, registryId.ResourceGroupName, registryId.RegistryName, config.TargetTags[0])
+
+			digest, err := r.importImage(ctx, metadata, *registryId, config, registries.ImportMode(config.Mode))
+			if err != nil {
+				return fmt.Errorf("importing %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			config.ImportedDigest = digest
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries
+
+			id, err := parse.ContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			// the source of the image can't be retrieved, so the existing state is used as the basis
+			var state ContainerRegistryImageImportModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			registryId := registries.NewRegistryID(id.SubscriptionId, id.ResourceGroup, id.RegistryName)
+			resp, err := client.Get(ctx, registryId)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", registryId, err)
+			}
+
+			state.ContainerRegistryId = registryId.ID()
+			if len(state.TargetTags) == 0 {
+				state.TargetTags = []string{id.ImageName}
+			}
+			if state.Mode == "" {
+				state.Mode = string(registries.ImportModeNoForce)
+			}
+
+			loginServer := ""
+			if model := resp.Model; model != nil && model.Properties != nil {
+				loginServer = pointer.From(model.Properties.LoginServer)
+			}
+
+			digest, err := r.targetDigest(ctx, metadata, loginServer, id.ImageName)
+			if err != nil {
+				// the Container Registry may not be reachable from where Terraform is run (e.g. when using a Private Endpoint)
+				log.Printf("[WARN] unable to retrieve the digest of %s, using the digest from the existing state: %+v", id, err)
+			} else if digest == nil {
+				log.Printf("[DEBUG] %s was not found - removing from state", id)
+				return metadata.MarkAsGone(id)
+			} else {
+				state.ImportedDigest = *digest
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			id, err := parse.ContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config ContainerRegistryImageImportModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the `mode` and the credentials for the source are only used when importing the image, so the image
+			// is only imported again when the source image has changed
+			if !metadata.ResourceData.HasChange("imported_digest") {
+				return nil
+			}
+
+			registryId := registries.NewRegistryID(id.SubscriptionId, id.ResourceGroup, id.RegistryName)
+			digest, err := r.importImage(ctx, metadata, registryId, config, registries.ImportModeForce)
+			if err != nil {
+				return fmt.Errorf("re-importing %s: %+v", id, err)
+			}
+
+			config.ImportedDigest = digest
+			return metadata.Encode(&config)
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries
+			dataPlaneClient := metadata.Client.Containers.ContainerRegistryDataPlaneClient
+
+			id, err := parse.ContainerRegistryImageImportID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var state ContainerRegistryImageImportModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the imported image is left in the Container Registry by default, since it may be in use
+			if !state.DeleteOnDestroy {
+				log.Printf("[DEBUG] `delete_on_destroy` is disabled, leaving the image imported by %s in the Container Registry", id)
+				return nil
+			}
+
+			registryId := registries.NewRegistryID(id.SubscriptionId, id.ResourceGroup, id.RegistryName)
+			resp, err := client.Get(ctx, registryId)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return nil
+				}
+				return fmt.Errorf("retrieving %s: %+v", registryId, err)
+			}
+			loginServer := ""
+			if model := resp.Model; model != nil && model.Properties != nil {
+				loginServer = pointer.From(model.Properties.LoginServer)
+			}
+			if loginServer == "" {
+				return fmt.Errorf("retrieving %s: the login server was empty", registryId)
+			}
+
+			credentials, err := dataPlaneClient.AzureContainerRegistryCredentials(ctx, loginServer)
+			if err != nil {
+				return fmt.Errorf("authenticating against %q: %+v", loginServer, err)
+			}
+
+			targetTags := state.TargetTags
+			if len(targetTags) == 0 {
+				targetTags = []string{id.ImageName}
+			}
+			for _, v := range targetTags {
+				image, err := registry.ParseImageReference(v)
+				if err != nil {
+					return err
+				}
+
+				if err := dataPlaneClient.DeleteTag(ctx, loginServer, *image, credentials); err != nil {
+					return fmt.Errorf("deleting the tag %q imported by %s: %+v", v, id, err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ContainerRegistryImageImportResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			// the source is only checked for changes once the image has been imported
+			if metadata.ResourceDiff.Id() == "" {
+				return nil
+			}
+
+			var config ContainerRegistryImageImportModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// when the `mode` is `NoForce` the existing image is never overwritten
+			if config.Mode != string(registries.ImportModeForce) || config.ImportedDigest == "" || len(config.Source) == 0 {
+				return nil
+			}
+
+			// the image will be imported again when any of these change
+			for _, key := range []string{"container_registry_id", "source.0.image", "source.0.registry_uri", "source.0.container_registry_id", "target_tags"} {
+				if metadata.ResourceDiff.HasChange(key) {
+					return nil
+				}
+			}
+
+			// the credentials for the source registry may not be known until apply
+			for _, key := range []string{"source.0.username", "source.0.password"} {
+				if !metadata.ResourceDiff.NewValueKnown(key) {
+					return nil
+				}
+			}
+
+			source := config.Source[0]
+			image, err := registry.ParseImageReference(source.Image)
+			if err != nil {
+				return err
+			}
+			// an image referenced by digest can't change
+			if image.IsDigest() {
+				return nil
+			}
+
+			digest, err := r.sourceDigest(ctx, metadata, source, *image)
+			if err != nil {
+				log.Printf("[WARN] unable to determine whether the source image %q has changed: %+v", image.String(), err)
+				return nil
+			}
+			if digest == nil {
+				log.Printf("[WARN] the source image %q was not found, the imported image will not be updated", image.String())
+				return nil
+			}
+
+			if *digest != config.ImportedDigest {
+				log.Printf("[DEBUG] the source image %q has changed from %q to %q, the image will be imported again", image.String(), config.ImportedDigest, *digest)
+				if err := metadata.ResourceDiff.SetNewComputed("imported_digest"); err != nil {
+					return fmt.Errorf("setting `imported_digest` to be computed: %+v", err)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// importImage imports the source image into the specified Container Registry, returning the digest of the imported image
+func (r ContainerRegistryImageImportResource) importImage(ctx context.Context, metadata sdk.ResourceMetaData, registryId registries.RegistryId, config ContainerRegistryImageImportModel, mode registries.ImportMode) (string, error) {
+	client := metadata.Client.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries
+
+	source := config.Source[0]
+	payload := registries.ImportImageParameters{
+		Mode: pointer.To(mode),
+		Source: registries.ImportSource{
+			SourceImage: source.Image,
+		},
+		TargetTags: pointer.To(config.TargetTags),
+	}
+
+	if source.RegistryUri != "" {
+		payload.Source.RegistryUri = pointer.To(source.RegistryUri)
+	}
+	if source.ContainerRegistryId != "" {
+		payload.Source.ResourceId = pointer.To(source.ContainerRegistryId)
+	}
+	if source.Username != "" {
+		payload.Source.Credentials = &registries.ImportSourceCredentials{
+			Username: pointer.To(source.Username),
+			Password: source.Password,
+		}
+	}
+
+	if err := client.ImportImageThenPoll(ctx, registryId, payload); err != nil {
+		return "", err
+	}
+
+	image, err := registry.ParseImageReference(source.Image)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Get(ctx, registryId)
+	if err != nil {
+		return "", fmt.Errorf("retrieving %s: %+v", registryId, err)
+	}
+	loginServer := ""
+	if model := resp.Model; model != nil && model.Properties != nil {
+		loginServer = pointer.From(model.Properties.LoginServer)
+	}
+
+	digest, err := r.targetDigest(ctx, metadata, loginServer, config.TargetTags[0])
+	if err != nil || digest == nil {
+		log.Printf("[WARN] unable to retrieve the digest of the imported image %q: %+v", config.TargetTags[0], err)
+
+		// an image imported by digest is known to have the same digest
+		if image.IsDigest() {
+			return image.Digest, nil
+		}
+
+		// otherwise fall back to the digest of the source image, if it's accessible
+		sourceDigest, err := r.sourceDigest(ctx, metadata, source, *image)
+		if err != nil || sourceDigest == nil {
+			log.Printf("[WARN] unable to retrieve the digest of the source image %q: %+v", image.String(), err)
+			return "", nil
+		}
+		return *sourceDigest, nil
+	}
+
+	return *digest, nil
+}
+
+// targetDigest returns the digest of the specified image within the Container Registry, or nil if it doesn't exist
+func (r ContainerRegistryImageImportResource) targetDigest(ctx context.Context, metadata sdk.ResourceMetaData, loginServer, imageName string) (*string, error) {
+	dataPlaneClient := metadata.Client.Containers.ContainerRegistryDataPlaneClient
+
+	if loginServer == "" {
+		return nil, fmt.Errorf("the login server of the Container Registry was empty")
+	}
+
+	image, err := registry.ParseImageReference(imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := dataPlaneClient.AzureContainerRegistryCredentials(ctx, loginServer)
+	if err != nil {
+		return nil, fmt.Errorf("authenticating against %q: %+v", loginServer, err)
+	}
+
+	return dataPlaneClient.ManifestDigest(ctx, loginServer, *image, credentials)
+}
+
+// sourceDigest returns the digest of the source image, or nil if it doesn't exist
+func (r ContainerRegistryImageImportResource) sourceDigest(ctx context.Context, metadata sdk.ResourceMetaData, source ContainerRegistryImageImportSource, image registry.ImageReference) (*string, error) {
+	dataPlaneClient := metadata.Client.Containers.ContainerRegistryDataPlaneClient
+
+	var credentials *registry.Credentials
+	if source.Username != "" {
+		credentials = &registry.Credentials{
+			Username: source.Username,
+			Password: source.Password,
+		}
+	}
+
+	loginServer := source.RegistryUri
+	if source.ContainerRegistryId != "" {
+		sourceRegistryId, err := registries.ParseRegistryID(source.ContainerRegistryId)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := metadata.Client.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries.Get(ctx, *sourceRegistryId)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving %s: %+v", sourceRegistryId, err)
+		}
+		if model := resp.Model; model != nil && model.Properties != nil {
+			loginServer = pointer.From(model.Properties.LoginServer)
+		}
+		if loginServer == "" {
+			return nil, fmt.Errorf("retrieving %s: the login server was empty", sourceRegistryId)
+		}
+
+		if credentials == nil {
+			if credentials, err = dataPlaneClient.AzureContainerRegistryCredentials(ctx, loginServer); err != nil {
+				return nil, fmt.Errorf("authenticating against %q: %+v", loginServer, err)
+			}
+		}
+	}
+
+	return dataPlaneClient.ManifestDigest(ctx, loginServer, image, credentials)
+}
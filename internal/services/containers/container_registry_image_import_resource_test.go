@@ -0,0 +1,226 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerregistry/2021-08-01-preview/registries"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/registry"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ContainerRegistryImageImportResource struct{}
+
+func TestAccContainerRegistryImageImport_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("imported_digest").IsNotEmpty(),
+			),
+		},
+		// the source of the image can't be retrieved from the Container Registry
+		data.ImportStep("source"),
+	})
+}
+
+func TestAccContainerRegistryImageImport_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("imported_digest").IsNotEmpty(),
+				check.That(data.ResourceName).Key("mode").HasValue("Force"),
+				check.That(data.ResourceName).Key("delete_on_destroy").HasValue("true"),
+			),
+		},
+	})
+}
+
+func TestAccContainerRegistryImageImport_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("mode").HasValue("Force"),
+			),
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("mode").HasValue("NoForce"),
+			),
+		},
+	})
+}
+
+func TestAccContainerRegistryImageImport_fromContainerRegistry(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_container_registry_image_import", "test")
+	r := ContainerRegistryImageImportResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.fromContainerRegistry(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("imported_digest").MatchesOtherKey(
+					check.That("azurerm_container_registry_image_import.source").Key("imported_digest"),
+				),
+			),
+		},
+	})
+}
+
+func (ContainerRegistryImageImportResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ContainerRegistryImageImportID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	registryId := registries.NewRegistryID(id.SubscriptionId, id.ResourceGroup, id.RegistryName)
+	resp, err := clients.Containers.ContainerRegistryClient_v2021_08_01_preview.Registries.Get(ctx, registryId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", registryId, err)
+	}
+	if resp.Model == nil || resp.Model.Properties == nil || resp.Model.Properties.LoginServer == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties.loginServer` was nil", registryId)
+	}
+	loginServer := *resp.Model.Properties.LoginServer
+
+	image, err := registry.ParseImageReference(id.ImageName)
+	if err != nil {
+		return nil, err
+	}
+
+	dataPlaneClient := clients.Containers.ContainerRegistryDataPlaneClient
+	credentials, err := dataPlaneClient.AzureContainerRegistryCredentials(ctx, loginServer)
+	if err != nil {
+		return nil, fmt.Errorf("authenticating against %q: %+v", loginServer, err)
+	}
+
+	digest, err := dataPlaneClient.ManifestDigest(ctx, loginServer, *image, credentials)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(digest != nil), nil
+}
+
+func (r ContainerRegistryImageImportResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id = azurerm_container_registry.test.id
+
+  source {
+    registry_uri = "mcr.microsoft.com"
+    image        = "hello-world:latest"
+  }
+
+  target_tags = ["hello-world:latest"]
+}
+`, r.template(data))
+}
+
+func (r ContainerRegistryImageImportResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id = azurerm_container_registry.test.id
+
+  source {
+    registry_uri = "mcr.microsoft.com"
+    image        = "hello-world:latest"
+  }
+
+  target_tags       = ["hello-world:latest"]
+  mode              = "Force"
+  delete_on_destroy = true
+}
+`, r.template(data))
+}
+
+func (r ContainerRegistryImageImportResource) fromContainerRegistry(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_container_registry" "target" {
+  name                = "testacccrtarget%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+
+resource "azurerm_container_registry_image_import" "source" {
+  container_registry_id = azurerm_container_registry.test.id
+
+  source {
+    registry_uri = "mcr.microsoft.com"
+    image        = "hello-world:latest"
+  }
+
+  target_tags = ["samples/hello-world:v1"]
+}
+
+resource "azurerm_container_registry_image_import" "test" {
+  container_registry_id = azurerm_container_registry.target.id
+
+  source {
+    container_registry_id = azurerm_container_registry.test.id
+    image                 = azurerm_container_registry_image_import.source.target_tags[0]
+  }
+
+  target_tags = ["samples/hello-world:v1", "samples/hello-world:latest"]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (ContainerRegistryImageImportResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-acr-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_container_registry" "test" {
+  name                = "testacccr%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  sku                 = "Basic"
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
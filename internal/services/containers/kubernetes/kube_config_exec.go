@@ -0,0 +1,208 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	KubeLoginModeAzureCli         = "azurecli"
+	KubeLoginModeManagedIdentity  = "msi"
+	KubeLoginModeServicePrincipal = "spn"
+	KubeLoginModeWorkloadIdentity = "workloadidentity"
+)
+
+func PossibleValuesForKubeLoginMode() []string {
+	return []string{
+		KubeLoginModeAzureCli,
+		KubeLoginModeManagedIdentity,
+		KubeLoginModeServicePrincipal,
+		KubeLoginModeWorkloadIdentity,
+	}
+}
+
+const (
+	// AKSManagedServerID is the ID of the Entra ID Application used by clusters with AKS-managed Entra ID integration
+	AKSManagedServerID = "6dae42f8-4368-4678-94ff-3960e28e3630"
+
+	ExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+	ExecCommand    = "kubelogin"
+
+	defaultEnvironment = "AzurePublicCloud"
+	execInstallHint    = "kubelogin is not installed which is required to connect to an Entra ID enabled cluster, see https://aka.ms/aks/kubelogin for installation instructions"
+)
+
+type userItemExec struct {
+	Name string   `yaml:"name"`
+	User userExec `yaml:"user"`
+}
+
+type userExec struct {
+	Exec *execConfig `yaml:"exec,omitempty"`
+}
+
+type execConfig struct {
+	APIVersion         string   `yaml:"apiVersion"`
+	Command            string   `yaml:"command"`
+	Args               []string `yaml:"args,omitempty"`
+	InstallHint        string   `yaml:"installHint,omitempty"`
+	ProvideClusterInfo bool     `yaml:"provideClusterInfo"`
+}
+
+type KubeConfigExec struct {
+	KubeConfigBase `yaml:",inline"`
+	Users          []userItemExec `yaml:"users"`
+}
+
+// KubeLoginOptions configures how kubelogin obtains a token for the cluster, any values which aren't specified
+// are taken from the exec plugin configuration returned by the API where available
+type KubeLoginOptions struct {
+	LoginMode   string
+	ServerID    string
+	Environment string
+	ClientID    string
+	TenantID    string
+}
+
+// NewKubeConfigExec builds a kubeconfig from the cluster user credentials returned by the API, which uses kubelogin
+// as an exec plugin to authenticate using the specified login mode
+func NewKubeConfigExec(config string, options KubeLoginOptions) (*KubeConfigExec, error) {
+	if config == "" {
+		return nil, fmt.Errorf("Cannot parse empty config")
+	}
+
+	var kubeConfig KubeConfigExec
+	if err := yaml.Unmarshal([]byte(config), &kubeConfig); err != nil {
+		return nil, fmt.Errorf("Failed to unmarshal YAML config with error %+v", err)
+	}
+	if len(kubeConfig.Clusters) == 0 || len(kubeConfig.Users) == 0 {
+		return nil, fmt.Errorf("Config %+v contains no valid clusters or users", kubeConfig)
+	}
+	c := kubeConfig.Clusters[0].Cluster
+	if c.Server == "" {
+		return nil, fmt.Errorf("Config has invalid or non existent server for cluster %+v", c)
+	}
+
+	if existing := kubeConfig.Users[0].User.Exec; existing != nil {
+		if options.ServerID == "" {
+			options.ServerID = existing.arg("--server-id")
+		}
+		if options.Environment == "" {
+			options.Environment = existing.arg("--environment")
+		}
+		if options.TenantID == "" {
+			options.TenantID = existing.arg("--tenant-id")
+		}
+	}
+
+	args, err := KubeLoginArgs(options)
+	if err != nil {
+		return nil, err
+	}
+
+	kubeConfig.Users = []userItemExec{
+		{
+			Name: kubeConfig.Users[0].Name,
+			User: userExec{
+				Exec: &execConfig{
+					APIVersion:  ExecAPIVersion,
+					Command:     ExecCommand,
+					Args:        args,
+					InstallHint: execInstallHint,
+				},
+			},
+		},
+	}
+
+	return &kubeConfig, nil
+}
+
+// Raw returns the kubeconfig as YAML
+func (c KubeConfigExec) Raw() (string, error) {
+	out, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("Failed to marshal YAML config with error %+v", err)
+	}
+
+	return string(out), nil
+}
+
+// Host returns the address of the Kubernetes API Server
+func (c KubeConfigExec) Host() string {
+	return c.Clusters[0].Cluster.Server
+}
+
+// ClusterCACertificate returns the base64 encoded CA certificate of the Kubernetes API Server
+func (c KubeConfigExec) ClusterCACertificate() string {
+	return c.Clusters[0].Cluster.ClusterAuthorityData
+}
+
+// ExecArgs returns the arguments passed to kubelogin
+func (c KubeConfigExec) ExecArgs() []string {
+	return c.Users[0].User.Exec.Args
+}
+
+// KubeLoginArgs returns the arguments passed to `kubelogin` to retrieve a token using the specified login mode, the
+// login modes which support a secret (such as the Service Principal Client Secret) read it from the environment so
+// that it's not persisted in the kubeconfig
+func KubeLoginArgs(options KubeLoginOptions) ([]string, error) {
+	serverId := options.ServerID
+	if serverId == "" {
+		serverId = AKSManagedServerID
+	}
+
+	args := []string{"get-token", "--login", options.LoginMode, "--server-id", serverId}
+
+	switch options.LoginMode {
+	case KubeLoginModeAzureCli:
+		// the Azure CLI determines the Tenant and Cloud Environment from the logged in account
+
+	case KubeLoginModeManagedIdentity:
+		// a User Assigned Identity can optionally be specified, otherwise the System Assigned Identity is used
+		if options.ClientID != "" {
+			args = append(args, "--client-id", options.ClientID)
+		}
+
+	case KubeLoginModeServicePrincipal:
+		// the Client Secret is read from `AAD_SERVICE_PRINCIPAL_CLIENT_SECRET` by kubelogin
+		if options.ClientID == "" {
+			return nil, fmt.Errorf("a Client ID must be specified when using the %q login mode", options.LoginMode)
+		}
+		if options.TenantID == "" {
+			return nil, fmt.Errorf("a Tenant ID must be specified when using the %q login mode", options.LoginMode)
+		}
+		environment := options.Environment
+		if environment == "" {
+			environment = defaultEnvironment
+		}
+		args = append(args, "--environment", environment, "--client-id", options.ClientID, "--tenant-id", options.TenantID)
+
+	case KubeLoginModeWorkloadIdentity:
+		// when omitted these are read from `AZURE_CLIENT_ID` and `AZURE_TENANT_ID`, which are injected by the Workload Identity webhook
+		if options.ClientID != "" {
+			args = append(args, "--client-id", options.ClientID)
+		}
+		if options.TenantID != "" {
+			args = append(args, "--tenant-id", options.TenantID)
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported login mode %q", options.LoginMode)
+	}
+
+	return args, nil
+}
+
+func (e execConfig) arg(name string) string {
+	for i, arg := range e.Args {
+		if arg == name && i+1 < len(e.Args) {
+			return e.Args[i+1]
+		}
+	}
+
+	return ""
+}
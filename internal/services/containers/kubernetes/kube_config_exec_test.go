@@ -0,0 +1,95 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package kubernetes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewKubeConfigExec(t *testing.T) {
+	testCases := []struct {
+		sourceFile   string
+		options      KubeLoginOptions
+		expectedArgs []string
+		shouldError  bool
+	}{
+		{
+			sourceFile:   "user_with_exec.yml",
+			options:      KubeLoginOptions{LoginMode: KubeLoginModeAzureCli},
+			expectedArgs: []string{"get-token", "--login", "azurecli", "--server-id", "test-server-id"},
+		},
+		{
+			sourceFile:   "user_with_exec.yml",
+			options:      KubeLoginOptions{LoginMode: KubeLoginModeManagedIdentity, ClientID: "test-msi-client-id"},
+			expectedArgs: []string{"get-token", "--login", "msi", "--server-id", "test-server-id", "--client-id", "test-msi-client-id"},
+		},
+		{
+			sourceFile:   "user_with_exec.yml",
+			options:      KubeLoginOptions{LoginMode: KubeLoginModeServicePrincipal, ClientID: "test-spn-client-id"},
+			expectedArgs: []string{"get-token", "--login", "spn", "--server-id", "test-server-id", "--environment", "AzureUSGovernmentCloud", "--client-id", "test-spn-client-id", "--tenant-id", "test-tenant-id"},
+		},
+		{
+			// a Client ID is required for a Service Principal
+			sourceFile:  "user_with_exec.yml",
+			options:     KubeLoginOptions{LoginMode: KubeLoginModeServicePrincipal},
+			shouldError: true,
+		},
+		{
+			sourceFile:   "user_with_exec.yml",
+			options:      KubeLoginOptions{LoginMode: KubeLoginModeWorkloadIdentity, TenantID: "other-tenant-id"},
+			expectedArgs: []string{"get-token", "--login", "workloadidentity", "--server-id", "test-server-id", "--tenant-id", "other-tenant-id"},
+		},
+		{
+			// the AKS-managed Server ID is used when the config doesn't contain an exec plugin
+			sourceFile:   "user_with_token.yml",
+			options:      KubeLoginOptions{LoginMode: KubeLoginModeAzureCli},
+			expectedArgs: []string{"get-token", "--login", "azurecli", "--server-id", AKSManagedServerID},
+		},
+		{
+			sourceFile:  "user_with_exec.yml",
+			options:     KubeLoginOptions{LoginMode: "devicecode"},
+			shouldError: true,
+		},
+		{
+			sourceFile:  "cluster_with_no_server.yml",
+			options:     KubeLoginOptions{LoginMode: KubeLoginModeAzureCli},
+			shouldError: true,
+		},
+	}
+
+	for i, test := range testCases {
+		result, err := NewKubeConfigExec(LoadConfig(test.sourceFile), test.options)
+		if err != nil {
+			if test.shouldError {
+				continue
+			}
+			t.Fatalf("Test case [%d]: unexpected error for config '%s': %+v", i, test.sourceFile, err)
+		}
+		if test.shouldError {
+			t.Fatalf("Test case [%d]: expected an error for config '%s' but didn't get one", i, test.sourceFile)
+		}
+
+		if !reflect.DeepEqual(test.expectedArgs, result.ExecArgs()) {
+			t.Fatalf("Test case [%d]: expected args %+v but got %+v", i, test.expectedArgs, result.ExecArgs())
+		}
+
+		raw, err := result.Raw()
+		if err != nil {
+			t.Fatalf("Test case [%d]: rendering config: %+v", i, err)
+		}
+
+		// the rendered config must round-trip, retaining the cluster and the exec plugin
+		parsed, err := NewKubeConfigExec(raw, test.options)
+		if err != nil {
+			t.Fatalf("Test case [%d]: parsing rendered config: %+v", i, err)
+		}
+		if parsed.Host() != result.Host() || parsed.ClusterCACertificate() != result.ClusterCACertificate() {
+			t.Fatalf("Test case [%d]: expected the cluster %+v but got %+v", i, result.Clusters, parsed.Clusters)
+		}
+		if !reflect.DeepEqual(result.ExecArgs(), parsed.ExecArgs()) {
+			t.Fatalf("Test case [%d]: expected args %+v after rendering but got %+v", i, result.ExecArgs(), parsed.ExecArgs())
+		}
+	}
+}
@@ -510,6 +510,12 @@ func dataSourceKubernetesCluster() *pluginsdk.Resource {
 				},
 			},
 
+			"kube_config_exec": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"kube_config_raw": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -901,6 +907,12 @@ func dataSourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{}
 			return fmt.Errorf("setting `kube_config`: %+v", err)
 		}
 
+		var aadProfile *managedclusters.ManagedClusterAADProfile
+		if props := model.Properties; props != nil {
+			aadProfile = props.AadProfile
+		}
+		d.Set("kube_config_exec", flattenKubernetesClusterKubeConfigExec(kubeConfigRaw, aadProfile))
+
 		d.Set("tags", tags.Flatten(model.Tags))
 	}
 
@@ -1011,6 +1023,33 @@ func flattenKubernetesClusterCredentials(model *managedclusters.CredentialResult
 	return nil, []interface{}{}
 }
 
+// flattenKubernetesClusterKubeConfigExec returns a kubeconfig which uses kubelogin to authenticate using the Azure CLI,
+// which is only available for clusters with Entra ID integration enabled
+func flattenKubernetesClusterKubeConfigExec(kubeConfigRaw *string, aadProfile *managedclusters.ManagedClusterAADProfile) *string {
+	if kubeConfigRaw == nil || aadProfile == nil {
+		return nil
+	}
+
+	options := kubernetes.KubeLoginOptions{
+		LoginMode: kubernetes.KubeLoginModeAzureCli,
+	}
+	if !pointer.From(aadProfile.Managed) {
+		options.ServerID = pointer.From(aadProfile.ServerAppID)
+	}
+
+	kubeConfig, err := kubernetes.NewKubeConfigExec(*kubeConfigRaw, options)
+	if err != nil {
+		return nil
+	}
+
+	raw, err := kubeConfig.Raw()
+	if err != nil {
+		return nil
+	}
+
+	return &raw
+}
+
 func flattenKubernetesClusterDataSourceAddOns(profile map[string]managedclusters.ManagedClusterAddonProfile) map[string]interface{} {
 	aciConnectors := make([]interface{}, 0)
 	aciConnector := kubernetesAddonProfileLocate(profile, aciConnectorKey)
@@ -1476,7 +1515,9 @@ func flattenKubernetesClusterDataSourceUpgradeSettings(input *managedclusters.Ag
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                     maxSurge,
+			"drain_timeout_in_minutes":      int(pointer.From(input.DrainTimeoutInMinutes)),
+			"node_soak_duration_in_minutes": int(pointer.From(input.NodeSoakDurationInMinutes)),
 		},
 	}
 }
@@ -0,0 +1,215 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/managedclusters"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/kubernetes"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+type KubernetesClusterKubeConfigExecDataSourceModel struct {
+	KubernetesClusterId  string                                 `tfschema:"kubernetes_cluster_id"`
+	LoginMode            string                                 `tfschema:"login_mode"`
+	ClientId             string                                 `tfschema:"client_id"`
+	TenantId             string                                 `tfschema:"tenant_id"`
+	Host                 string                                 `tfschema:"host"`
+	ClusterCaCertificate string                                 `tfschema:"cluster_ca_certificate"`
+	Exec                 []KubernetesClusterKubeConfigExecModel `tfschema:"exec"`
+	KubeConfigRaw        string                                 `tfschema:"kube_config_raw"`
+}
+
+type KubernetesClusterKubeConfigExecModel struct {
+	ApiVersion string   `tfschema:"api_version"`
+	Command    string   `tfschema:"command"`
+	Args       []string `tfschema:"args"`
+}
+
+type KubernetesClusterKubeConfigExecDataSource struct{}
+
+var _ sdk.DataSource = KubernetesClusterKubeConfigExecDataSource{}
+
+func (r KubernetesClusterKubeConfigExecDataSource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_kube_config_exec"
+}
+
+func (r KubernetesClusterKubeConfigExecDataSource) ModelObject() interface{} {
+	return &KubernetesClusterKubeConfigExecDataSourceModel{}
+}
+
+func (r KubernetesClusterKubeConfigExecDataSource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return commonids.ValidateKubernetesClusterID
+}
+
+func (r KubernetesClusterKubeConfigExecDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: commonids.ValidateKubernetesClusterID,
+		},
+
+		"login_mode": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      kubernetes.KubeLoginModeAzureCli,
+			ValidateFunc: validation.StringInSlice(kubernetes.PossibleValuesForKubeLoginMode(), false),
+		},
+
+		"client_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+
+		"tenant_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: validation.IsUUID,
+		},
+	}
+}
+
+func (r KubernetesClusterKubeConfigExecDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"host": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"cluster_ca_certificate": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+
+		"exec": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"api_version": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"command": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"args": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"kube_config_raw": {
+			Type:      pluginsdk.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+	}
+}
+
+func (r KubernetesClusterKubeConfigExecDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.KubernetesClustersClient
+
+			var state KubernetesClusterKubeConfigExecDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id, err := commonids.ParseKubernetesClusterID(state.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return fmt.Errorf("%s was not found", id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			var aadProfile *managedclusters.ManagedClusterAADProfile
+			if model := resp.Model; model != nil && model.Properties != nil {
+				aadProfile = model.Properties.AadProfile
+			}
+			if aadProfile == nil {
+				return fmt.Errorf("an exec kubeconfig can only be retrieved for a Kubernetes Cluster with Entra ID integration enabled, but %s does not have `azure_active_directory_role_based_access_control` configured", id)
+			}
+
+			credentials, err := client.ListClusterUserCredentials(ctx, *id, managedclusters.ListClusterUserCredentialsOperationOptions{
+				Format: pointer.To(managedclusters.FormatExec),
+			})
+			if err != nil {
+				return fmt.Errorf("retrieving User Credentials for %s: %+v", id, err)
+			}
+
+			kubeConfigRaw, _ := flattenKubernetesClusterCredentials(credentials.Model, "clusterUser")
+			if kubeConfigRaw == nil {
+				return fmt.Errorf("retrieving User Credentials for %s: `clusterUser` was not found", id)
+			}
+
+			if state.TenantId == "" {
+				state.TenantId = pointer.From(aadProfile.TenantID)
+			}
+
+			options := kubernetes.KubeLoginOptions{
+				LoginMode: state.LoginMode,
+				ClientID:  state.ClientId,
+				TenantID:  state.TenantId,
+			}
+			if !pointer.From(aadProfile.Managed) {
+				options.ServerID = pointer.From(aadProfile.ServerAppID)
+			}
+
+			kubeConfig, err := kubernetes.NewKubeConfigExec(*kubeConfigRaw, options)
+			if err != nil {
+				return fmt.Errorf("building the exec kubeconfig for %s: %+v", id, err)
+			}
+
+			raw, err := kubeConfig.Raw()
+			if err != nil {
+				return fmt.Errorf("building the exec kubeconfig for %s: %+v", id, err)
+			}
+
+			state.Host = kubeConfig.Host()
+			state.ClusterCaCertificate = kubeConfig.ClusterCACertificate()
+			state.Exec = []KubernetesClusterKubeConfigExecModel{
+				{
+					ApiVersion: kubernetes.ExecAPIVersion,
+					Command:    kubernetes.ExecCommand,
+					Args:       kubeConfig.ExecArgs(),
+				},
+			}
+			state.KubeConfigRaw = raw
+
+			metadata.SetID(id)
+
+			return metadata.Encode(&state)
+		},
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type KubernetesClusterKubeConfigExecDataSource struct{}
+
+func TestAccDataSourceKubernetesClusterKubeConfigExec_azureCli(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_cluster_kube_config_exec", "test")
+	r := KubernetesClusterKubeConfigExecDataSource{}
+	clientData := data.Client()
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.basic(data, clientData.TenantID),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("host").IsSet(),
+				check.That(data.ResourceName).Key("cluster_ca_certificate").IsSet(),
+				check.That(data.ResourceName).Key("kube_config_raw").IsSet(),
+				check.That(data.ResourceName).Key("tenant_id").HasValue(clientData.TenantID),
+				check.That(data.ResourceName).Key("exec.0.command").HasValue("kubelogin"),
+				check.That(data.ResourceName).Key("exec.0.args.2").HasValue("azurecli"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceKubernetesClusterKubeConfigExec_servicePrincipal(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_kubernetes_cluster_kube_config_exec", "test")
+	r := KubernetesClusterKubeConfigExecDataSource{}
+	clientData := data.Client()
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.servicePrincipal(data, clientData.TenantID, clientData.Default.ClientID),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("host").IsSet(),
+				check.That(data.ResourceName).Key("exec.0.args.2").HasValue("spn"),
+				check.That(data.ResourceName).Key("exec.0.args.#").HasValue("11"),
+			),
+		},
+	})
+}
+
+func (KubernetesClusterKubeConfigExecDataSource) basic(data acceptance.TestData, tenantId string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_kube_config_exec" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+}
+`, KubernetesClusterResource{}.roleBasedAccessControlAADManagedConfigWithLocalAccountDisabled(data, tenantId))
+}
+
+func (KubernetesClusterKubeConfigExecDataSource) servicePrincipal(data acceptance.TestData, tenantId, clientId string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_kubernetes_cluster_kube_config_exec" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  login_mode            = "spn"
+  client_id             = %q
+}
+`, KubernetesClusterResource{}.roleBasedAccessControlAADManagedConfigWithLocalAccountDisabled(data, tenantId), clientId)
+}
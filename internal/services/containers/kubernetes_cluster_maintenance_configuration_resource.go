@@ -0,0 +1,728 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/maintenanceconfigurations"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/suppress"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+const (
+	kubernetesClusterMaintenanceConfigurationDefault     = "default"
+	kubernetesClusterMaintenanceConfigurationAutoUpgrade = "aksManagedAutoUpgradeSchedule"
+	kubernetesClusterMaintenanceConfigurationNodeOS      = "aksManagedNodeOSUpgradeSchedule"
+)
+
+// kubernetesClusterMaintenanceConfigurationInlineBlocks maps the name of each Maintenance Configuration to the block
+// within the `azurerm_kubernetes_cluster` resource which can also manage it
+var kubernetesClusterMaintenanceConfigurationInlineBlocks = map[string]string{
+	kubernetesClusterMaintenanceConfigurationDefault:     "maintenance_window",
+	kubernetesClusterMaintenanceConfigurationAutoUpgrade: "maintenance_window_auto_upgrade",
+	kubernetesClusterMaintenanceConfigurationNodeOS:      "maintenance_window_node_os",
+}
+
+var (
+	_ sdk.Resource                  = KubernetesClusterMaintenanceConfigurationResource{}
+	_ sdk.ResourceWithUpdate        = KubernetesClusterMaintenanceConfigurationResource{}
+	_ sdk.ResourceWithCustomizeDiff = KubernetesClusterMaintenanceConfigurationResource{}
+)
+
+type KubernetesClusterMaintenanceConfigurationResource struct{}
+
+type KubernetesClusterMaintenanceConfigurationModel struct {
+	Name                string                                                 `tfschema:"name"`
+	KubernetesClusterId string                                                 `tfschema:"kubernetes_cluster_id"`
+	Allowed             []KubernetesClusterMaintenanceConfigurationAllowed     `tfschema:"allowed"`
+	NotAllowedTime      []KubernetesClusterMaintenanceConfigurationTimeSpan    `tfschema:"not_allowed_time"`
+	MaintenanceWindow   []KubernetesClusterMaintenanceConfigurationWindowModel `tfschema:"maintenance_window"`
+}
+
+type KubernetesClusterMaintenanceConfigurationAllowed struct {
+	Day   string `tfschema:"day"`
+	Hours []int  `tfschema:"hours"`
+}
+
+type KubernetesClusterMaintenanceConfigurationTimeSpan struct {
+	Start string `tfschema:"start"`
+	End   string `tfschema:"end"`
+}
+
+type KubernetesClusterMaintenanceConfigurationWindowModel struct {
+	DurationInHours int64                                               `tfschema:"duration_in_hours"`
+	StartTime       string                                              `tfschema:"start_time"`
+	StartDate       string                                              `tfschema:"start_date"`
+	UtcOffset       string                                              `tfschema:"utc_offset"`
+	NotAllowedDate  []KubernetesClusterMaintenanceConfigurationTimeSpan `tfschema:"not_allowed_date"`
+	Schedule        []KubernetesClusterMaintenanceConfigurationSchedule `tfschema:"schedule"`
+}
+
+type KubernetesClusterMaintenanceConfigurationSchedule struct {
+	Daily           []KubernetesClusterMaintenanceConfigurationDailySchedule           `tfschema:"daily"`
+	Weekly          []KubernetesClusterMaintenanceConfigurationWeeklySchedule          `tfschema:"weekly"`
+	AbsoluteMonthly []KubernetesClusterMaintenanceConfigurationAbsoluteMonthlySchedule `tfschema:"absolute_monthly"`
+	RelativeMonthly []KubernetesClusterMaintenanceConfigurationRelativeMonthlySchedule `tfschema:"relative_monthly"`
+}
+
+type KubernetesClusterMaintenanceConfigurationDailySchedule struct {
+	IntervalDays int64 `tfschema:"interval_days"`
+}
+
+type KubernetesClusterMaintenanceConfigurationWeeklySchedule struct {
+	IntervalWeeks int64  `tfschema:"interval_weeks"`
+	DayOfWeek     string `tfschema:"day_of_week"`
+}
+
+type KubernetesClusterMaintenanceConfigurationAbsoluteMonthlySchedule struct {
+	IntervalMonths int64 `tfschema:"interval_months"`
+	DayOfMonth     int64 `tfschema:"day_of_month"`
+}
+
+type KubernetesClusterMaintenanceConfigurationRelativeMonthlySchedule struct {
+	IntervalMonths int64  `tfschema:"interval_months"`
+	DayOfWeek      string `tfschema:"day_of_week"`
+	WeekIndex      string `tfschema:"week_index"`
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_maintenance_configuration"
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) ModelObject() interface{} {
+	return &KubernetesClusterMaintenanceConfigurationModel{}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return maintenanceconfigurations.ValidateMaintenanceConfigurationID
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Arguments() map[string]*pluginsdk.Schema {
+	dateSchema := func() *pluginsdk.Schema {
+		return &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the format `YYYY-MM-DD`"),
+		}
+	}
+	schedulePaths := []string{
+		"maintenance_window.0.schedule.0.daily",
+		"maintenance_window.0.schedule.0.weekly",
+		"maintenance_window.0.schedule.0.absolute_monthly",
+		"maintenance_window.0.schedule.0.relative_monthly",
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:     pluginsdk.TypeString,
+			Required: true,
+			ForceNew: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				kubernetesClusterMaintenanceConfigurationDefault,
+				kubernetesClusterMaintenanceConfigurationAutoUpgrade,
+				kubernetesClusterMaintenanceConfigurationNodeOS,
+			}, false),
+		},
+
+		"kubernetes_cluster_id": commonschema.ResourceIDReferenceRequiredForceNew(&commonids.KubernetesClusterId{}),
+
+		"allowed": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"day": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(maintenanceconfigurations.PossibleValuesForWeekDay(), false),
+					},
+
+					"hours": {
+						Type:     pluginsdk.TypeSet,
+						Required: true,
+						MinItems: 1,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeInt,
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+					},
+				},
+			},
+		},
+
+		"not_allowed_time": {
+			Type:     pluginsdk.TypeSet,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"start": {
+						Type:             pluginsdk.TypeString,
+						Required:         true,
+						DiffSuppressFunc: suppress.RFC3339Time,
+						ValidateFunc:     validation.IsRFC3339Time,
+					},
+
+					"end": {
+						Type:             pluginsdk.TypeString,
+						Required:         true,
+						DiffSuppressFunc: suppress.RFC3339Time,
+						ValidateFunc:     validation.IsRFC3339Time,
+					},
+				},
+			},
+		},
+
+		"maintenance_window": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"duration_in_hours": {
+						Type:         pluginsdk.TypeInt,
+						Required:     true,
+						ValidateFunc: validation.IntBetween(4, 24),
+					},
+
+					"start_time": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^([01]\d|2[0-3]):[0-5]\d$`), "must be a time in the format `HH:MM`"),
+					},
+
+					"start_date": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the format `YYYY-MM-DD`"),
+					},
+
+					"utc_offset": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Default:      "+00:00",
+						ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[+-]\d{2}:\d{2}$`), "must be an offset in the format `+HH:MM` or `-HH:MM`"),
+					},
+
+					"not_allowed_date": {
+						Type:     pluginsdk.TypeSet,
+						Optional: true,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"start": dateSchema(),
+
+								"end": dateSchema(),
+							},
+						},
+					},
+
+					"schedule": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"daily": {
+									Type:         pluginsdk.TypeList,
+									Optional:     true,
+									MaxItems:     1,
+									ExactlyOneOf: schedulePaths,
+									Elem: &pluginsdk.Resource{
+										Schema: map[string]*pluginsdk.Schema{
+											"interval_days": {
+												Type:         pluginsdk.TypeInt,
+												Required:     true,
+												ValidateFunc: validation.IntBetween(1, 7),
+											},
+										},
+									},
+								},
+
+								"weekly": {
+									Type:         pluginsdk.TypeList,
+									Optional:     true,
+									MaxItems:     1,
+									ExactlyOneOf: schedulePaths,
+									Elem: &pluginsdk.Resource{
+										Schema: map[string]*pluginsdk.Schema{
+											"interval_weeks": {
+												Type:         pluginsdk.TypeInt,
+												Required:     true,
+												ValidateFunc: validation.IntBetween(1, 4),
+											},
+
+											"day_of_week": {
+												Type:         pluginsdk.TypeString,
+												Required:     true,
+												ValidateFunc: validation.StringInSlice(maintenanceconfigurations.PossibleValuesForWeekDay(), false),
+											},
+										},
+									},
+								},
+
+								"absolute_monthly": {
+									Type:         pluginsdk.TypeList,
+									Optional:     true,
+									MaxItems:     1,
+									ExactlyOneOf: schedulePaths,
+									Elem: &pluginsdk.Resource{
+										Schema: map[string]*pluginsdk.Schema{
+											"interval_months": {
+												Type:         pluginsdk.TypeInt,
+												Required:     true,
+												ValidateFunc: validation.IntBetween(1, 6),
+											},
+
+											"day_of_month": {
+												Type:         pluginsdk.TypeInt,
+												Required:     true,
+												ValidateFunc: validation.IntBetween(1, 31),
+											},
+										},
+									},
+								},
+
+								"relative_monthly": {
+									Type:         pluginsdk.TypeList,
+									Optional:     true,
+									MaxItems:     1,
+									ExactlyOneOf: schedulePaths,
+									Elem: &pluginsdk.Resource{
+										Schema: map[string]*pluginsdk.Schema{
+											"interval_months": {
+												Type:         pluginsdk.TypeInt,
+												Required:     true,
+												ValidateFunc: validation.IntBetween(1, 6),
+											},
+
+											"day_of_week": {
+												Type:         pluginsdk.TypeString,
+												Required:     true,
+												ValidateFunc: validation.StringInSlice(maintenanceconfigurations.PossibleValuesForWeekDay(), false),
+											},
+
+											"week_index": {
+												Type:         pluginsdk.TypeString,
+												Required:     true,
+												ValidateFunc: validation.StringInSlice(maintenanceconfigurations.PossibleValuesForType(), false),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			rd := metadata.ResourceDiff
+
+			name := rd.Get("name").(string)
+			if name == "" {
+				// the name isn't known yet
+				return nil
+			}
+
+			window := rd.Get("maintenance_window").([]interface{})
+			allowed := rd.Get("allowed").(*pluginsdk.Set).List()
+			notAllowedTime := rd.Get("not_allowed_time").(*pluginsdk.Set).List()
+
+			if name == kubernetesClusterMaintenanceConfigurationDefault {
+				if len(window) > 0 {
+					return fmt.Errorf("`maintenance_window` cannot be specified when `name` is %q, use `allowed` and `not_allowed_time` instead", name)
+				}
+				if len(allowed) == 0 && len(notAllowedTime) == 0 {
+					return fmt.Errorf("at least one of `allowed` or `not_allowed_time` must be specified when `name` is %q", name)
+				}
+				return nil
+			}
+
+			if len(allowed) > 0 || len(notAllowedTime) > 0 {
+				return fmt.Errorf("`allowed` and `not_allowed_time` can only be specified when `name` is %q", kubernetesClusterMaintenanceConfigurationDefault)
+			}
+			if len(window) == 0 {
+				return fmt.Errorf("`maintenance_window` must be specified when `name` is %q", name)
+			}
+			if daily := rd.Get("maintenance_window.0.schedule.0.daily").([]interface{}); len(daily) > 0 && name != kubernetesClusterMaintenanceConfigurationNodeOS {
+				return fmt.Errorf("a `daily` schedule can only be used when `name` is %q", kubernetesClusterMaintenanceConfigurationNodeOS)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.MaintenanceConfigurationsClient
+
+			var config KubernetesClusterMaintenanceConfigurationModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			clusterId, err := commonids.ParseKubernetesClusterID(config.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			id := maintenanceconfigurations.NewMaintenanceConfigurationID(clusterId.SubscriptionId, clusterId.ResourceGroupName, clusterId.ManagedClusterName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				// the Maintenance Configuration may also be managed using a block within the `azurerm_kubernetes_cluster` resource
+				return fmt.Errorf("%+v\n\nif %s is managed using the `%s` block within the `azurerm_kubernetes_cluster` resource, that block must be removed before it can be managed using this resource", metadata.ResourceRequiresImport(r.ResourceType(), id), id, kubernetesClusterMaintenanceConfigurationInlineBlocks[config.Name])
+			}
+
+			properties, err := expandKubernetesClusterMaintenanceConfigurationProperties(config, true)
+			if err != nil {
+				return fmt.Errorf("expanding properties for %s: %+v", id, err)
+			}
+
+			payload := maintenanceconfigurations.MaintenanceConfiguration{
+				Properties: properties,
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.MaintenanceConfigurationsClient
+
+			id, err := maintenanceconfigurations.ParseMaintenanceConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesClusterMaintenanceConfigurationModel{
+				Name:                id.MaintenanceConfigurationName,
+				KubernetesClusterId: commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if props := model.Properties; props != nil {
+					state.Allowed = flattenKubernetesClusterMaintenanceConfigurationAllowed(props.TimeInWeek)
+					state.NotAllowedTime = flattenKubernetesClusterMaintenanceConfigurationNotAllowedTime(props.NotAllowedTime)
+					state.MaintenanceWindow = flattenKubernetesClusterMaintenanceConfigurationWindow(props.MaintenanceWindow)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.MaintenanceConfigurationsClient
+
+			id, err := maintenanceconfigurations.ParseMaintenanceConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KubernetesClusterMaintenanceConfigurationModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// `start_date` defaults to the date the Maintenance Configuration was created, which the API rejects once it's
+			// in the past - so this is only sent when it's been changed
+			properties, err := expandKubernetesClusterMaintenanceConfigurationProperties(config, metadata.ResourceData.HasChange("maintenance_window.0.start_date"))
+			if err != nil {
+				return fmt.Errorf("expanding properties for %s: %+v", *id, err)
+			}
+
+			payload := maintenanceconfigurations.MaintenanceConfiguration{
+				Properties: properties,
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Containers.MaintenanceConfigurationsClient
+
+			id, err := maintenanceconfigurations.ParseMaintenanceConfigurationID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// flattenKubernetesClusterMaintenanceConfigurationBlock returns the value of the block within the `azurerm_kubernetes_cluster`
+// resource for the Maintenance Configuration, which is empty when the Maintenance Configuration doesn't exist
+func flattenKubernetesClusterMaintenanceConfigurationBlock(ctx context.Context, client *maintenanceconfigurations.MaintenanceConfigurationsClient, id maintenanceconfigurations.MaintenanceConfigurationId) interface{} {
+	configResp, _ := client.Get(ctx, id)
+	configurationBody := configResp.Model
+	if configurationBody == nil || configurationBody.Properties == nil {
+		return make([]interface{}, 0)
+	}
+
+	if id.MaintenanceConfigurationName == kubernetesClusterMaintenanceConfigurationDefault {
+		return flattenKubernetesClusterMaintenanceConfigurationDefault(configurationBody.Properties)
+	}
+	return flattenKubernetesClusterMaintenanceConfiguration(configurationBody.Properties.MaintenanceWindow)
+}
+
+func expandKubernetesClusterMaintenanceConfigurationProperties(input KubernetesClusterMaintenanceConfigurationModel, includeStartDate bool) (*maintenanceconfigurations.MaintenanceConfigurationProperties, error) {
+	if input.Name == kubernetesClusterMaintenanceConfigurationDefault {
+		timeInWeek := make([]maintenanceconfigurations.TimeInWeek, 0)
+		for _, v := range input.Allowed {
+			hours := make([]int64, 0)
+			for _, hour := range v.Hours {
+				hours = append(hours, int64(hour))
+			}
+			timeInWeek = append(timeInWeek, maintenanceconfigurations.TimeInWeek{
+				Day:       pointer.To(maintenanceconfigurations.WeekDay(v.Day)),
+				HourSlots: pointer.To(hours),
+			})
+		}
+
+		notAllowedTime := make([]maintenanceconfigurations.TimeSpan, 0)
+		for _, v := range input.NotAllowedTime {
+			start, err := time.Parse(time.RFC3339, v.Start)
+			if err != nil {
+				return nil, fmt.Errorf("parsing `start` %q within `not_allowed_time`: %+v", v.Start, err)
+			}
+			end, err := time.Parse(time.RFC3339, v.End)
+			if err != nil {
+				return nil, fmt.Errorf("parsing `end` %q within `not_allowed_time`: %+v", v.End, err)
+			}
+			notAllowedTime = append(notAllowedTime, maintenanceconfigurations.TimeSpan{
+				Start: pointer.To(start.Format(time.RFC3339)),
+				End:   pointer.To(end.Format(time.RFC3339)),
+			})
+		}
+
+		return &maintenanceconfigurations.MaintenanceConfigurationProperties{
+			TimeInWeek:     &timeInWeek,
+			NotAllowedTime: &notAllowedTime,
+		}, nil
+	}
+
+	if len(input.MaintenanceWindow) == 0 {
+		return &maintenanceconfigurations.MaintenanceConfigurationProperties{}, nil
+	}
+	window := input.MaintenanceWindow[0]
+
+	notAllowedDates := make([]maintenanceconfigurations.DateSpan, 0)
+	for _, v := range window.NotAllowedDate {
+		notAllowedDates = append(notAllowedDates, maintenanceconfigurations.DateSpan{
+			Start: v.Start,
+			End:   v.End,
+		})
+	}
+
+	output := &maintenanceconfigurations.MaintenanceConfigurationProperties{
+		MaintenanceWindow: &maintenanceconfigurations.MaintenanceWindow{
+			DurationHours:   window.DurationInHours,
+			StartTime:       window.StartTime,
+			UtcOffset:       pointer.To(window.UtcOffset),
+			NotAllowedDates: &notAllowedDates,
+			Schedule:        expandKubernetesClusterMaintenanceConfigurationSchedule(window.Schedule),
+		},
+	}
+
+	if includeStartDate && window.StartDate != "" {
+		output.MaintenanceWindow.StartDate = pointer.To(window.StartDate)
+	}
+
+	return output, nil
+}
+
+func expandKubernetesClusterMaintenanceConfigurationSchedule(input []KubernetesClusterMaintenanceConfigurationSchedule) maintenanceconfigurations.Schedule {
+	output := maintenanceconfigurations.Schedule{}
+	if len(input) == 0 {
+		return output
+	}
+	schedule := input[0]
+
+	if len(schedule.Daily) > 0 {
+		output.Daily = &maintenanceconfigurations.DailySchedule{
+			IntervalDays: schedule.Daily[0].IntervalDays,
+		}
+	}
+	if len(schedule.Weekly) > 0 {
+		output.Weekly = &maintenanceconfigurations.WeeklySchedule{
+			IntervalWeeks: schedule.Weekly[0].IntervalWeeks,
+			DayOfWeek:     maintenanceconfigurations.WeekDay(schedule.Weekly[0].DayOfWeek),
+		}
+	}
+	if len(schedule.AbsoluteMonthly) > 0 {
+		output.AbsoluteMonthly = &maintenanceconfigurations.AbsoluteMonthlySchedule{
+			IntervalMonths: schedule.AbsoluteMonthly[0].IntervalMonths,
+			DayOfMonth:     schedule.AbsoluteMonthly[0].DayOfMonth,
+		}
+	}
+	if len(schedule.RelativeMonthly) > 0 {
+		output.RelativeMonthly = &maintenanceconfigurations.RelativeMonthlySchedule{
+			IntervalMonths: schedule.RelativeMonthly[0].IntervalMonths,
+			DayOfWeek:      maintenanceconfigurations.WeekDay(schedule.RelativeMonthly[0].DayOfWeek),
+			WeekIndex:      maintenanceconfigurations.Type(schedule.RelativeMonthly[0].WeekIndex),
+		}
+	}
+
+	return output
+}
+
+func flattenKubernetesClusterMaintenanceConfigurationAllowed(input *[]maintenanceconfigurations.TimeInWeek) []KubernetesClusterMaintenanceConfigurationAllowed {
+	output := make([]KubernetesClusterMaintenanceConfigurationAllowed, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		hours := make([]int, 0)
+		for _, hour := range pointer.From(v.HourSlots) {
+			hours = append(hours, int(hour))
+		}
+		output = append(output, KubernetesClusterMaintenanceConfigurationAllowed{
+			Day:   string(pointer.From(v.Day)),
+			Hours: hours,
+		})
+	}
+
+	return output
+}
+
+func flattenKubernetesClusterMaintenanceConfigurationNotAllowedTime(input *[]maintenanceconfigurations.TimeSpan) []KubernetesClusterMaintenanceConfigurationTimeSpan {
+	output := make([]KubernetesClusterMaintenanceConfigurationTimeSpan, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, v := range *input {
+		output = append(output, KubernetesClusterMaintenanceConfigurationTimeSpan{
+			Start: pointer.From(v.Start),
+			End:   pointer.From(v.End),
+		})
+	}
+
+	return output
+}
+
+func flattenKubernetesClusterMaintenanceConfigurationWindow(input *maintenanceconfigurations.MaintenanceWindow) []KubernetesClusterMaintenanceConfigurationWindowModel {
+	if input == nil {
+		return []KubernetesClusterMaintenanceConfigurationWindowModel{}
+	}
+
+	notAllowedDates := make([]KubernetesClusterMaintenanceConfigurationTimeSpan, 0)
+	if input.NotAllowedDates != nil {
+		for _, v := range *input.NotAllowedDates {
+			notAllowedDates = append(notAllowedDates, KubernetesClusterMaintenanceConfigurationTimeSpan{
+				Start: v.Start,
+				End:   v.End,
+			})
+		}
+	}
+
+	schedule := KubernetesClusterMaintenanceConfigurationSchedule{}
+	if v := input.Schedule.Daily; v != nil {
+		schedule.Daily = []KubernetesClusterMaintenanceConfigurationDailySchedule{
+			{
+				IntervalDays: v.IntervalDays,
+			},
+		}
+	}
+	if v := input.Schedule.Weekly; v != nil {
+		schedule.Weekly = []KubernetesClusterMaintenanceConfigurationWeeklySchedule{
+			{
+				IntervalWeeks: v.IntervalWeeks,
+				DayOfWeek:     string(v.DayOfWeek),
+			},
+		}
+	}
+	if v := input.Schedule.AbsoluteMonthly; v != nil {
+		schedule.AbsoluteMonthly = []KubernetesClusterMaintenanceConfigurationAbsoluteMonthlySchedule{
+			{
+				IntervalMonths: v.IntervalMonths,
+				DayOfMonth:     v.DayOfMonth,
+			},
+		}
+	}
+	if v := input.Schedule.RelativeMonthly; v != nil {
+		schedule.RelativeMonthly = []KubernetesClusterMaintenanceConfigurationRelativeMonthlySchedule{
+			{
+				IntervalMonths: v.IntervalMonths,
+				DayOfWeek:      string(v.DayOfWeek),
+				WeekIndex:      string(v.WeekIndex),
+			},
+		}
+	}
+
+	return []KubernetesClusterMaintenanceConfigurationWindowModel{
+		{
+			DurationInHours: input.DurationHours,
+			StartTime:       input.StartTime,
+			StartDate:       pointer.From(input.StartDate),
+			UtcOffset:       pointer.From(input.UtcOffset),
+			NotAllowedDate:  notAllowedDates,
+			Schedule:        []KubernetesClusterMaintenanceConfigurationSchedule{schedule},
+		},
+	}
+}
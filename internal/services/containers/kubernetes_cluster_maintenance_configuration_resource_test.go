@@ -0,0 +1,342 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/maintenanceconfigurations"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type KubernetesClusterMaintenanceConfigurationResource struct{}
+
+func TestAccKubernetesClusterMaintenanceConfiguration_default(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_maintenance_configuration", "test")
+	r := KubernetesClusterMaintenanceConfigurationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.defaultConfiguration(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesClusterMaintenanceConfiguration_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_maintenance_configuration", "test")
+	r := KubernetesClusterMaintenanceConfigurationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.defaultConfiguration(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccKubernetesClusterMaintenanceConfiguration_autoUpgradeUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_maintenance_configuration", "test")
+	r := KubernetesClusterMaintenanceConfigurationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.autoUpgradeWeekly(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.autoUpgradeAbsoluteMonthly(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.autoUpgradeRelativeMonthly(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesClusterMaintenanceConfiguration_nodeOSDaily(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_maintenance_configuration", "test")
+	r := KubernetesClusterMaintenanceConfigurationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.nodeOSDaily(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccKubernetesClusterMaintenanceConfiguration_conflictsWithInlineBlock(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_maintenance_configuration", "test")
+	r := KubernetesClusterMaintenanceConfigurationResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.conflictsWithInlineBlock(data),
+			ExpectError: regexp.MustCompile("managed using the `maintenance_window_auto_upgrade` block"),
+		},
+	})
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := maintenanceconfigurations.ParseMaintenanceConfigurationID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Containers.MaintenanceConfigurationsClient.Get(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) template(data acceptance.TestData, inlineBlock string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-aks-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_kubernetes_cluster" "test" {
+  name                = "acctestaks%[1]d"
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+  dns_prefix          = "acctestaks%[1]d"
+
+  default_node_pool {
+    name       = "default"
+    node_count = 1
+    vm_size    = "Standard_DS2_v2"
+    upgrade_settings {
+      max_surge = "10%%"
+    }
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  %[3]s
+
+  lifecycle {
+    ignore_changes = [maintenance_window, maintenance_window_auto_upgrade, maintenance_window_node_os]
+  }
+}
+`, data.RandomInteger, data.Locations.Primary, inlineBlock)
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) defaultConfiguration(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "default"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  allowed {
+    day   = "Monday"
+    hours = [1, 2]
+  }
+
+  not_allowed_time {
+    start = "2031-11-26T03:00:00Z"
+    end   = "2031-11-30T12:00:00Z"
+  }
+}
+`, r.template(data, ""))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "import" {
+  name                  = azurerm_kubernetes_cluster_maintenance_configuration.test.name
+  kubernetes_cluster_id = azurerm_kubernetes_cluster_maintenance_configuration.test.kubernetes_cluster_id
+
+  allowed {
+    day   = "Monday"
+    hours = [1, 2]
+  }
+}
+`, r.defaultConfiguration(data))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) autoUpgradeWeekly(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "aksManagedAutoUpgradeSchedule"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  maintenance_window {
+    duration_in_hours = 4
+    start_time        = "07:00"
+    utc_offset        = "+01:00"
+
+    schedule {
+      weekly {
+        interval_weeks = 2
+        day_of_week    = "Tuesday"
+      }
+    }
+  }
+}
+`, r.template(data, ""))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) autoUpgradeAbsoluteMonthly(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "aksManagedAutoUpgradeSchedule"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  maintenance_window {
+    duration_in_hours = 8
+    start_time        = "02:30"
+
+    schedule {
+      absolute_monthly {
+        interval_months = 1
+        day_of_month    = 15
+      }
+    }
+
+    not_allowed_date {
+      start = "2031-12-20"
+      end   = "2032-01-05"
+    }
+  }
+}
+`, r.template(data, ""))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) autoUpgradeRelativeMonthly(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "aksManagedAutoUpgradeSchedule"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  maintenance_window {
+    duration_in_hours = 6
+    start_time        = "22:00"
+    utc_offset        = "-05:00"
+    start_date        = "2031-01-01"
+
+    schedule {
+      relative_monthly {
+        interval_months = 2
+        day_of_week     = "Saturday"
+        week_index      = "Last"
+      }
+    }
+
+    not_allowed_date {
+      start = "2031-11-26"
+      end   = "2031-11-30"
+    }
+
+    not_allowed_date {
+      start = "2031-12-20"
+      end   = "2032-01-05"
+    }
+  }
+}
+`, r.template(data, ""))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) nodeOSDaily(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "aksManagedNodeOSUpgradeSchedule"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  maintenance_window {
+    duration_in_hours = 4
+    start_time        = "01:00"
+
+    schedule {
+      daily {
+        interval_days = 1
+      }
+    }
+  }
+}
+`, r.template(data, ""))
+}
+
+func (r KubernetesClusterMaintenanceConfigurationResource) conflictsWithInlineBlock(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_cluster_maintenance_configuration" "test" {
+  name                  = "aksManagedAutoUpgradeSchedule"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+
+  maintenance_window {
+    duration_in_hours = 4
+    start_time        = "07:00"
+
+    schedule {
+      weekly {
+        interval_weeks = 1
+        day_of_week    = "Tuesday"
+      }
+    }
+  }
+}
+`, r.template(data, `
+  maintenance_window_auto_upgrade {
+    frequency   = "Weekly"
+    interval    = 1
+    duration    = 4
+    day_of_week = "Monday"
+    start_time  = "07:00"
+    utc_offset  = "+00:00"
+  }
+`))
+}
@@ -43,8 +43,9 @@ func resourceKubernetesClusterNodePool() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
 		Create: resourceKubernetesClusterNodePoolCreate,
 		Read:   resourceKubernetesClusterNodePoolRead,
-		Update: resourceKubernetesClusterNodePoolUpdate,
-		Delete: resourceKubernetesClusterNodePoolDelete,
+		// a paused upgrade of the Node Pool is returned as a warning, which requires the Update function to return Diagnostics
+		UpdateContext: kubernetesNodePoolUpgradeWarnings(resourceKubernetesClusterNodePoolUpdate),
+		Delete:        resourceKubernetesClusterNodePoolDelete,
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
 			_, err := parse.NodePoolID(id)
@@ -887,14 +888,27 @@ func resourceKubernetesClusterNodePoolUpdate(d *pluginsdk.ResourceData, meta int
 
 	log.Printf("[DEBUG] Updating existing %s..", *id)
 	existing.Model.Properties = props
-	err = client.CreateOrUpdateThenPoll(ctx, *id, *existing.Model)
-	if err != nil {
-		return fmt.Errorf("updating Node Pool %s: %+v", *id, err)
+	var upgradePausedErr error
+	if d.HasChange("orchestrator_version") {
+		if err := upgradeKubernetesNodePool(ctx, meta, *id, *existing.Model); err != nil {
+			if !kubernetesNodePoolUpgradePaused(err) {
+				return err
+			}
+			upgradePausedErr = err
+		}
+	} else {
+		if err := client.CreateOrUpdateThenPoll(ctx, *id, *existing.Model); err != nil {
+			return fmt.Errorf("updating Node Pool %s: %+v", *id, err)
+		}
 	}
 
 	d.Partial(false)
 
-	return resourceKubernetesClusterNodePoolRead(d, meta)
+	if err := resourceKubernetesClusterNodePoolRead(d, meta); err != nil {
+		return err
+	}
+
+	return upgradePausedErr
 }
 
 func resourceKubernetesClusterNodePoolRead(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -1042,7 +1056,7 @@ func resourceKubernetesClusterNodePoolRead(d *pluginsdk.ResourceData, meta inter
 		}
 
 		// NOTE: workaround for migration from 2022-01-02-preview (<3.12.0) to 2022-03-02-preview (>=3.12.0). Before terraform apply is run against the new API, Azure will respond only with currentOrchestratorVersion, orchestratorVersion will be absent. More details: https://github.com/hashicorp/terraform-provider-azurerm/issues/17833#issuecomment-1227583353
-		if props.OrchestratorVersion != nil {
+		if props.OrchestratorVersion != nil && !kubernetesNodePoolUpgradeFailed(props.ProvisioningState, props.OrchestratorVersion, props.CurrentOrchestratorVersion) {
 			d.Set("orchestrator_version", props.OrchestratorVersion)
 		} else {
 			d.Set("orchestrator_version", props.CurrentOrchestratorVersion)
@@ -1137,6 +1151,21 @@ func upgradeSettingsSchema() *pluginsdk.Schema {
 						Type:     pluginsdk.TypeString,
 						Required: true,
 					},
+
+					// these default to a value determined by AKS when omitted
+					"drain_timeout_in_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+
+					"node_soak_duration_in_minutes": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Computed:     true,
+						ValidateFunc: validation.IntBetween(0, 30),
+					},
 				},
 			},
 		}
@@ -1152,6 +1181,21 @@ func upgradeSettingsSchema() *pluginsdk.Schema {
 					Optional: true,
 					Default:  "10%",
 				},
+
+				// these default to a value determined by AKS when omitted
+				"drain_timeout_in_minutes": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+
+				"node_soak_duration_in_minutes": {
+					Type:         pluginsdk.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntBetween(0, 30),
+				},
 			},
 		},
 	}
@@ -1167,6 +1211,16 @@ func upgradeSettingsForDataSourceSchema() *pluginsdk.Schema {
 					Type:     pluginsdk.TypeString,
 					Computed: true,
 				},
+
+				"drain_timeout_in_minutes": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
+
+				"node_soak_duration_in_minutes": {
+					Type:     pluginsdk.TypeInt,
+					Computed: true,
+				},
 			},
 		},
 	}
@@ -1223,6 +1277,11 @@ func expandAgentPoolUpgradeSettings(input []interface{}) *agentpools.AgentPoolUp
 	if maxSurgeRaw := v["max_surge"].(string); maxSurgeRaw != "" {
 		setting.MaxSurge = utils.String(maxSurgeRaw)
 	}
+	if drainTimeoutRaw := v["drain_timeout_in_minutes"].(int); drainTimeoutRaw != 0 {
+		setting.DrainTimeoutInMinutes = pointer.To(int64(drainTimeoutRaw))
+	}
+	// a node soak duration of `0` is valid, so this is always sent when the block is specified
+	setting.NodeSoakDurationInMinutes = pointer.To(int64(v["node_soak_duration_in_minutes"].(int)))
 	return setting
 }
 
@@ -1238,7 +1297,9 @@ func flattenAgentPoolUpgradeSettings(input *agentpools.AgentPoolUpgradeSettings)
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                     maxSurge,
+			"drain_timeout_in_minutes":      int(pointer.From(input.DrainTimeoutInMinutes)),
+			"node_soak_duration_in_minutes": int(pointer.From(input.NodeSoakDurationInMinutes)),
 		},
 	}
 }
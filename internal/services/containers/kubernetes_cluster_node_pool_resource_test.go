@@ -594,6 +594,32 @@ func TestAccKubernetesClusterNodePool_upgradeSettings(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesClusterNodePool_upgradeSettingsDrainTimeoutAndNodeSoakDuration(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_node_pool", "test")
+	r := KubernetesClusterNodePoolResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.upgradeSettingsDrainTimeoutAndNodeSoakDurationConfig(data, 30, 0),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upgrade_settings.0.drain_timeout_in_minutes").HasValue("30"),
+				check.That(data.ResourceName).Key("upgrade_settings.0.node_soak_duration_in_minutes").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.upgradeSettingsDrainTimeoutAndNodeSoakDurationConfig(data, 45, 5),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("upgrade_settings.0.drain_timeout_in_minutes").HasValue("45"),
+				check.That(data.ResourceName).Key("upgrade_settings.0.node_soak_duration_in_minutes").HasValue("5"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccKubernetesClusterNodePool_virtualNetworkAutomatic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_node_pool", "test")
 	r := KubernetesClusterNodePoolResource{}
@@ -2047,6 +2073,28 @@ resource "azurerm_kubernetes_cluster_node_pool" "test" {
 `, template, maxSurge)
 }
 
+func (r KubernetesClusterNodePoolResource) upgradeSettingsDrainTimeoutAndNodeSoakDurationConfig(data acceptance.TestData, drainTimeout, nodeSoakDuration int) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+%s
+
+resource "azurerm_kubernetes_cluster_node_pool" "test" {
+  name                  = "internal"
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  vm_size               = "Standard_DS2_v2"
+  node_count            = 3
+  upgrade_settings {
+    max_surge                     = "10%%"
+    drain_timeout_in_minutes      = %d
+    node_soak_duration_in_minutes = %d
+  }
+}
+`, r.templateConfig(data), drainTimeout, nodeSoakDuration)
+}
+
 func (r KubernetesClusterNodePoolResource) virtualNetworkAutomaticConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
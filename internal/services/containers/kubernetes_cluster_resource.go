@@ -32,6 +32,7 @@ import (
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	computeValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/compute/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/migration"
 	containerValidate "github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
@@ -49,8 +50,9 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 	resource := &pluginsdk.Resource{
 		Create: resourceKubernetesClusterCreate,
 		Read:   resourceKubernetesClusterRead,
-		Update: resourceKubernetesClusterUpdate,
-		Delete: resourceKubernetesClusterDelete,
+		// a paused upgrade of the Default Node Pool is returned as a warning, which requires the Update function to return Diagnostics
+		UpdateContext: kubernetesNodePoolUpgradeWarnings(resourceKubernetesClusterUpdate),
+		Delete:        resourceKubernetesClusterDelete,
 
 		Importer: pluginsdk.ImporterValidatingResourceIdThen(
 			func(id string) error {
@@ -544,6 +546,12 @@ func resourceKubernetesCluster() *pluginsdk.Resource {
 				},
 			},
 
+			"kube_config_exec": {
+				Type:      pluginsdk.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"kube_config_raw": {
 				Type:      pluginsdk.TypeString,
 				Computed:  true,
@@ -2021,6 +2029,10 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		return err
 	}
 
+	// resources such as Trusted Access Role Bindings can't be modified whilst the cluster is being updated
+	locks.ByID(id.ID())
+	defer locks.UnlockByID(id.ID())
+
 	d.Partial(true)
 
 	// we need to conditionally update the cluster
@@ -2512,6 +2524,7 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 	}
 
 	// update the node pool using the separate API
+	var upgradePausedErr error
 	if d.HasChange("default_node_pool") {
 		agentProfiles, err := ExpandDefaultNodePool(d)
 		if err != nil {
@@ -2616,8 +2629,17 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 		} else {
 			log.Printf("[DEBUG] Updating of Default Node Pool..")
 
-			if err := nodePoolsClient.CreateOrUpdateThenPoll(ctx, defaultNodePoolId, agentProfile); err != nil {
-				return fmt.Errorf("updating Default Node Pool %s %+v", defaultNodePoolId, err)
+			if d.HasChange("default_node_pool.0.orchestrator_version") {
+				if err := upgradeKubernetesNodePool(ctx, meta, defaultNodePoolId, agentProfile); err != nil {
+					if !kubernetesNodePoolUpgradePaused(err) {
+						return err
+					}
+					upgradePausedErr = err
+				}
+			} else {
+				if err := nodePoolsClient.CreateOrUpdateThenPoll(ctx, defaultNodePoolId, agentProfile); err != nil {
+					return fmt.Errorf("updating Default Node Pool %s %+v", defaultNodePoolId, err)
+				}
 			}
 
 			log.Printf("[DEBUG] Updated Default Node Pool.")
@@ -2696,7 +2718,11 @@ func resourceKubernetesClusterUpdate(d *pluginsdk.ResourceData, meta interface{}
 
 	d.Partial(false)
 
-	return resourceKubernetesClusterRead(d, meta)
+	if err := resourceKubernetesClusterRead(d, meta); err != nil {
+		return err
+	}
+
+	return upgradePausedErr
 }
 
 func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{}) error {
@@ -3000,23 +3026,19 @@ func resourceKubernetesClusterRead(d *pluginsdk.ResourceData, meta interface{})
 			return fmt.Errorf("setting `kube_config`: %+v", err)
 		}
 
-		maintenanceConfigurationsClient := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
-		maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "default")
-		configResp, _ := maintenanceConfigurationsClient.Get(ctx, maintenanceId)
-		if configurationBody := configResp.Model; configurationBody != nil && configurationBody.Properties != nil {
-			d.Set("maintenance_window", flattenKubernetesClusterMaintenanceConfigurationDefault(configurationBody.Properties))
-		}
-
-		maintenanceId = maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "aksManagedAutoUpgradeSchedule")
-		configResp, _ = maintenanceConfigurationsClient.Get(ctx, maintenanceId)
-		if configurationBody := configResp.Model; configurationBody != nil && configurationBody.Properties != nil && configurationBody.Properties.MaintenanceWindow != nil {
-			d.Set("maintenance_window_auto_upgrade", flattenKubernetesClusterMaintenanceConfiguration(configurationBody.Properties.MaintenanceWindow))
+		var aadProfile *managedclusters.ManagedClusterAADProfile
+		if props := model.Properties; props != nil {
+			aadProfile = props.AadProfile
 		}
+		d.Set("kube_config_exec", flattenKubernetesClusterKubeConfigExec(kubeConfigRaw, aadProfile))
 
-		maintenanceId = maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, "aksManagedNodeOSUpgradeSchedule")
-		configResp, _ = maintenanceConfigurationsClient.Get(ctx, maintenanceId)
-		if configurationBody := configResp.Model; configurationBody != nil && configurationBody.Properties != nil && configurationBody.Properties.MaintenanceWindow != nil {
-			d.Set("maintenance_window_node_os", flattenKubernetesClusterMaintenanceConfiguration(configurationBody.Properties.MaintenanceWindow))
+		// the Maintenance Configurations are always refreshed so that changes made outside of Terraform are detected, when
+		// these are managed using the `azurerm_kubernetes_cluster_maintenance_configuration` resource the corresponding
+		// blocks should be included in `ignore_changes`
+		maintenanceConfigurationsClient := meta.(*clients.Client).Containers.MaintenanceConfigurationsClient
+		for name, block := range kubernetesClusterMaintenanceConfigurationInlineBlocks {
+			maintenanceId := maintenanceconfigurations.NewMaintenanceConfigurationID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName, name)
+			d.Set(block, flattenKubernetesClusterMaintenanceConfigurationBlock(ctx, maintenanceConfigurationsClient, maintenanceId))
 		}
 
 		if err := tags.FlattenAndSet(d, model.Tags); err != nil {
@@ -0,0 +1,450 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-03-02-preview/trustedaccess"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/azure"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/containers/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+var (
+	_ sdk.Resource                  = KubernetesClusterTrustedAccessRoleBindingResource{}
+	_ sdk.ResourceWithUpdate        = KubernetesClusterTrustedAccessRoleBindingResource{}
+	_ sdk.ResourceWithCustomizeDiff = KubernetesClusterTrustedAccessRoleBindingResource{}
+)
+
+// NOTE: this resource was previously generated by Pandora, but is now maintained by hand so that it can validate the
+// `roles` and serialise changes to the Kubernetes Cluster. The generation of this resource must be disabled within the
+// Pandora configuration (https://github.com/hashicorp/pandora), otherwise regenerating the provider will reintroduce
+// `kubernetes_cluster_trusted_access_role_binding_resource_gen.go` and the registration within `registration_gen.go`.
+type KubernetesClusterTrustedAccessRoleBindingResource struct{}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) ModelObject() interface{} {
+	return &KubernetesClusterTrustedAccessRoleBindingResourceSchema{}
+}
+
+type KubernetesClusterTrustedAccessRoleBindingResourceSchema struct {
+	KubernetesClusterId string   `tfschema:"kubernetes_cluster_id"`
+	Name                string   `tfschema:"name"`
+	Roles               []string `tfschema:"roles"`
+	SourceResourceId    string   `tfschema:"source_resource_id"`
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return trustedaccess.ValidateTrustedAccessRoleBindingID
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) ResourceType() string {
+	return "azurerm_kubernetes_cluster_trusted_access_role_binding"
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"kubernetes_cluster_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: commonids.ValidateKubernetesClusterID,
+		},
+
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.KubernetesClusterTrustedAccessRoleBindingName,
+		},
+
+		"roles": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validate.KubernetesClusterTrustedAccessRoleName,
+			},
+		},
+
+		"source_resource_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: azure.ValidateResourceID,
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config KubernetesClusterTrustedAccessRoleBindingResourceSchema
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// the Source Resource ID won't be known until apply when the Source Resource is being created
+			if config.SourceResourceId == "" {
+				return nil
+			}
+
+			return validateKubernetesClusterTrustedAccessRolesForSourceResource(config.SourceResourceId, config.Roles)
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.V20230302Preview.TrustedAccess
+
+			var config KubernetesClusterTrustedAccessRoleBindingResourceSchema
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			kubernetesClusterId, err := commonids.ParseKubernetesClusterID(config.KubernetesClusterId)
+			if err != nil {
+				return err
+			}
+
+			id := trustedaccess.NewTrustedAccessRoleBindingID(kubernetesClusterId.SubscriptionId, kubernetesClusterId.ResourceGroupName, kubernetesClusterId.ManagedClusterName, config.Name)
+
+			locks.ByID(kubernetesClusterId.ID())
+			defer locks.UnlockByID(kubernetesClusterId.ID())
+
+			existing, err := client.RoleBindingsGet(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			if err := r.validateRolesAreAvailable(ctx, metadata, *kubernetesClusterId, config); err != nil {
+				return err
+			}
+
+			payload := trustedaccess.TrustedAccessRoleBinding{
+				Properties: trustedaccess.TrustedAccessRoleBindingProperties{
+					Roles:            config.Roles,
+					SourceResourceId: config.SourceResourceId,
+				},
+			}
+
+			if _, err := client.RoleBindingsCreateOrUpdate(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			if err := waitForKubernetesClusterTrustedAccessRoleBinding(ctx, client, id); err != nil {
+				return fmt.Errorf("waiting for the creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.V20230302Preview.TrustedAccess
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			kubernetesClusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+
+			resp, err := client.RoleBindingsGet(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := KubernetesClusterTrustedAccessRoleBindingResourceSchema{
+				KubernetesClusterId: kubernetesClusterId.ID(),
+				Name:                id.TrustedAccessRoleBindingName,
+			}
+
+			if model := resp.Model; model != nil {
+				state.Roles = model.Properties.Roles
+				state.SourceResourceId = model.Properties.SourceResourceId
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.V20230302Preview.TrustedAccess
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config KubernetesClusterTrustedAccessRoleBindingResourceSchema
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			kubernetesClusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+
+			locks.ByID(kubernetesClusterId.ID())
+			defer locks.UnlockByID(kubernetesClusterId.ID())
+
+			existing, err := client.RoleBindingsGet(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving existing %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving existing %s: `model` was nil", *id)
+			}
+			payload := *existing.Model
+
+			if metadata.ResourceData.HasChange("roles") {
+				if err := r.validateRolesAreAvailable(ctx, metadata, kubernetesClusterId, config); err != nil {
+					return err
+				}
+
+				payload.Properties.Roles = config.Roles
+			}
+
+			if _, err := client.RoleBindingsCreateOrUpdate(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			if err := waitForKubernetesClusterTrustedAccessRoleBinding(ctx, client, *id); err != nil {
+				return fmt.Errorf("waiting for the update of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r KubernetesClusterTrustedAccessRoleBindingResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ContainerService.V20230302Preview.TrustedAccess
+
+			id, err := trustedaccess.ParseTrustedAccessRoleBindingID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			kubernetesClusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+
+			locks.ByID(kubernetesClusterId.ID())
+			defer locks.UnlockByID(kubernetesClusterId.ID())
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return fmt.Errorf("internal-error: context had no deadline")
+			}
+
+			// the Role Binding can't be deleted whilst an operation (such as an upgrade) is in progress on the Kubernetes
+			// Cluster, which can be started outside of Terraform - so we retry until that operation has completed
+			stateConf := &pluginsdk.StateChangeConf{
+				Pending: []string{"ClusterOperationInProgress"},
+				Target:  []string{"Deleted"},
+				Refresh: func() (interface{}, string, error) {
+					resp, err := client.RoleBindingsDelete(ctx, *id)
+					if err != nil {
+						if response.WasConflict(resp.HttpResponse) {
+							return resp, "ClusterOperationInProgress", nil
+						}
+						if response.WasNotFound(resp.HttpResponse) {
+							return resp, "Deleted", nil
+						}
+						return nil, "", err
+					}
+
+					return resp, "Deleted", nil
+				},
+				MinTimeout:   30 * time.Second,
+				PollInterval: 30 * time.Second,
+				Timeout:      time.Until(deadline),
+			}
+			if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			deleteConf := &pluginsdk.StateChangeConf{
+				Pending: []string{string(trustedaccess.TrustedAccessRoleBindingProvisioningStateDeleting)},
+				Target:  []string{"NotFound"},
+				Refresh: func() (interface{}, string, error) {
+					resp, err := client.RoleBindingsGet(ctx, *id)
+					if err != nil {
+						if response.WasNotFound(resp.HttpResponse) {
+							return resp, "NotFound", nil
+						}
+						return nil, "", fmt.Errorf("retrieving %s: %+v", *id, err)
+					}
+
+					return resp, string(trustedaccess.TrustedAccessRoleBindingProvisioningStateDeleting), nil
+				},
+				MinTimeout:   10 * time.Second,
+				PollInterval: 10 * time.Second,
+				Timeout:      time.Until(deadline),
+			}
+			if _, err := deleteConf.WaitForStateContext(ctx); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// validateRolesAreAvailable checks that the Roles are available for the Source Resource within the location of the Kubernetes Cluster
+func (r KubernetesClusterTrustedAccessRoleBindingResource) validateRolesAreAvailable(ctx context.Context, metadata sdk.ResourceMetaData, kubernetesClusterId commonids.KubernetesClusterId, config KubernetesClusterTrustedAccessRoleBindingResourceSchema) error {
+	clusterClient := metadata.Client.Containers.KubernetesClustersClient
+	client := metadata.Client.ContainerService.V20230302Preview.TrustedAccess
+
+	cluster, err := clusterClient.Get(ctx, kubernetesClusterId)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", kubernetesClusterId, err)
+	}
+	if cluster.Model == nil {
+		return fmt.Errorf("retrieving %s: `model` was nil", kubernetesClusterId)
+	}
+
+	locationId := trustedaccess.NewLocationID(kubernetesClusterId.SubscriptionId, location.Normalize(cluster.Model.Location))
+	roles, err := client.RolesListComplete(ctx, locationId)
+	if err != nil {
+		return fmt.Errorf("listing the Trusted Access Roles available within %s: %+v", locationId, err)
+	}
+
+	sourceResourceType := kubernetesClusterTrustedAccessSourceResourceType(config.SourceResourceId)
+	available := make([]string, 0)
+	for _, role := range roles.Items {
+		if !strings.EqualFold(pointer.From(role.SourceResourceType), sourceResourceType) {
+			continue
+		}
+		available = append(available, fmt.Sprintf("%s/%s", pointer.From(role.SourceResourceType), pointer.From(role.Name)))
+	}
+	sort.Strings(available)
+
+	for _, role := range config.Roles {
+		found := false
+		for _, v := range available {
+			if strings.EqualFold(role, v) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("the Trusted Access Role %q isn't available for the Source Resource %q within %s - the available roles are: %s", role, config.SourceResourceId, locationId, strings.Join(available, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateKubernetesClusterTrustedAccessRolesForSourceResource checks that each Role is defined for the type of the Source Resource
+func validateKubernetesClusterTrustedAccessRolesForSourceResource(sourceResourceId string, roles []string) error {
+	sourceResourceType := kubernetesClusterTrustedAccessSourceResourceType(sourceResourceId)
+	if sourceResourceType == "" {
+		return fmt.Errorf("unable to determine the Resource Type of the `source_resource_id` %q", sourceResourceId)
+	}
+
+	for _, role := range roles {
+		// roles are in the format `{Resource Provider}/{Resource Type}/{Role}`
+		if i := strings.LastIndex(role, "/"); i == -1 || !strings.EqualFold(role[:i], sourceResourceType) {
+			return fmt.Errorf("the role %q in `roles` must be a role for the Resource Type %q of the `source_resource_id`", role, sourceResourceType)
+		}
+	}
+
+	return nil
+}
+
+// kubernetesClusterTrustedAccessSourceResourceType returns the Resource Type of the Source Resource, for example a
+// Source Resource ID of `/subscriptions/.../providers/Microsoft.MachineLearningServices/workspaces/workspace1`
+// returns `Microsoft.MachineLearningServices/workspaces`
+func kubernetesClusterTrustedAccessSourceResourceType(sourceResourceId string) string {
+	i := strings.LastIndex(strings.ToLower(sourceResourceId), "/providers/")
+	if i == -1 {
+		return ""
+	}
+
+	segments := strings.Split(strings.Trim(sourceResourceId[i+len("/providers/"):], "/"), "/")
+	if len(segments) < 3 || len(segments)%2 == 0 {
+		return ""
+	}
+
+	resourceType := segments[0]
+	for j := 1; j < len(segments); j += 2 {
+		resourceType += "/" + segments[j]
+	}
+
+	return resourceType
+}
+
+func waitForKubernetesClusterTrustedAccessRoleBinding(ctx context.Context, client *trustedaccess.TrustedAccessClient, id trustedaccess.TrustedAccessRoleBindingId) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{string(trustedaccess.TrustedAccessRoleBindingProvisioningStateUpdating)},
+		Target:  []string{string(trustedaccess.TrustedAccessRoleBindingProvisioningStateSucceeded)},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.RoleBindingsGet(ctx, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil {
+				return nil, "", fmt.Errorf("retrieving %s: `model` was nil", id)
+			}
+
+			// a Role Binding without a provisioning state has finished provisioning
+			state := string(trustedaccess.TrustedAccessRoleBindingProvisioningStateSucceeded)
+			if v := resp.Model.Properties.ProvisioningState; v != nil {
+				state = string(*v)
+			}
+			return resp, state, nil
+		},
+		MinTimeout:   10 * time.Second,
+		PollInterval: 10 * time.Second,
+		Timeout:      time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
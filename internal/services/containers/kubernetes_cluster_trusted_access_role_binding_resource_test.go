@@ -1,8 +1,8 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
 package containers_test
 
-// NOTE: this file is generated - manual changes will be overwritten.
-// Copyright (c) Microsoft Corporation. All rights reserved.
-// Licensed under the MIT License. See NOTICE.txt in the project root for license information.
 import (
 	"context"
 	"fmt"
@@ -33,6 +33,36 @@ func TestAccKubernetesClusterTrustedAccessRoleBinding_basic(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesClusterTrustedAccessRoleBinding_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_trusted_access_role_binding", "test")
+	r := KubernetesClusterTrustedAccessRoleBindingTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.multipleRoles(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("roles.#").HasValue("2"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccKubernetesClusterTrustedAccessRoleBinding_requiresImport(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_cluster_trusted_access_role_binding", "test")
 	r := KubernetesClusterTrustedAccessRoleBindingTestResource{}
@@ -77,6 +107,26 @@ resource "azurerm_kubernetes_cluster_trusted_access_role_binding" "test" {
 `, r.template(data))
 }
 
+func (r KubernetesClusterTrustedAccessRoleBindingTestResource) multipleRoles(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_kubernetes_cluster_trusted_access_role_binding" "test" {
+  kubernetes_cluster_id = azurerm_kubernetes_cluster.test.id
+  name                  = "acctestkctarb-${var.random_string}"
+  roles = [
+    "Microsoft.MachineLearningServices/workspaces/mlworkload",
+    "Microsoft.MachineLearningServices/workspaces/inference-v1",
+  ]
+  source_resource_id = azurerm_machine_learning_workspace.test.id
+}
+`, r.template(data))
+}
+
 func (r KubernetesClusterTrustedAccessRoleBindingTestResource) requiresImport(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 %s
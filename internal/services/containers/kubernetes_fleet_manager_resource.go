@@ -8,13 +8,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
-	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-10-15/fleets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/fleets"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
 )
 
 var _ sdk.Resource = KubernetesFleetManagerResource{}
@@ -27,10 +30,21 @@ func (r KubernetesFleetManagerResource) ModelObject() interface{} {
 }
 
 type KubernetesFleetManagerResourceSchema struct {
-	Location          string                 `tfschema:"location"`
-	Name              string                 `tfschema:"name"`
-	ResourceGroupName string                 `tfschema:"resource_group_name"`
-	Tags              map[string]interface{} `tfschema:"tags"`
+	HubProfile        []KubernetesFleetManagerHubProfileSchema `tfschema:"hub_profile"`
+	Location          string                                   `tfschema:"location"`
+	Name              string                                   `tfschema:"name"`
+	ResourceGroupName string                                   `tfschema:"resource_group_name"`
+	Tags              map[string]interface{}                   `tfschema:"tags"`
+}
+
+type KubernetesFleetManagerHubProfileSchema struct {
+	DnsPrefix             string `tfschema:"dns_prefix"`
+	Fqdn                  string `tfschema:"fqdn"`
+	KubernetesVersion     string `tfschema:"kubernetes_version"`
+	PortalFqdn            string `tfschema:"portal_fqdn"`
+	PrivateClusterEnabled bool   `tfschema:"private_cluster_enabled"`
+	SubnetId              string `tfschema:"subnet_id"`
+	VMSize                string `tfschema:"vm_size"`
 }
 
 func (r KubernetesFleetManagerResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
@@ -49,12 +63,32 @@ func (r KubernetesFleetManagerResource) Arguments() map[string]*pluginsdk.Schema
 		},
 		"resource_group_name": commonschema.ResourceGroupName(),
 		"hub_profile": {
-			Deprecated: "The service team has indicated this field is now deprecated and not to be used, as such we are marking it as such and no longer sending it to the API, please see url: https://learn.microsoft.com/en-us/azure/kubernetes-fleet/architectural-overview",
 			Elem: &pluginsdk.Resource{
 				Schema: map[string]*pluginsdk.Schema{
 					"dns_prefix": {
-						Required: true,
-						Type:     pluginsdk.TypeString,
+						ForceNew:     true,
+						Required:     true,
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+					"private_cluster_enabled": {
+						Default:  false,
+						ForceNew: true,
+						Optional: true,
+						Type:     pluginsdk.TypeBool,
+					},
+					"subnet_id": {
+						ForceNew:     true,
+						Optional:     true,
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: commonids.ValidateSubnetID,
+					},
+					"vm_size": {
+						Computed:     true,
+						ForceNew:     true,
+						Optional:     true,
+						Type:         pluginsdk.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
 					},
 					"fqdn": {
 						Computed: true,
@@ -64,12 +98,26 @@ func (r KubernetesFleetManagerResource) Arguments() map[string]*pluginsdk.Schema
 						Computed: true,
 						Type:     pluginsdk.TypeString,
 					},
+					"portal_fqdn": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
 				},
 			},
 			ForceNew: true,
 			MaxItems: 1,
 			Optional: true,
 			Type:     pluginsdk.TypeList,
+			// the `hub_profile` block was previously deprecated and not sent to the API, so existing Fleets whose
+			// configuration contains this block were created without a Hub Profile - rather than replacing these
+			// Fleets, the block is ignored until the Fleet is recreated
+			DiffSuppressFunc: func(_, _, _ string, d *pluginsdk.ResourceData) bool {
+				if d.Id() == "" {
+					return false
+				}
+				old, new := d.GetChange("hub_profile")
+				return len(old.([]interface{})) == 0 && len(new.([]interface{})) > 0
+			},
 		},
 		"tags": commonschema.Tags(),
 	}
@@ -81,7 +129,7 @@ func (r KubernetesFleetManagerResource) Create() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 30 * time.Minute,
 		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
-			client := metadata.Client.ContainerService.V20231015.Fleets
+			client := metadata.Client.ContainerService.V20240401.Fleets
 
 			var config KubernetesFleetManagerResourceSchema
 			if err := metadata.Decode(&config); err != nil {
@@ -105,6 +153,11 @@ func (r KubernetesFleetManagerResource) Create() sdk.ResourceFunc {
 			var payload fleets.Fleet
 			r.mapKubernetesFleetManagerResourceSchemaToFleet(config, &payload)
 
+			// the Hub Profile can only be specified when the Fleet is created
+			if len(config.HubProfile) > 0 {
+				payload.Properties.HubProfile = expandKubernetesFleetManagerHubProfile(config.HubProfile[0])
+			}
+
 			if err := client.CreateOrUpdateThenPoll(ctx, id, payload, fleets.DefaultCreateOrUpdateOperationOptions()); err != nil {
 				return fmt.Errorf("creating %s: %+v", id, err)
 			}
@@ -118,7 +171,7 @@ func (r KubernetesFleetManagerResource) Read() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 5 * time.Minute,
 		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
-			client := metadata.Client.ContainerService.V20231015.Fleets
+			client := metadata.Client.ContainerService.V20240401.Fleets
 			schema := KubernetesFleetManagerResourceSchema{}
 
 			id, err := fleets.ParseFleetID(metadata.ResourceData.Id())
@@ -148,7 +201,7 @@ func (r KubernetesFleetManagerResource) Delete() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 30 * time.Minute,
 		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
-			client := metadata.Client.ContainerService.V20231015.Fleets
+			client := metadata.Client.ContainerService.V20240401.Fleets
 
 			id, err := fleets.ParseFleetID(metadata.ResourceData.Id())
 			if err != nil {
@@ -167,7 +220,7 @@ func (r KubernetesFleetManagerResource) Update() sdk.ResourceFunc {
 	return sdk.ResourceFunc{
 		Timeout: 30 * time.Minute,
 		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
-			client := metadata.Client.ContainerService.V20231015.Fleets
+			client := metadata.Client.ContainerService.V20240401.Fleets
 
 			id, err := fleets.ParseFleetID(metadata.ResourceData.Id())
 			if err != nil {
@@ -215,4 +268,49 @@ func (r KubernetesFleetManagerResource) mapFleetToKubernetesFleetManagerResource
 	if input.Properties == nil {
 		input.Properties = &fleets.FleetProperties{}
 	}
+
+	output.HubProfile = flattenKubernetesFleetManagerHubProfile(input.Properties.HubProfile)
+}
+
+func expandKubernetesFleetManagerHubProfile(input KubernetesFleetManagerHubProfileSchema) *fleets.FleetHubProfile {
+	output := &fleets.FleetHubProfile{
+		DnsPrefix: pointer.To(input.DnsPrefix),
+		ApiServerAccessProfile: &fleets.APIServerAccessProfile{
+			EnablePrivateCluster: pointer.To(input.PrivateClusterEnabled),
+		},
+	}
+
+	if input.SubnetId != "" || input.VMSize != "" {
+		output.AgentProfile = &fleets.AgentProfile{}
+		if input.SubnetId != "" {
+			output.AgentProfile.SubnetId = pointer.To(input.SubnetId)
+		}
+		if input.VMSize != "" {
+			output.AgentProfile.VMSize = pointer.To(input.VMSize)
+		}
+	}
+
+	return output
+}
+
+func flattenKubernetesFleetManagerHubProfile(input *fleets.FleetHubProfile) []KubernetesFleetManagerHubProfileSchema {
+	if input == nil {
+		return []KubernetesFleetManagerHubProfileSchema{}
+	}
+
+	output := KubernetesFleetManagerHubProfileSchema{
+		DnsPrefix:         pointer.From(input.DnsPrefix),
+		Fqdn:              pointer.From(input.Fqdn),
+		KubernetesVersion: pointer.From(input.KubernetesVersion),
+		PortalFqdn:        pointer.From(input.PortalFqdn),
+	}
+	if profile := input.ApiServerAccessProfile; profile != nil {
+		output.PrivateClusterEnabled = pointer.From(profile.EnablePrivateCluster)
+	}
+	if profile := input.AgentProfile; profile != nil {
+		output.SubnetId = pointer.From(profile.SubnetId)
+		output.VMSize = pointer.From(profile.VMSize)
+	}
+
+	return []KubernetesFleetManagerHubProfileSchema{output}
 }
@@ -8,9 +8,10 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-10-15/fleets"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2024-04-01/fleets"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -57,9 +58,11 @@ func TestAccKubernetesFleetManager_complete(t *testing.T) {
 			Config: r.complete(data),
 			Check: acceptance.ComposeTestCheckFunc(
 				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("hub_profile.0.fqdn").IsSet(),
+				check.That(data.ResourceName).Key("hub_profile.0.kubernetes_version").IsSet(),
 			),
 		},
-		data.ImportStep("hub_profile.#", "hub_profile.0.%", "hub_profile.0.dns_prefix", "hub_profile.0.fqdn", "hub_profile.0.kubernetes_version"),
+		data.ImportStep(),
 	})
 }
 
@@ -81,7 +84,7 @@ func TestAccKubernetesFleetManager_update(t *testing.T) {
 				check.That(data.ResourceName).ExistsInAzure(r),
 			),
 		},
-		data.ImportStep("hub_profile.#", "hub_profile.0.%", "hub_profile.0.dns_prefix", "hub_profile.0.fqdn", "hub_profile.0.kubernetes_version"),
+		data.ImportStep(),
 		{
 			Config: r.basic(data),
 			Check: acceptance.ComposeTestCheckFunc(
@@ -91,13 +94,45 @@ func TestAccKubernetesFleetManager_update(t *testing.T) {
 		data.ImportStep(),
 	})
 }
+func TestKubernetesFleetManager_mockHubProfileOnExistingFleet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_manager", "test")
+	data.UseMockServer(t, mockarm.NewServer(t))
+	r := KubernetesFleetManagerTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		{
+			// a Fleet created without a Hub Profile (e.g. whilst the block was ignored) shouldn't be replaced
+			Config: r.complete(data),
+			ConfigPlanChecks: acceptance.ConfigPlanChecks{
+				PreApply: []acceptance.PlanCheck{
+					check.Plan.ExpectUpdateInPlace(data.ResourceName, "tags.environment", "tags.some_key"),
+				},
+				PostApplyPostRefresh: []acceptance.PlanCheck{
+					check.Plan.ExpectNoChanges(),
+				},
+			},
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("hub_profile.#").HasValue("0"),
+				check.That(data.ResourceName).Key("tags.%").HasValue("2"),
+			),
+		},
+	})
+}
+
 func (r KubernetesFleetManagerTestResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	id, err := fleets.ParseFleetID(state.ID)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := clients.ContainerService.V20231015.Fleets.Get(ctx, *id)
+	resp, err := clients.ContainerService.V20240401.Fleets.Get(ctx, *id)
 	if err != nil {
 		return nil, fmt.Errorf("reading %s: %+v", *id, err)
 	}
@@ -6,6 +6,7 @@ package containers
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
@@ -33,6 +34,21 @@ type KubernetesFleetUpdateRunResourceSchema struct {
 	ManagedClusterUpdate     []KubernetesFleetUpdateRunResourceManagedClusterUpdateSchema `tfschema:"managed_cluster_update"`
 	FleetUpdateStrategyId    string                                                       `tfschema:"fleet_update_strategy_id"`
 	Stage                    []KubernetesFleetUpdateRunResourceUpdateStageSchema          `tfschema:"stage"`
+	WaitForCompletion        bool                                                         `tfschema:"wait_for_completion"`
+	Status                   string                                                       `tfschema:"status"`
+	MemberStatus             []KubernetesFleetUpdateRunResourceMemberStatusSchema         `tfschema:"member_status"`
+}
+
+type KubernetesFleetUpdateRunResourceMemberStatusSchema struct {
+	Name                string `tfschema:"name"`
+	KubernetesClusterId string `tfschema:"kubernetes_cluster_id"`
+	StageName           string `tfschema:"stage_name"`
+	GroupName           string `tfschema:"group_name"`
+	Status              string `tfschema:"status"`
+	Message             string `tfschema:"message"`
+	StartTime           string `tfschema:"start_time"`
+	CompletedTime       string `tfschema:"completed_time"`
+	ErrorMessage        string `tfschema:"error_message"`
 }
 
 type KubernetesFleetUpdateRunResourceManagedClusterUpdateSchema struct {
@@ -168,11 +184,75 @@ func (r KubernetesFleetUpdateRunResource) Arguments() map[string]*pluginsdk.Sche
 				},
 			},
 		},
+
+		"wait_for_completion": {
+			Optional: true,
+			Type:     pluginsdk.TypeBool,
+			Default:  false,
+		},
 	}
 }
 
 func (r KubernetesFleetUpdateRunResource) Attributes() map[string]*pluginsdk.Schema {
-	return map[string]*pluginsdk.Schema{}
+	return map[string]*pluginsdk.Schema{
+		"status": {
+			Computed: true,
+			Type:     pluginsdk.TypeString,
+		},
+
+		"member_status": {
+			Computed: true,
+			Type:     pluginsdk.TypeList,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"kubernetes_cluster_id": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"stage_name": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"group_name": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"status": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"message": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"start_time": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"completed_time": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+
+					"error_message": {
+						Computed: true,
+						Type:     pluginsdk.TypeString,
+					},
+				},
+			},
+		},
+	}
 }
 
 func (r KubernetesFleetUpdateRunResource) Create() sdk.ResourceFunc {
@@ -216,6 +296,13 @@ func (r KubernetesFleetUpdateRunResource) Create() sdk.ResourceFunc {
 			}
 
 			metadata.SetID(id)
+
+			if config.WaitForCompletion {
+				if err := startAndWaitForKubernetesFleetUpdateRun(ctx, client, id); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
@@ -262,6 +349,12 @@ func (r KubernetesFleetUpdateRunResource) Update() sdk.ResourceFunc {
 				return fmt.Errorf("updating %s: %+v", *id, err)
 			}
 
+			if config.WaitForCompletion && metadata.ResourceData.HasChange("wait_for_completion") {
+				if err := startAndWaitForKubernetesFleetUpdateRun(ctx, client, *id); err != nil {
+					return err
+				}
+			}
+
 			return nil
 		},
 	}
@@ -287,6 +380,9 @@ func (r KubernetesFleetUpdateRunResource) Read() sdk.ResourceFunc {
 				return fmt.Errorf("retrieving %s: %+v", *id, err)
 			}
 
+			// `wait_for_completion` isn't returned from the API, so we look this up from the config/state
+			schema.WaitForCompletion = metadata.ResourceData.Get("wait_for_completion").(bool)
+
 			if model := resp.Model; model != nil {
 				schema.Name = id.UpdateRunName
 				schema.KubernetesFleetManagerId = commonids.NewKubernetesFleetID(id.SubscriptionId, id.ResourceGroupName, id.FleetName).ID()
@@ -294,6 +390,7 @@ func (r KubernetesFleetUpdateRunResource) Read() sdk.ResourceFunc {
 					schema.ManagedClusterUpdate = flattenKubernetesFleetUpdateRunManagedClusterUpdate(model.Properties.ManagedClusterUpdate)
 					schema.FleetUpdateStrategyId = pointer.From(model.Properties.UpdateStrategyId)
 					schema.Stage = flattenKubernetesFleetUpdateRunStage(model.Properties.Strategy.Stages)
+					schema.Status, schema.MemberStatus = flattenKubernetesFleetUpdateRunStatus(model.Properties.Status)
 				}
 			}
 
@@ -434,3 +531,149 @@ func flattenKubernetesFleetUpdateRunGroup(input *[]updateruns.UpdateGroup) []Kub
 	}
 	return output
 }
+
+func flattenKubernetesFleetUpdateRunStatus(input *updateruns.UpdateRunStatus) (string, []KubernetesFleetUpdateRunResourceMemberStatusSchema) {
+	status := ""
+	members := make([]KubernetesFleetUpdateRunResourceMemberStatusSchema, 0)
+	if input == nil {
+		return status, members
+	}
+
+	if input.Status != nil && input.Status.State != nil {
+		status = string(*input.Status.State)
+	}
+
+	if input.Stages == nil {
+		return status, members
+	}
+
+	for _, stage := range *input.Stages {
+		if stage.Groups == nil {
+			continue
+		}
+
+		for _, group := range *stage.Groups {
+			if group.Members == nil {
+				continue
+			}
+
+			for _, member := range *group.Members {
+				output := KubernetesFleetUpdateRunResourceMemberStatusSchema{
+					Name:                pointer.From(member.Name),
+					KubernetesClusterId: pointer.From(member.ClusterResourceId),
+					StageName:           pointer.From(stage.Name),
+					GroupName:           pointer.From(group.Name),
+					Message:             pointer.From(member.Message),
+				}
+				if v := member.Status; v != nil {
+					if v.State != nil {
+						output.Status = string(*v.State)
+					}
+					output.StartTime = pointer.From(v.StartTime)
+					output.CompletedTime = pointer.From(v.CompletedTime)
+					if v.Error != nil {
+						output.ErrorMessage = pointer.From(v.Error.Message)
+					}
+				}
+				members = append(members, output)
+			}
+		}
+	}
+
+	return status, members
+}
+
+// startAndWaitForKubernetesFleetUpdateRun starts the Update Run (when it's not already been started) and then waits
+// for it to complete, returning the status of each member which failed to be updated when the Update Run fails
+func startAndWaitForKubernetesFleetUpdateRun(ctx context.Context, client *updateruns.UpdateRunsClient, id updateruns.UpdateRunId) error {
+	resp, err := client.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+
+	state := string(updateruns.UpdateStateNotStarted)
+	if model := resp.Model; model != nil && model.Properties != nil {
+		state, _ = flattenKubernetesFleetUpdateRunStatus(model.Properties.Status)
+	}
+	if state == "" || state == string(updateruns.UpdateStateNotStarted) {
+		if err := client.StartThenPoll(ctx, id, updateruns.DefaultStartOperationOptions()); err != nil {
+			return fmt.Errorf("starting %s: %+v", id, err)
+		}
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	var members []KubernetesFleetUpdateRunResourceMemberStatusSchema
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{
+			string(updateruns.UpdateStateNotStarted),
+			string(updateruns.UpdateStateRunning),
+			string(updateruns.UpdateStateStopping),
+		},
+		Target: []string{
+			string(updateruns.UpdateStateCompleted),
+			string(updateruns.UpdateStateFailed),
+			string(updateruns.UpdateStateSkipped),
+			string(updateruns.UpdateStateStopped),
+		},
+		Refresh: func() (interface{}, string, error) {
+			resp, err := client.Get(ctx, id)
+			if err != nil {
+				return nil, "", fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+			if resp.Model == nil || resp.Model.Properties == nil {
+				return nil, "", fmt.Errorf("retrieving %s: `properties` was nil", id)
+			}
+
+			var status string
+			status, members = flattenKubernetesFleetUpdateRunStatus(resp.Model.Properties.Status)
+			if status == "" {
+				status = string(updateruns.UpdateStateNotStarted)
+			}
+			return resp, status, nil
+		},
+		MinTimeout:   30 * time.Second,
+		PollInterval: 30 * time.Second,
+		Timeout:      time.Until(deadline),
+	}
+	result, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("waiting for %s to complete: %+v", id, err)
+	}
+
+	resp = result.(updateruns.GetOperationResponse)
+	if state, _ := flattenKubernetesFleetUpdateRunStatus(resp.Model.Properties.Status); state == string(updateruns.UpdateStateCompleted) {
+		return nil
+	}
+
+	return kubernetesFleetUpdateRunError(id, resp.Model.Properties.Status, members)
+}
+
+func kubernetesFleetUpdateRunError(id updateruns.UpdateRunId, status *updateruns.UpdateRunStatus, members []KubernetesFleetUpdateRunResourceMemberStatusSchema) error {
+	state, _ := flattenKubernetesFleetUpdateRunStatus(status)
+	message := fmt.Sprintf("%s finished with the status %q", id, state)
+	if status != nil && status.Status != nil && status.Status.Error != nil && status.Status.Error.Message != nil {
+		message += fmt.Sprintf(": %s", *status.Status.Error.Message)
+	}
+
+	failed := make([]string, 0)
+	for _, member := range members {
+		if member.Status == string(updateruns.UpdateStateCompleted) {
+			continue
+		}
+
+		detail := member.ErrorMessage
+		if detail == "" {
+			detail = member.Message
+		}
+		failed = append(failed, fmt.Sprintf("* Member %q (Stage %q / Group %q): %s %s", member.Name, member.StageName, member.GroupName, member.Status, detail))
+	}
+	if len(failed) > 0 {
+		message += fmt.Sprintf("\n\nThe following Members were not updated:\n\n%s", strings.Join(failed, "\n"))
+	}
+
+	return fmt.Errorf("%s", message)
+}
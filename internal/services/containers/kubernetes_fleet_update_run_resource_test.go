@@ -63,6 +63,24 @@ func TestAccKubernetesFleetUpdateRun_complete(t *testing.T) {
 	})
 }
 
+func TestAccKubernetesFleetUpdateRun_waitForCompletion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_update_run", "test")
+	r := KubernetesFleetUpdateRunTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.waitForCompletion(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("status").HasValue("Completed"),
+				check.That(data.ResourceName).Key("member_status.#").HasValue("1"),
+				check.That(data.ResourceName).Key("member_status.0.status").HasValue("Completed"),
+			),
+		},
+		data.ImportStep("wait_for_completion"),
+	})
+}
+
 func TestAccKubernetesFleetUpdateRun_update(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_kubernetes_fleet_update_run", "test")
 	r := KubernetesFleetUpdateRunTestResource{}
@@ -172,6 +190,30 @@ resource "azurerm_kubernetes_fleet_update_run" "test" {
 `, r.template(data), data.RandomInteger)
 }
 
+func (r KubernetesFleetUpdateRunTestResource) waitForCompletion(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_kubernetes_fleet_update_run" "test" {
+  name                        = "acctestfus-%[2]d"
+  kubernetes_fleet_manager_id = azurerm_kubernetes_fleet_manager.test.id
+  managed_cluster_update {
+    upgrade {
+      type = "NodeImageOnly"
+    }
+  }
+  fleet_update_strategy_id = azurerm_kubernetes_fleet_update_strategy.test.id
+  wait_for_completion      = true
+
+  timeouts {
+    create = "2h"
+  }
+
+  depends_on = [azurerm_kubernetes_fleet_member.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
 func (r KubernetesFleetUpdateRunTestResource) template(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachinescalesetvms"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/containerservice/2023-09-02-preview/agentpools"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+// kubernetesNodePoolUpgradeProgressInterval is how often the progress of a Node Pool upgrade is logged
+const kubernetesNodePoolUpgradeProgressInterval = time.Minute
+
+// kubernetesNodePoolNodeUpgraded is the status of a node which is running the latest model of the Node Pool
+const kubernetesNodePoolNodeUpgraded = "upgraded"
+
+// kubernetesNodePoolNameTag is the tag AKS assigns to the Virtual Machine Scale Set backing each Node Pool
+const kubernetesNodePoolNameTag = "aks-managed-poolName"
+
+// upgradeKubernetesNodePool creates/updates the Node Pool whilst its `orchestrator_version` is being upgraded, logging
+// the progress of each node until the upgrade completes. When the upgrade fails the status of each node is included
+// in the error - which, when the `pause_node_pool_upgrade_on_failure` feature is enabled, is a
+// kubernetesNodePoolUpgradePausedError so that it can be surfaced as a warning and the upgrade retried during the next apply.
+func upgradeKubernetesNodePool(ctx context.Context, meta interface{}, id agentpools.AgentPoolId, payload agentpools.AgentPool) error {
+	client := meta.(*clients.Client).Containers.AgentPoolsClient
+
+	targetVersion := ""
+	if props := payload.Properties; props != nil {
+		targetVersion = pointer.From(props.OrchestratorVersion)
+
+		settings := pointer.From(props.UpgradeSettings)
+		log.Printf("[INFO] Upgrading %s to Kubernetes version %q (max surge %q, drain timeout %d minutes, node soak duration %d minutes)..", id, targetVersion, pointer.From(settings.MaxSurge), pointer.From(settings.DrainTimeoutInMinutes), pointer.From(settings.NodeSoakDurationInMinutes))
+	}
+
+	err := func() error {
+		resp, err := client.CreateOrUpdate(ctx, id, payload)
+		if err != nil {
+			return err
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+		go logKubernetesNodePoolUpgradeProgress(ctx, meta, id, done)
+
+		return resp.Poller.PollUntilDone(ctx)
+	}()
+	if err == nil {
+		log.Printf("[INFO] Upgraded %s to Kubernetes version %q", id, targetVersion)
+		return nil
+	}
+
+	upgradeErr := fmt.Errorf("upgrading %s to Kubernetes version %q: %+v", id, targetVersion, err)
+	if nodes, nodesErr := kubernetesNodePoolNodeStatuses(ctx, meta, id); nodesErr == nil && len(nodes) > 0 {
+		statuses := make([]string, 0)
+		for name, status := range nodes {
+			statuses = append(statuses, fmt.Sprintf("* %s: %s", name, status))
+		}
+		sort.Strings(statuses)
+		upgradeErr = fmt.Errorf("%+v\n\nthe nodes within the Node Pool have the following status:\n\n%s", upgradeErr, strings.Join(statuses, "\n"))
+	}
+
+	if !meta.(*clients.Client).Features.KubernetesCluster.PauseNodePoolUpgradeOnFailure {
+		return upgradeErr
+	}
+
+	log.Printf("[WARN] Pausing the upgrade since the `pause_node_pool_upgrade_on_failure` feature is enabled, the upgrade will be retried during the next apply: %+v", upgradeErr)
+	return kubernetesNodePoolUpgradePausedError{
+		err: upgradeErr,
+	}
+}
+
+// kubernetesNodePoolUpgradePausedError is returned when the upgrade of a Node Pool has failed and been paused, since
+// the `pause_node_pool_upgrade_on_failure` feature is enabled
+type kubernetesNodePoolUpgradePausedError struct {
+	err error
+}
+
+func (e kubernetesNodePoolUpgradePausedError) Error() string {
+	return e.err.Error()
+}
+
+func (e kubernetesNodePoolUpgradePausedError) Unwrap() error {
+	return e.err
+}
+
+func kubernetesNodePoolUpgradePaused(err error) bool {
+	return errors.As(err, &kubernetesNodePoolUpgradePausedError{})
+}
+
+// kubernetesNodePoolUpgradeWarnings wraps the Update function of a Resource which upgrades Node Pools, so that when
+// the upgrade of a Node Pool has been paused this is returned as a warning rather than an error
+func kubernetesNodePoolUpgradeWarnings(update func(*pluginsdk.ResourceData, interface{}) error) func(context.Context, *pluginsdk.ResourceData, interface{}) diag.Diagnostics {
+	return func(_ context.Context, d *pluginsdk.ResourceData, meta interface{}) diag.Diagnostics {
+		err := update(d, meta)
+		if err == nil {
+			return nil
+		}
+
+		if kubernetesNodePoolUpgradePaused(err) {
+			return diag.Diagnostics{
+				{
+					Severity: diag.Warning,
+					Summary:  "The upgrade of the Node Pool has been paused",
+					Detail:   fmt.Sprintf("%+v\n\nThe upgrade will be retried during the next apply since the `pause_node_pool_upgrade_on_failure` feature is enabled.", err),
+				},
+			}
+		}
+
+		return diag.FromErr(err)
+	}
+}
+
+// logKubernetesNodePoolUpgradeProgress logs the status of each node within the Node Pool when it changes, until done is closed
+func logKubernetesNodePoolUpgradeProgress(ctx context.Context, meta interface{}, id agentpools.AgentPoolId, done chan struct{}) {
+	ticker := time.NewTicker(kubernetesNodePoolUpgradeProgressInterval)
+	defer ticker.Stop()
+
+	previous := make(map[string]string)
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		nodes, err := kubernetesNodePoolNodeStatuses(ctx, meta, id)
+		if err != nil {
+			// the credentials used by Terraform may not have access to the Node Resource Group, in which case only the overall status is available
+			log.Printf("[DEBUG] Unable to retrieve the status of the nodes within %s: %+v", id, err)
+			continue
+		}
+
+		upgraded := 0
+		for name, status := range nodes {
+			if status == kubernetesNodePoolNodeUpgraded {
+				upgraded++
+			}
+			if previous[name] != status {
+				log.Printf("[INFO] Upgrading %s: node %q is %s", id, name, status)
+			}
+		}
+		for name := range previous {
+			if _, ok := nodes[name]; !ok {
+				log.Printf("[INFO] Upgrading %s: node %q has been removed", id, name)
+			}
+		}
+		log.Printf("[INFO] Upgrading %s: %d of %d nodes upgraded", id, upgraded, len(nodes))
+
+		previous = nodes
+	}
+}
+
+// kubernetesNodePoolNodeStatuses returns the upgrade status of each node within the Node Pool, keyed by the node name
+func kubernetesNodePoolNodeStatuses(ctx context.Context, meta interface{}, id agentpools.AgentPoolId) (map[string]string, error) {
+	clusterClient := meta.(*clients.Client).Containers.KubernetesClustersClient
+	scaleSetsClient := meta.(*clients.Client).Compute.VirtualMachineScaleSetsClient
+	scaleSetVMsClient := meta.(*clients.Client).Compute.VirtualMachineScaleSetVMsClient
+
+	clusterId := commonids.NewKubernetesClusterID(id.SubscriptionId, id.ResourceGroupName, id.ManagedClusterName)
+	cluster, err := clusterClient.Get(ctx, clusterId)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", clusterId, err)
+	}
+	if cluster.Model == nil || cluster.Model.Properties == nil || cluster.Model.Properties.NodeResourceGroup == nil {
+		return nil, fmt.Errorf("retrieving %s: `properties.nodeResourceGroup` was nil", clusterId)
+	}
+
+	nodeResourceGroupId := commonids.NewResourceGroupID(id.SubscriptionId, *cluster.Model.Properties.NodeResourceGroup)
+	scaleSets, err := scaleSetsClient.ListComplete(ctx, nodeResourceGroupId)
+	if err != nil {
+		return nil, fmt.Errorf("listing the Virtual Machine Scale Sets within %s: %+v", nodeResourceGroupId, err)
+	}
+
+	nodes := make(map[string]string)
+	for _, scaleSet := range scaleSets.Items {
+		if scaleSet.Name == nil || !strings.EqualFold(pointer.From(scaleSet.Tags)[kubernetesNodePoolNameTag], id.AgentPoolName) {
+			continue
+		}
+
+		scaleSetId := virtualmachinescalesetvms.NewVirtualMachineScaleSetID(nodeResourceGroupId.SubscriptionId, nodeResourceGroupId.ResourceGroupName, *scaleSet.Name)
+		instances, err := scaleSetVMsClient.ListComplete(ctx, scaleSetId, virtualmachinescalesetvms.DefaultListOperationOptions())
+		if err != nil {
+			return nil, fmt.Errorf("listing the instances within %s: %+v", scaleSetId, err)
+		}
+
+		for _, instance := range instances.Items {
+			props := instance.Properties
+			if props == nil {
+				continue
+			}
+
+			name := pointer.From(instance.Name)
+			if props.OsProfile != nil && props.OsProfile.ComputerName != nil {
+				name = *props.OsProfile.ComputerName
+			}
+			nodes[name] = kubernetesNodePoolNodeStatus(pointer.From(props.ProvisioningState), pointer.From(props.LatestModelApplied))
+		}
+	}
+
+	return nodes, nil
+}
+
+func kubernetesNodePoolNodeStatus(provisioningState string, latestModelApplied bool) string {
+	if !strings.EqualFold(provisioningState, "Succeeded") {
+		return strings.ToLower(provisioningState)
+	}
+	if !latestModelApplied {
+		return "waiting to be upgraded"
+	}
+	return kubernetesNodePoolNodeUpgraded
+}
+
+// kubernetesNodePoolUpgradeFailed returns whether an upgrade of the Node Pool failed part way through, in which case
+// the version the Node Pool is running is used for the `orchestrator_version` so that the upgrade is retried
+func kubernetesNodePoolUpgradeFailed(provisioningState, orchestratorVersion, currentOrchestratorVersion *string) bool {
+	if provisioningState == nil || !strings.EqualFold(*provisioningState, "Failed") {
+		return false
+	}
+
+	return currentOrchestratorVersion != nil && pointer.From(orchestratorVersion) != *currentOrchestratorVersion
+}
@@ -1182,6 +1182,10 @@ func ConvertDefaultNodePoolToAgentPool(input *[]managedclusters.ManagedClusterAg
 	if upgradeSettingsNodePool := defaultCluster.UpgradeSettings; upgradeSettingsNodePool != nil && upgradeSettingsNodePool.MaxSurge != nil && *upgradeSettingsNodePool.MaxSurge != "" {
 		agentpool.Properties.UpgradeSettings.MaxSurge = upgradeSettingsNodePool.MaxSurge
 	}
+	if upgradeSettingsNodePool := defaultCluster.UpgradeSettings; upgradeSettingsNodePool != nil {
+		agentpool.Properties.UpgradeSettings.DrainTimeoutInMinutes = upgradeSettingsNodePool.DrainTimeoutInMinutes
+		agentpool.Properties.UpgradeSettings.NodeSoakDurationInMinutes = upgradeSettingsNodePool.NodeSoakDurationInMinutes
+	}
 	if workloadRuntimeNodePool := defaultCluster.WorkloadRuntime; workloadRuntimeNodePool != nil {
 		agentpool.Properties.WorkloadRuntime = pointer.To(agentpools.WorkloadRuntime(string(*workloadRuntimeNodePool)))
 	}
@@ -1710,7 +1714,7 @@ func FlattenDefaultNodePool(input *[]managedclusters.ManagedClusterAgentPoolProf
 
 	orchestratorVersion := ""
 	// NOTE: workaround for migration from 2022-01-02-preview (<3.12.0) to 2022-03-02-preview (>=3.12.0). Before terraform apply is run against the new API, Azure will respond only with currentOrchestratorVersion, orchestratorVersion will be absent. More details: https://github.com/hashicorp/terraform-provider-azurerm/issues/17833#issuecomment-1227583353
-	if agentPool.OrchestratorVersion != nil {
+	if agentPool.OrchestratorVersion != nil && !kubernetesNodePoolUpgradeFailed(agentPool.ProvisioningState, agentPool.OrchestratorVersion, agentPool.CurrentOrchestratorVersion) {
 		orchestratorVersion = *agentPool.OrchestratorVersion
 	} else if agentPool.CurrentOrchestratorVersion != nil {
 		orchestratorVersion = *agentPool.CurrentOrchestratorVersion
@@ -1840,7 +1844,9 @@ func flattenClusterNodePoolUpgradeSettings(input *managedclusters.AgentPoolUpgra
 
 	return []interface{}{
 		map[string]interface{}{
-			"max_surge": maxSurge,
+			"max_surge":                     maxSurge,
+			"drain_timeout_in_minutes":      int(pointer.From(input.DrainTimeoutInMinutes)),
+			"node_soak_duration_in_minutes": int(pointer.From(input.NodeSoakDurationInMinutes)),
 		},
 	}
 }
@@ -2206,6 +2212,11 @@ func expandClusterNodePoolUpgradeSettings(input []interface{}) *managedclusters.
 	if maxSurgeRaw := v["max_surge"].(string); maxSurgeRaw != "" {
 		setting.MaxSurge = utils.String(maxSurgeRaw)
 	}
+	if drainTimeoutRaw := v["drain_timeout_in_minutes"].(int); drainTimeoutRaw != 0 {
+		setting.DrainTimeoutInMinutes = pointer.To(int64(drainTimeoutRaw))
+	}
+	// a node soak duration of `0` is valid, so this is always sent when the block is specified
+	setting.NodeSoakDurationInMinutes = pointer.To(int64(v["node_soak_duration_in_minutes"].(int)))
 	return setting
 }
 
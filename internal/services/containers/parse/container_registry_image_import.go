@@ -0,0 +1,84 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package parse
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/resourceids"
+)
+
+// ContainerRegistryImageImportId identifies an image which has been imported into a Container Registry, since the
+// repository of the image can contain `/` characters the image is the remainder of the ID after `importedImages`
+type ContainerRegistryImageImportId struct {
+	SubscriptionId string
+	ResourceGroup  string
+	RegistryName   string
+	ImageName      string
+}
+
+func NewContainerRegistryImageImportID(subscriptionId, resourceGroup, registryName, imageName string) ContainerRegistryImageImportId {
+	return ContainerRegistryImageImportId{
+		SubscriptionId: subscriptionId,
+		ResourceGroup:  resourceGroup,
+		RegistryName:   registryName,
+		ImageName:      imageName,
+	}
+}
+
+func (id ContainerRegistryImageImportId) String() string {
+	segments := []string{
+		fmt.Sprintf("Image Name %q", id.ImageName),
+		fmt.Sprintf("Registry Name %q", id.RegistryName),
+		fmt.Sprintf("Resource Group %q", id.ResourceGroup),
+	}
+	segmentsStr := strings.Join(segments, " / ")
+	return fmt.Sprintf("%s: (%s)", "Container Registry Image Import", segmentsStr)
+}
+
+func (id ContainerRegistryImageImportId) ID() string {
+	fmtString := "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerRegistry/registries/%s/importedImages/%s"
+	return fmt.Sprintf(fmtString, id.SubscriptionId, id.ResourceGroup, id.RegistryName, id.ImageName)
+}
+
+// ContainerRegistryImageImportID parses a ContainerRegistryImageImport ID into an ContainerRegistryImageImportId struct
+func ContainerRegistryImageImportID(input string) (*ContainerRegistryImageImportId, error) {
+	registryIdRaw, imageName, ok := strings.Cut(input, "/importedImages/")
+	if !ok {
+		return nil, fmt.Errorf("parsing %q as an ContainerRegistryImageImport ID: ID was missing the 'importedImages' element", input)
+	}
+	if imageName == "" || strings.HasPrefix(imageName, "/") || strings.HasSuffix(imageName, "/") || strings.Contains(imageName, "//") {
+		return nil, fmt.Errorf("parsing %q as an ContainerRegistryImageImport ID: ID contained an invalid value for the 'importedImages' element", input)
+	}
+
+	id, err := resourceids.ParseAzureResourceID(registryIdRaw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %q as an ContainerRegistryImageImport ID: %+v", input, err)
+	}
+
+	resourceId := ContainerRegistryImageImportId{
+		SubscriptionId: id.SubscriptionID,
+		ResourceGroup:  id.ResourceGroup,
+		ImageName:      imageName,
+	}
+
+	if resourceId.SubscriptionId == "" {
+		return nil, fmt.Errorf("ID was missing the 'subscriptions' element")
+	}
+
+	if resourceId.ResourceGroup == "" {
+		return nil, fmt.Errorf("ID was missing the 'resourceGroups' element")
+	}
+
+	if resourceId.RegistryName, err = id.PopSegment("registries"); err != nil {
+		return nil, err
+	}
+
+	if err := id.ValidateNoEmptySegments(registryIdRaw); err != nil {
+		return nil, err
+	}
+
+	return &resourceId, nil
+}
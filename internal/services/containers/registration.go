@@ -60,6 +60,7 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 func (r Registration) DataSources() []sdk.DataSource {
 	dataSources := []sdk.DataSource{
+		KubernetesClusterKubeConfigExecDataSource{},
 		KubernetesNodePoolSnapshotDataSource{},
 	}
 	dataSources = append(dataSources, r.autoRegistration.DataSources()...)
@@ -68,11 +69,16 @@ func (r Registration) DataSources() []sdk.DataSource {
 
 func (r Registration) Resources() []sdk.Resource {
 	resources := []sdk.Resource{
+		ContainerRegistryCacheRuleResource{},
+		ContainerRegistryCredentialSetResource{},
+		ContainerRegistryImageImportResource{},
 		ContainerRegistryTaskResource{},
 		ContainerRegistryTaskScheduleResource{},
 		ContainerRegistryTokenPasswordResource{},
 		ContainerConnectedRegistryResource{},
 		KubernetesClusterExtensionResource{},
+		KubernetesClusterMaintenanceConfigurationResource{},
+		KubernetesClusterTrustedAccessRoleBindingResource{},
 		KubernetesFluxConfigurationResource{},
 		KubernetesFleetManagerResource{},
 		KubernetesFleetUpdateRunResource{},
@@ -19,7 +19,6 @@ func (autoRegistration) DataSources() []sdk.DataSource {
 
 func (autoRegistration) Resources() []sdk.Resource {
 	return []sdk.Resource{
-		KubernetesClusterTrustedAccessRoleBindingResource{},
 		KubernetesFleetMemberResource{},
 	}
 }
@@ -0,0 +1,350 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-azure-sdk/sdk/auth"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+const (
+	dockerHubLoginServer  = "docker.io"
+	dockerHubIndexServer  = "index.docker.io"
+	dockerHubRegistryHost = "registry-1.docker.io"
+
+	// refreshTokenUsername is the username used to authenticate against an Azure Container Registry using a refresh token
+	refreshTokenUsername = "00000000-0000-0000-0000-000000000000"
+)
+
+// manifestMediaTypes are the media types accepted when retrieving a manifest, the index types are preferred so that the
+// digest of a multi-architecture image matches the digest of the image index rather than that of a single platform
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+var challengeParameterRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// Credentials are used to authenticate against a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client retrieves information about images from a registry implementing the OCI Distribution Specification
+// (such as Docker Hub or an Azure Container Registry), authenticating using the token flow when challenged
+type Client struct {
+	// HTTPClient is the client used to send requests to the registry
+	HTTPClient *http.Client
+
+	authorizer auth.Authorizer
+	tenantId   string
+}
+
+// NewClient returns a Client which sends requests using the specified HTTP Client, and uses the specified Authorizer
+// to authenticate against Azure Container Registries
+func NewClient(httpClient *http.Client, authorizer auth.Authorizer, tenantId string) *Client {
+	return &Client{
+		HTTPClient: httpClient,
+		authorizer: authorizer,
+		tenantId:   tenantId,
+	}
+}
+
+// AzureContainerRegistryCredentials exchanges an Entra ID access token for a refresh token, which can be used as the
+// password to authenticate against the Azure Container Registry with the specified login server
+func (c *Client) AzureContainerRegistryCredentials(ctx context.Context, loginServer string) (*Credentials, error) {
+	if c.authorizer == nil {
+		return nil, fmt.Errorf("an authorizer is required to authenticate against %q", loginServer)
+	}
+
+	endpoint := fmt.Sprintf("https://%s/oauth2/exchange", loginServer)
+	tokenRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	token, err := c.authorizer.Token(ctx, tokenRequest)
+	if err != nil {
+		return nil, fmt.Errorf("obtaining access token: %+v", err)
+	}
+
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {loginServer},
+		"tenant":       {c.tenantId},
+		"access_token": {token.AccessToken},
+	}
+	req, err := http.NewRequestWithContext(common.WithCredentialRequest(ctx), http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("exchanging access token for a refresh token: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("exchanging access token for a refresh token: unexpected status %d: %s", resp.StatusCode, readErrorBody(resp))
+	}
+
+	var result struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decoding refresh token: %+v", err)
+	}
+	if result.RefreshToken == "" {
+		return nil, fmt.Errorf("exchanging access token for a refresh token: `refresh_token` was empty")
+	}
+
+	return &Credentials{
+		Username: refreshTokenUsername,
+		Password: result.RefreshToken,
+	}, nil
+}
+
+// ManifestDigest returns the digest of the manifest for the specified image within the registry with the specified
+// login server, or nil if the image doesn't exist
+func (c *Client) ManifestDigest(ctx context.Context, loginServer string, image ImageReference, credentials *Credentials) (*string, error) {
+	host, repository := registryHostAndRepository(loginServer, image.Repository)
+	endpoint := fmt.Sprintf("https://%s/v2/%s/manifests/%s", host, repository, image.Reference())
+
+	resp, err := c.manifestRequest(ctx, http.MethodHead, endpoint, "")
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	authorization := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		authorization, err = c.authorize(ctx, resp.Header.Get("WWW-Authenticate"), credentials)
+		if err != nil {
+			return nil, fmt.Errorf("authenticating against %q: %+v", host, err)
+		}
+
+		resp, err = c.manifestRequest(ctx, http.MethodHead, endpoint, authorization)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+			return &digest, nil
+		}
+
+		// the header is optional, in which case the digest is calculated from the manifest itself
+		log.Printf("[DEBUG] %q didn't return a digest for %q, retrieving the manifest to calculate it", host, image.String())
+		return c.calculateManifestDigest(ctx, endpoint, authorization)
+
+	case http.StatusNotFound:
+		return nil, nil
+
+	default:
+		return nil, fmt.Errorf("retrieving manifest for %q from %q: unexpected status %d", image.String(), host, resp.StatusCode)
+	}
+}
+
+// DeleteTag removes the tag for the specified image from the Azure Container Registry with the specified login server,
+// the manifest referenced by the tag isn't deleted - and no error is returned when the tag doesn't exist
+func (c *Client) DeleteTag(ctx context.Context, loginServer string, image ImageReference, credentials *Credentials) error {
+	if image.IsDigest() {
+		return fmt.Errorf("%q doesn't reference a tag", image.String())
+	}
+
+	host, repository := registryHostAndRepository(loginServer, image.Repository)
+	endpoint := fmt.Sprintf("https://%s/acr/v1/%s/_tags/%s", host, repository, image.Tag)
+
+	send := func(authorization string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodDelete, endpoint, http.NoBody)
+		if err != nil {
+			return nil, fmt.Errorf("building request: %+v", err)
+		}
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("sending request to %q: %+v", endpoint, err)
+		}
+		return resp, nil
+	}
+
+	resp, err := send("")
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		resp.Body.Close()
+
+		authorization, err := c.authorize(ctx, resp.Header.Get("WWW-Authenticate"), credentials)
+		if err != nil {
+			return fmt.Errorf("authenticating against %q: %+v", host, err)
+		}
+
+		if resp, err = send(authorization); err != nil {
+			return err
+		}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("deleting tag %q from %q: unexpected status %d: %s", image.String(), host, resp.StatusCode, readErrorBody(resp))
+	}
+}
+
+func (c *Client) calculateManifestDigest(ctx context.Context, endpoint, authorization string) (*string, error) {
+	resp, err := c.manifestRequest(ctx, http.MethodGet, endpoint, authorization)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("retrieving manifest: unexpected status %d: %s", resp.StatusCode, readErrorBody(resp))
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, resp.Body); err != nil {
+		return nil, fmt.Errorf("reading manifest: %+v", err)
+	}
+
+	digest := fmt.Sprintf("sha256:%s", hex.EncodeToString(hash.Sum(nil)))
+	return &digest, nil
+}
+
+func (c *Client) manifestRequest(ctx context.Context, method, endpoint, authorization string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("building request: %+v", err)
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("sending request to %q: %+v", endpoint, err)
+	}
+
+	return resp, nil
+}
+
+// authorize returns the value of the Authorization header to use in response to the specified challenge, obtaining
+// a Bearer token from the token endpoint specified in the challenge where required
+func (c *Client) authorize(ctx context.Context, challenge string, credentials *Credentials) (string, error) {
+	scheme, parameters := parseChallenge(challenge)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if credentials == nil {
+			return "", fmt.Errorf("the registry requires credentials but none were specified")
+		}
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(credentials.Username, credentials.Password)
+		return req.Header.Get("Authorization"), nil
+
+	case "bearer":
+		realm := parameters["realm"]
+		if realm == "" {
+			return "", fmt.Errorf("the challenge %q didn't contain a `realm`", challenge)
+		}
+		tokenUrl, err := url.Parse(realm)
+		if err != nil {
+			return "", fmt.Errorf("parsing realm %q: %+v", realm, err)
+		}
+		query := tokenUrl.Query()
+		for _, key := range []string{"service", "scope"} {
+			if v := parameters[key]; v != "" {
+				query.Set(key, v)
+			}
+		}
+		tokenUrl.RawQuery = query.Encode()
+
+		// the token endpoint is defined by the registry (e.g. `https://auth.docker.io/token`) so the request is marked
+		// explicitly, to ensure that the token isn't logged
+		req, err := http.NewRequestWithContext(common.WithCredentialRequest(ctx), http.MethodGet, tokenUrl.String(), http.NoBody)
+		if err != nil {
+			return "", fmt.Errorf("building request: %+v", err)
+		}
+		if credentials != nil {
+			req.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("obtaining token: %+v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("obtaining token: unexpected status %d: %s", resp.StatusCode, readErrorBody(resp))
+		}
+
+		var result struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return "", fmt.Errorf("decoding token: %+v", err)
+		}
+
+		token := result.Token
+		if token == "" {
+			token = result.AccessToken
+		}
+		if token == "" {
+			return "", fmt.Errorf("obtaining token: the token was empty")
+		}
+
+		return fmt.Sprintf("Bearer %s", token), nil
+	}
+
+	return "", fmt.Errorf("unsupported authentication challenge %q", challenge)
+}
+
+// parseChallenge parses a `WWW-Authenticate` header, for example `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`
+func parseChallenge(input string) (string, map[string]string) {
+	scheme, remaining, _ := strings.Cut(strings.TrimSpace(input), " ")
+
+	parameters := make(map[string]string)
+	for _, match := range challengeParameterRegex.FindAllStringSubmatch(remaining, -1) {
+		parameters[strings.ToLower(match[1])] = match[2]
+	}
+
+	return scheme, parameters
+}
+
+func readErrorBody(resp *http.Response) string {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return fmt.Sprintf("reading response body: %+v", err)
+	}
+	return string(body)
+}
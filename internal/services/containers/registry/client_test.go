@@ -0,0 +1,299 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+const testManifest = `{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[]}`
+
+// testRegistry emulates a registry which requires a Bearer token for the `team/app` repository
+type testRegistry struct {
+	server *httptest.Server
+
+	username string
+	password string
+	digests  map[string]string
+
+	// omitDigestHeader emulates registries which don't return the `Docker-Content-Digest` header
+	omitDigestHeader bool
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	r := &testRegistry{
+		username: "user",
+		password: "pass",
+		digests: map[string]string{
+			"team/app:v1":  "sha256:1111",
+			"team/app:v2":  "sha256:2222",
+			"team/app@abc": "sha256:abc",
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", r.token)
+	mux.HandleFunc("/oauth2/exchange", r.exchange)
+	mux.HandleFunc("/v2/", r.manifest)
+	mux.HandleFunc("/acr/v1/", r.deleteTag)
+	r.server = httptest.NewTLSServer(mux)
+	t.Cleanup(r.server.Close)
+
+	return r
+}
+
+func (r *testRegistry) loginServer() string {
+	return strings.TrimPrefix(r.server.URL, "https://")
+}
+
+func (r *testRegistry) client() *Client {
+	client := NewClient(r.server.Client(), testAuthorizer{}, "tenant1")
+	return client
+}
+
+func (r *testRegistry) token(w http.ResponseWriter, req *http.Request) {
+	username, password, ok := req.BasicAuth()
+	if !ok || username != r.username || password != r.password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if scope := req.URL.Query().Get("scope"); (scope != "repository:team/app:pull" && scope != "repository:team/app:delete") || req.URL.Query().Get("service") != "test" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	fmt.Fprint(w, `{"access_token": "bearer-token"}`)
+}
+
+func (r *testRegistry) exchange(w http.ResponseWriter, req *http.Request) {
+	if err := req.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if req.PostForm.Get("grant_type") != "access_token" || req.PostForm.Get("access_token") != "entra-token" || req.PostForm.Get("tenant") != "tenant1" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	fmt.Fprint(w, `{"refresh_token": "refresh-token"}`)
+}
+
+func (r *testRegistry) manifest(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer bearer-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:team/app:pull"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.Contains(req.Header.Get("Accept"), "application/vnd.oci.image.index.v1+json") {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	repository, reference, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/v2/"), "/manifests/")
+	separator := ":"
+	if strings.HasPrefix(reference, "sha256:") {
+		separator = "@"
+		reference = strings.TrimPrefix(reference, "sha256:")
+	}
+	digest, ok := r.digests[repository+separator+reference]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if !r.omitDigestHeader {
+		w.Header().Set("Docker-Content-Digest", digest)
+	}
+	if req.Method == http.MethodGet {
+		fmt.Fprint(w, testManifest)
+	}
+}
+
+func (r *testRegistry) deleteTag(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get("Authorization") != "Bearer bearer-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:team/app:delete"`, r.server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if req.Method != http.MethodDelete {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	repository, tag, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, "/acr/v1/"), "/_tags/")
+	if _, ok := r.digests[repository+":"+tag]; !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	delete(r.digests, repository+":"+tag)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+type testAuthorizer struct{}
+
+func (testAuthorizer) Token(_ context.Context, _ *http.Request) (*oauth2.Token, error) {
+	return &oauth2.Token{AccessToken: "entra-token"}, nil
+}
+
+func (testAuthorizer) AuxiliaryTokens(_ context.Context, _ *http.Request) ([]*oauth2.Token, error) {
+	return nil, nil
+}
+
+func TestManifestDigest(t *testing.T) {
+	registry := newTestRegistry(t)
+	client := registry.client()
+	credentials := &Credentials{Username: "user", Password: "pass"}
+
+	cases := []struct {
+		Image    string
+		Expected *string
+	}{
+		{
+			Image:    "team/app:v1",
+			Expected: pointerTo("sha256:1111"),
+		},
+		{
+			Image:    "team/app:v2",
+			Expected: pointerTo("sha256:2222"),
+		},
+		{
+			Image:    "team/app@sha256:abc",
+			Expected: pointerTo("sha256:abc"),
+		},
+		{
+			// not found
+			Image:    "team/app:v3",
+			Expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Image)
+
+		image, err := ParseImageReference(tc.Image)
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", tc.Image, err)
+		}
+
+		actual, err := client.ManifestDigest(context.TODO(), registry.loginServer(), *image, credentials)
+		if err != nil {
+			t.Fatalf("retrieving digest for %q: %+v", tc.Image, err)
+		}
+
+		if (actual == nil) != (tc.Expected == nil) || (actual != nil && *actual != *tc.Expected) {
+			t.Fatalf("expected %v but got %v for %q", valueOf(tc.Expected), valueOf(actual), tc.Image)
+		}
+	}
+}
+
+func TestManifestDigest_invalidCredentials(t *testing.T) {
+	registry := newTestRegistry(t)
+	client := registry.client()
+
+	image, _ := ParseImageReference("team/app:v1")
+	if _, err := client.ManifestDigest(context.TODO(), registry.loginServer(), *image, &Credentials{Username: "user", Password: "wrong"}); err == nil {
+		t.Fatalf("expected an error when authenticating with invalid credentials")
+	}
+}
+
+func TestManifestDigest_calculatedWhenHeaderMissing(t *testing.T) {
+	registry := newTestRegistry(t)
+	registry.omitDigestHeader = true
+	client := registry.client()
+
+	image, _ := ParseImageReference("team/app:v1")
+	actual, err := client.ManifestDigest(context.TODO(), registry.loginServer(), *image, &Credentials{Username: "user", Password: "pass"})
+	if err != nil {
+		t.Fatalf("retrieving digest: %+v", err)
+	}
+
+	hash := sha256.Sum256([]byte(testManifest))
+	expected := fmt.Sprintf("sha256:%s", hex.EncodeToString(hash[:]))
+	if actual == nil || *actual != expected {
+		t.Fatalf("expected %q but got %v", expected, valueOf(actual))
+	}
+}
+
+func TestDeleteTag(t *testing.T) {
+	registry := newTestRegistry(t)
+	client := registry.client()
+	credentials := &Credentials{Username: "user", Password: "pass"}
+
+	image, _ := ParseImageReference("team/app:v1")
+	if err := client.DeleteTag(context.TODO(), registry.loginServer(), *image, credentials); err != nil {
+		t.Fatalf("deleting tag: %+v", err)
+	}
+	if _, ok := registry.digests["team/app:v1"]; ok {
+		t.Fatalf("expected the tag %q to be deleted", "team/app:v1")
+	}
+	if _, ok := registry.digests["team/app:v2"]; !ok {
+		t.Fatalf("expected the tag %q to be retained", "team/app:v2")
+	}
+
+	// deleting a tag which doesn't exist isn't an error
+	if err := client.DeleteTag(context.TODO(), registry.loginServer(), *image, credentials); err != nil {
+		t.Fatalf("deleting missing tag: %+v", err)
+	}
+
+	digest, _ := ParseImageReference("team/app@sha256:abc")
+	if err := client.DeleteTag(context.TODO(), registry.loginServer(), *digest, credentials); err == nil {
+		t.Fatalf("expected an error when deleting an image referenced by digest")
+	}
+}
+
+func TestAzureContainerRegistryCredentials(t *testing.T) {
+	registry := newTestRegistry(t)
+	client := registry.client()
+
+	credentials, err := client.AzureContainerRegistryCredentials(context.TODO(), registry.loginServer())
+	if err != nil {
+		t.Fatalf("exchanging token: %+v", err)
+	}
+
+	if credentials.Username != refreshTokenUsername {
+		t.Fatalf("expected the username to be %q but got %q", refreshTokenUsername, credentials.Username)
+	}
+	if credentials.Password != "refresh-token" {
+		t.Fatalf("expected the password to be %q but got %q", "refresh-token", credentials.Password)
+	}
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, parameters := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/ubuntu:pull"`)
+	if scheme != "Bearer" {
+		t.Fatalf("expected the scheme to be %q but got %q", "Bearer", scheme)
+	}
+
+	expected := map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/ubuntu:pull",
+	}
+	for k, v := range expected {
+		if parameters[k] != v {
+			t.Fatalf("expected %q to be %q but got %q", k, v, parameters[k])
+		}
+	}
+}
+
+func pointerTo(input string) *string {
+	return &input
+}
+
+func valueOf(input *string) string {
+	if input == nil {
+		return "<nil>"
+	}
+	return *input
+}
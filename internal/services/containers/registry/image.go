@@ -0,0 +1,144 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const defaultTag = "latest"
+
+var (
+	repositoryRegex  = regexp.MustCompile(`^[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*$`)
+	tagRegex         = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	digestRegex      = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-zA-Z0-9=_-]+$`)
+	loginServerRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?)*(:[0-9]{1,5})?$`)
+)
+
+// ImageReference is a reference to an image within a registry, either by Tag or by Digest
+type ImageReference struct {
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference parses an image in the format `{repository}:{tag}` or `{repository}@{digest}`, where
+// the Tag defaults to `latest` when neither a Tag nor a Digest is specified
+func ParseImageReference(input string) (*ImageReference, error) {
+	image := ImageReference{}
+
+	remaining := input
+	if i := strings.Index(remaining, "@"); i != -1 {
+		image.Digest = remaining[i+1:]
+		remaining = remaining[:i]
+		if !digestRegex.MatchString(image.Digest) {
+			return nil, fmt.Errorf("parsing %q: the digest %q is not valid", input, image.Digest)
+		}
+	}
+
+	// the Tag follows the last `:` which isn't part of the repository path
+	if i := strings.LastIndex(remaining, ":"); i != -1 && !strings.Contains(remaining[i:], "/") {
+		image.Tag = remaining[i+1:]
+		remaining = remaining[:i]
+		if !tagRegex.MatchString(image.Tag) {
+			return nil, fmt.Errorf("parsing %q: the tag %q is not valid", input, image.Tag)
+		}
+	}
+
+	image.Repository = remaining
+	if !repositoryRegex.MatchString(image.Repository) {
+		return nil, fmt.Errorf("parsing %q: the repository %q must be a lowercase repository path", input, image.Repository)
+	}
+
+	if image.Tag == "" && image.Digest == "" {
+		image.Tag = defaultTag
+	}
+
+	return &image, nil
+}
+
+// IsDigest returns whether this image is referenced by Digest, and as such can't change
+func (i ImageReference) IsDigest() bool {
+	return i.Digest != ""
+}
+
+// Reference returns the Digest of the image when specified, otherwise the Tag
+func (i ImageReference) Reference() string {
+	if i.Digest != "" {
+		return i.Digest
+	}
+	return i.Tag
+}
+
+func (i ImageReference) String() string {
+	if i.Digest != "" {
+		return fmt.Sprintf("%s@%s", i.Repository, i.Digest)
+	}
+	return fmt.Sprintf("%s:%s", i.Repository, i.Tag)
+}
+
+// ValidateImageReference validates that the specified value is an image in the format `{repository}:{tag}` or `{repository}@{digest}`
+func ValidateImageReference(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	if _, err := ParseImageReference(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an image in the format `{repository}:{tag}` or `{repository}@{digest}`: %+v", k, err))
+	}
+
+	return
+}
+
+// ValidateTaggedImageReference validates that the specified value is an image in the format `{repository}:{tag}`
+func ValidateTaggedImageReference(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	image, err := ParseImageReference(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an image in the format `{repository}:{tag}`: %+v", k, err))
+		return
+	}
+	if image.IsDigest() {
+		errors = append(errors, fmt.Errorf("%q must be an image in the format `{repository}:{tag}`, got %q", k, value))
+	}
+
+	return
+}
+
+// ValidateLoginServer validates that the specified value is the login server of a registry (e.g. `docker.io`), without a scheme or path
+func ValidateLoginServer(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	if !loginServerRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be the login server of a registry (e.g. `docker.io`) without a scheme or path, got %q", k, value))
+	}
+
+	return
+}
+
+// registryHostAndRepository returns the host which serves the registry API for the specified login server, and the
+// repository within that registry - since Docker Hub uses a different host and implicitly prefixes official images with `library/`
+func registryHostAndRepository(loginServer, repository string) (string, string) {
+	if strings.EqualFold(loginServer, dockerHubLoginServer) || strings.EqualFold(loginServer, dockerHubIndexServer) {
+		if !strings.Contains(repository, "/") {
+			repository = fmt.Sprintf("library/%s", repository)
+		}
+		return dockerHubRegistryHost, repository
+	}
+
+	return strings.ToLower(loginServer), repository
+}
@@ -0,0 +1,148 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package registry
+
+import "testing"
+
+func TestParseImageReference(t *testing.T) {
+	cases := []struct {
+		Input    string
+		Expected *ImageReference
+	}{
+		{
+			Input:    "",
+			Expected: nil,
+		},
+		{
+			Input: "hello-world",
+			Expected: &ImageReference{
+				Repository: "hello-world",
+				Tag:        "latest",
+			},
+		},
+		{
+			Input: "library/hello-world:v1.2",
+			Expected: &ImageReference{
+				Repository: "library/hello-world",
+				Tag:        "v1.2",
+			},
+		},
+		{
+			Input: "team/app@sha256:0123456789abcdef",
+			Expected: &ImageReference{
+				Repository: "team/app",
+				Digest:     "sha256:0123456789abcdef",
+			},
+		},
+		{
+			Input: "team/app:v1@sha256:0123456789abcdef",
+			Expected: &ImageReference{
+				Repository: "team/app",
+				Tag:        "v1",
+				Digest:     "sha256:0123456789abcdef",
+			},
+		},
+		{
+			// uppercase characters aren't allowed in repository names
+			Input:    "Team/app:v1",
+			Expected: nil,
+		},
+		{
+			Input:    "team/app:",
+			Expected: nil,
+		},
+		{
+			Input:    "team/app@latest",
+			Expected: nil,
+		},
+		{
+			// registries should be specified separately
+			Input:    "docker.io:443/library/hello-world",
+			Expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.Input)
+
+		actual, err := ParseImageReference(tc.Input)
+		if tc.Expected == nil {
+			if err == nil {
+				t.Fatalf("expected an error but got %+v", actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("parsing %q: %+v", tc.Input, err)
+		}
+
+		if *actual != *tc.Expected {
+			t.Fatalf("expected %+v but got %+v", *tc.Expected, *actual)
+		}
+	}
+}
+
+func TestRegistryHostAndRepository(t *testing.T) {
+	cases := []struct {
+		LoginServer        string
+		Repository         string
+		ExpectedHost       string
+		ExpectedRepository string
+	}{
+		{
+			LoginServer:        "docker.io",
+			Repository:         "hello-world",
+			ExpectedHost:       "registry-1.docker.io",
+			ExpectedRepository: "library/hello-world",
+		},
+		{
+			LoginServer:        "docker.io",
+			Repository:         "bitnami/redis",
+			ExpectedHost:       "registry-1.docker.io",
+			ExpectedRepository: "bitnami/redis",
+		},
+		{
+			LoginServer:        "mcr.microsoft.com",
+			Repository:         "dotnet/runtime",
+			ExpectedHost:       "mcr.microsoft.com",
+			ExpectedRepository: "dotnet/runtime",
+		},
+		{
+			LoginServer:        "Example.azurecr.io",
+			Repository:         "hello-world",
+			ExpectedHost:       "example.azurecr.io",
+			ExpectedRepository: "hello-world",
+		},
+	}
+
+	for _, tc := range cases {
+		host, repository := registryHostAndRepository(tc.LoginServer, tc.Repository)
+		if host != tc.ExpectedHost || repository != tc.ExpectedRepository {
+			t.Fatalf("expected %q / %q but got %q / %q", tc.ExpectedHost, tc.ExpectedRepository, host, repository)
+		}
+	}
+}
+
+func TestValidateLoginServer(t *testing.T) {
+	cases := map[string]bool{
+		"":                         false,
+		"docker.io":                true,
+		"mcr.microsoft.com":        true,
+		"example.azurecr.io":       true,
+		"localhost:5000":           true,
+		"https://docker.io":        false,
+		"docker.io/library":        false,
+		"-invalid.example.com":     false,
+		"registry.example.com:abc": false,
+	}
+
+	for input, valid := range cases {
+		t.Logf("[DEBUG] Testing %q", input)
+
+		_, errors := ValidateLoginServer(input, "registry_uri")
+		if (len(errors) == 0) != valid {
+			t.Fatalf("expected %q to be valid: %t but got %+v", input, valid, errors)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	containerRegistryRepositoryPathRegex = `[a-z0-9]+(?:[._-][a-z0-9]+)*(?:/[a-z0-9]+(?:[._-][a-z0-9]+)*)*`
+
+	// a Source Repository is prefixed with the login server of the upstream registry (e.g. `docker.io` or `mcr.microsoft.com`)
+	containerRegistrySourceRepositoryRegex = regexp.MustCompile(`^[a-z0-9-]+(?:\.[a-z0-9-]+)+(?::[0-9]+)?/(?:` + containerRegistryRepositoryPathRegex + `(?:/\*)?|\*)$`)
+	containerRegistryTargetRepositoryRegex = regexp.MustCompile(`^(?:` + containerRegistryRepositoryPathRegex + `(?:/\*)?|\*)$`)
+)
+
+func ContainerRegistryCacheRuleName(v interface{}, k string) (warnings []string, errors []error) {
+	return containerRegistryChildResourceName(v, k)
+}
+
+func ContainerRegistryCredentialSetName(v interface{}, k string) (warnings []string, errors []error) {
+	return containerRegistryChildResourceName(v, k)
+}
+
+func ContainerRegistryCacheRuleSourceRepository(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	if !containerRegistrySourceRepositoryRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be the login server of the upstream registry followed by a lowercase repository path, which can end with a `/*` wildcard (e.g. `docker.io/library/*`), got %q", k, value))
+	}
+
+	return warnings, errors
+}
+
+func ContainerRegistryCacheRuleTargetRepository(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	if !containerRegistryTargetRepositoryRegex.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a lowercase repository path, which can end with a `/*` wildcard (e.g. `dockerhub/*`), got %q", k, value))
+	}
+
+	return warnings, errors
+}
+
+// ContainerRegistryCacheRuleRepositoryIsWildcard returns whether the specified Source or Target Repository matches multiple repositories
+func ContainerRegistryCacheRuleRepositoryIsWildcard(input string) bool {
+	return input == "*" || strings.HasSuffix(input, "/*")
+}
+
+func containerRegistryChildResourceName(v interface{}, k string) (warnings []string, errors []error) {
+	value, ok := v.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("expected %q to be a string", k))
+		return
+	}
+
+	if !regexp.MustCompile(`^[a-zA-Z0-9-]+$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("alpha numeric characters and hyphens only are allowed in %q: %q", k, value))
+	}
+
+	if len(value) < 5 || len(value) > 50 {
+		errors = append(errors, fmt.Errorf("%q must be between 5 and 50 characters: %q", k, value))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,173 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestContainerRegistryCacheRuleName(t *testing.T) {
+	cases := []struct {
+		Value string
+		Valid bool
+	}{
+		{
+			Value: "",
+			Valid: false,
+		},
+		{
+			Value: "four",
+			Valid: false,
+		},
+		{
+			Value: "5five",
+			Valid: true,
+		},
+		{
+			Value: "docker-hub-rule",
+			Valid: true,
+		},
+		{
+			Value: "DockerHubRule",
+			Valid: true,
+		},
+		{
+			Value: "docker_hub_rule",
+			Valid: false,
+		},
+		{
+			Value: "docker.hub",
+			Valid: false,
+		},
+		{
+			Value: "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwx",
+			Valid: true,
+		},
+		{
+			Value: "abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxy",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %q", tc.Value)
+		_, errors := ContainerRegistryCacheRuleName(tc.Value, "name")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t for %q", tc.Valid, valid, tc.Value)
+		}
+	}
+}
+
+func TestContainerRegistryCacheRuleSourceRepository(t *testing.T) {
+	cases := []struct {
+		Value string
+		Valid bool
+	}{
+		{
+			Value: "",
+			Valid: false,
+		},
+		{
+			// no login server
+			Value: "library/hello-world",
+			Valid: false,
+		},
+		{
+			// login server only
+			Value: "docker.io",
+			Valid: false,
+		},
+		{
+			Value: "docker.io/library/hello-world",
+			Valid: true,
+		},
+		{
+			Value: "docker.io/library/*",
+			Valid: true,
+		},
+		{
+			Value: "mcr.microsoft.com/*",
+			Valid: true,
+		},
+		{
+			Value: "myregistry.azurecr.io:443/team/app",
+			Valid: true,
+		},
+		{
+			// uppercase characters aren't allowed in repository names
+			Value: "docker.io/library/Hello-World",
+			Valid: false,
+		},
+		{
+			// wildcards are only supported as the last segment
+			Value: "docker.io/*/hello-world",
+			Valid: false,
+		},
+		{
+			// tags can't be cached individually
+			Value: "docker.io/library/hello-world:latest",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %q", tc.Value)
+		_, errors := ContainerRegistryCacheRuleSourceRepository(tc.Value, "source_repo")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t for %q", tc.Valid, valid, tc.Value)
+		}
+	}
+}
+
+func TestContainerRegistryCacheRuleTargetRepository(t *testing.T) {
+	cases := []struct {
+		Value string
+		Valid bool
+	}{
+		{
+			Value: "",
+			Valid: false,
+		},
+		{
+			Value: "hello-world",
+			Valid: true,
+		},
+		{
+			Value: "dockerhub/library/hello-world",
+			Valid: true,
+		},
+		{
+			Value: "dockerhub/*",
+			Valid: true,
+		},
+		{
+			Value: "*",
+			Valid: true,
+		},
+		{
+			Value: "dockerhub/",
+			Valid: false,
+		},
+		{
+			Value: "DockerHub/hello-world",
+			Valid: false,
+		},
+		{
+			Value: "dockerhub/*/hello-world",
+			Valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing Value %q", tc.Value)
+		_, errors := ContainerRegistryCacheRuleTargetRepository(tc.Value, "target_repo")
+		valid := len(errors) == 0
+
+		if tc.Valid != valid {
+			t.Fatalf("Expected %t but got %t for %q", tc.Valid, valid, tc.Value)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// KubernetesClusterTrustedAccessRoleBindingName validates the name of a Trusted Access Role Binding
+func KubernetesClusterTrustedAccessRoleBindingName(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if !regexp.MustCompile(`^[a-zA-Z0-9-]{1,24}$`).MatchString(v) {
+		return nil, []error{fmt.Errorf("%q must be between 1 and 24 characters in length and may only contain alphanumeric characters and hyphens, got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// KubernetesClusterTrustedAccessRoleName validates the name of a Trusted Access Role, which is in the format
+// `{Resource Provider}/{Resource Type}/{Role}` - for example `Microsoft.MachineLearningServices/workspaces/mlworkload`
+func KubernetesClusterTrustedAccessRoleName(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %q to be string", k)}
+	}
+
+	if !regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9]*(\.[a-zA-Z][a-zA-Z0-9]*)+(/[a-zA-Z][a-zA-Z0-9]*)+/[a-zA-Z0-9-]+$`).MatchString(v) {
+		return nil, []error{fmt.Errorf("%q must be in the format `{Resource Provider}/{Resource Type}/{Role}` (for example `Microsoft.MachineLearningServices/workspaces/mlworkload`), got %q", k, v)}
+	}
+
+	return nil, nil
+}
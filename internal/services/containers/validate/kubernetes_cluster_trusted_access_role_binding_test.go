@@ -0,0 +1,104 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestKubernetesClusterTrustedAccessRoleBindingName(t *testing.T) {
+	testData := []struct {
+		input string
+		error bool
+	}{
+		{
+			input: "",
+			error: true,
+		},
+		{
+			input: "binding",
+			error: false,
+		},
+		{
+			input: "My-Binding-1",
+			error: false,
+		},
+		{
+			input: "my_binding",
+			error: true,
+		},
+		{
+			input: "my.binding",
+			error: true,
+		},
+		{
+			input: "abcdefghijklmnopqrstuvwx",
+			error: false,
+		},
+		{
+			input: "abcdefghijklmnopqrstuvwxy",
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("Testing %q..", v.input)
+		_, errors := KubernetesClusterTrustedAccessRoleBindingName(v.input, "name")
+		hasErrors := len(errors) > 0
+		if v.error != hasErrors {
+			t.Fatalf("Expected %t but got %t", v.error, hasErrors)
+		}
+	}
+}
+
+func TestKubernetesClusterTrustedAccessRoleName(t *testing.T) {
+	testData := []struct {
+		input string
+		error bool
+	}{
+		{
+			input: "",
+			error: true,
+		},
+		{
+			input: "mlworkload",
+			error: true,
+		},
+		{
+			input: "Microsoft.MachineLearningServices/mlworkload",
+			error: true,
+		},
+		{
+			input: "Microsoft.MachineLearningServices/workspaces/mlworkload",
+			error: false,
+		},
+		{
+			input: "Microsoft.DataProtection/backupVaults/backup-operator",
+			error: false,
+		},
+		{
+			input: "Microsoft.Example/parents/children/reader",
+			error: false,
+		},
+		{
+			input: "Microsoft/workspaces/mlworkload",
+			error: true,
+		},
+		{
+			input: "Microsoft.MachineLearningServices/workspaces/",
+			error: true,
+		},
+		{
+			input: "Microsoft.MachineLearningServices/workspaces/ml workload",
+			error: true,
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("Testing %q..", v.input)
+		_, errors := KubernetesClusterTrustedAccessRoleName(v.input, "roles")
+		hasErrors := len(errors) > 0
+		if v.error != hasErrors {
+			t.Fatalf("Expected %t but got %t", v.error, hasErrors)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/virtualmachineimagebuilder/mgmt/2021-10-01/virtualmachineimagebuilder" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
+)
+
+// NOTE: `hashicorp/go-azure-sdk` only contains the Image Builder API (`imagebuilder/2024-02-01`) from
+// v0.20260811 onwards, which removes API versions used throughout the Provider and requires a newer
+// version of Go - as such this uses the track1 SDK until go-azure-sdk can be updated to that version,
+// at which point this (and the `parse`/`validate` packages) should be switched to the generated client
+// and Resource IDs.
+type Client struct {
+	VirtualMachineImageTemplatesClient *virtualmachineimagebuilder.VirtualMachineImageTemplatesClient
+}
+
+func NewClient(o *common.ClientOptions) *Client {
+	virtualMachineImageTemplatesClient := virtualmachineimagebuilder.NewVirtualMachineImageTemplatesClientWithBaseURI(o.ResourceManagerEndpoint, o.SubscriptionId)
+	o.ConfigureClient(&virtualMachineImageTemplatesClient.Client, o.ResourceManagerAuthorizer)
+
+	return &Client{
+		VirtualMachineImageTemplatesClient: &virtualMachineImageTemplatesClient,
+	}
+}
@@ -0,0 +1,1488 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package imagebuilder
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/virtualmachineimagebuilder/mgmt/2021-10-01/virtualmachineimagebuilder" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/identity"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-01/images"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-03/galleryimages"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2022-03-03/galleryimageversions"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/compute/2024-03-01/virtualmachineimages"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/imagebuilder/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/imagebuilder/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+var (
+	_ sdk.ResourceWithUpdate        = ImageBuilderTemplateResource{}
+	_ sdk.ResourceWithCustomizeDiff = ImageBuilderTemplateResource{}
+)
+
+type ImageBuilderTemplateResource struct{}
+
+type ImageBuilderTemplateModel struct {
+	Name                       string                                        `tfschema:"name"`
+	ResourceGroupName          string                                        `tfschema:"resource_group_name"`
+	Location                   string                                        `tfschema:"location"`
+	Identity                   []identity.ModelUserAssigned                  `tfschema:"identity"`
+	SourcePlatformImage        []ImageBuilderTemplatePlatformImage           `tfschema:"source_platform_image"`
+	SourceManagedImageId       string                                        `tfschema:"source_managed_image_id"`
+	SourceSharedImageVersionId string                                        `tfschema:"source_shared_image_version_id"`
+	Customizer                 []ImageBuilderTemplateCustomizer              `tfschema:"customizer"`
+	ManagedImageDistributor    []ImageBuilderTemplateManagedImageDistributor `tfschema:"managed_image_distributor"`
+	SharedImageDistributor     []ImageBuilderTemplateSharedImageDistributor  `tfschema:"shared_image_distributor"`
+	VhdDistributor             []ImageBuilderTemplateVhdDistributor          `tfschema:"vhd_distributor"`
+	BuildTimeoutInMinutes      int64                                         `tfschema:"build_timeout_in_minutes"`
+	VMProfile                  []ImageBuilderTemplateVMProfile               `tfschema:"vm_profile"`
+	RunOnCreate                bool                                          `tfschema:"run_on_create"`
+	RunOutput                  []ImageBuilderTemplateRunOutput               `tfschema:"run_output"`
+	Tags                       map[string]string                             `tfschema:"tags"`
+}
+
+type ImageBuilderTemplatePlatformImage struct {
+	Publisher string                                  `tfschema:"publisher"`
+	Offer     string                                  `tfschema:"offer"`
+	Sku       string                                  `tfschema:"sku"`
+	Version   string                                  `tfschema:"version"`
+	Plan      []ImageBuilderTemplatePlatformImagePlan `tfschema:"plan"`
+}
+
+type ImageBuilderTemplatePlatformImagePlan struct {
+	Name      string `tfschema:"name"`
+	Product   string `tfschema:"product"`
+	Publisher string `tfschema:"publisher"`
+}
+
+type ImageBuilderTemplateCustomizer struct {
+	Name           string                                         `tfschema:"name"`
+	Shell          []ImageBuilderTemplateShellCustomizer          `tfschema:"shell"`
+	PowerShell     []ImageBuilderTemplatePowerShellCustomizer     `tfschema:"powershell"`
+	File           []ImageBuilderTemplateFileCustomizer           `tfschema:"file"`
+	WindowsRestart []ImageBuilderTemplateWindowsRestartCustomizer `tfschema:"windows_restart"`
+	WindowsUpdate  []ImageBuilderTemplateWindowsUpdateCustomizer  `tfschema:"windows_update"`
+}
+
+type ImageBuilderTemplateShellCustomizer struct {
+	Inline         []string `tfschema:"inline"`
+	ScriptUri      string   `tfschema:"script_uri"`
+	Sha256Checksum string   `tfschema:"sha256_checksum"`
+}
+
+type ImageBuilderTemplatePowerShellCustomizer struct {
+	Inline         []string `tfschema:"inline"`
+	ScriptUri      string   `tfschema:"script_uri"`
+	Sha256Checksum string   `tfschema:"sha256_checksum"`
+	RunElevated    bool     `tfschema:"run_elevated"`
+	RunAsSystem    bool     `tfschema:"run_as_system"`
+	ValidExitCodes []int64  `tfschema:"valid_exit_codes"`
+}
+
+type ImageBuilderTemplateFileCustomizer struct {
+	SourceUri      string `tfschema:"source_uri"`
+	Destination    string `tfschema:"destination"`
+	Sha256Checksum string `tfschema:"sha256_checksum"`
+}
+
+type ImageBuilderTemplateWindowsRestartCustomizer struct {
+	RestartCommand      string `tfschema:"restart_command"`
+	RestartCheckCommand string `tfschema:"restart_check_command"`
+	RestartTimeout      string `tfschema:"restart_timeout"`
+}
+
+type ImageBuilderTemplateWindowsUpdateCustomizer struct {
+	SearchCriteria string   `tfschema:"search_criteria"`
+	Filters        []string `tfschema:"filters"`
+	UpdateLimit    int64    `tfschema:"update_limit"`
+}
+
+type ImageBuilderTemplateManagedImageDistributor struct {
+	RunOutputName string            `tfschema:"run_output_name"`
+	ImageId       string            `tfschema:"image_id"`
+	Location      string            `tfschema:"location"`
+	ArtifactTags  map[string]string `tfschema:"artifact_tags"`
+}
+
+type ImageBuilderTemplateSharedImageDistributor struct {
+	RunOutputName      string            `tfschema:"run_output_name"`
+	GalleryImageId     string            `tfschema:"gallery_image_id"`
+	ReplicationRegions []string          `tfschema:"replication_regions"`
+	ExcludeFromLatest  bool              `tfschema:"exclude_from_latest"`
+	StorageAccountType string            `tfschema:"storage_account_type"`
+	ArtifactTags       map[string]string `tfschema:"artifact_tags"`
+}
+
+type ImageBuilderTemplateVhdDistributor struct {
+	RunOutputName string            `tfschema:"run_output_name"`
+	ArtifactTags  map[string]string `tfschema:"artifact_tags"`
+}
+
+type ImageBuilderTemplateVMProfile struct {
+	VMSize                  string   `tfschema:"vm_size"`
+	OsDiskSizeInGB          int64    `tfschema:"os_disk_size_in_gb"`
+	SubnetId                string   `tfschema:"subnet_id"`
+	UserAssignedIdentityIds []string `tfschema:"user_assigned_identity_ids"`
+}
+
+type ImageBuilderTemplateRunOutput struct {
+	Name        string `tfschema:"name"`
+	ArtifactId  string `tfschema:"artifact_id"`
+	ArtifactUri string `tfschema:"artifact_uri"`
+}
+
+func (r ImageBuilderTemplateResource) ResourceType() string {
+	return "azurerm_image_builder_template"
+}
+
+func (r ImageBuilderTemplateResource) ModelObject() interface{} {
+	return &ImageBuilderTemplateModel{}
+}
+
+func (r ImageBuilderTemplateResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return validate.ImageTemplateID
+}
+
+func (r ImageBuilderTemplateResource) Arguments() map[string]*pluginsdk.Schema {
+	sources := []string{"source_platform_image", "source_managed_image_id", "source_shared_image_version_id"}
+	distributors := []string{"managed_image_distributor", "shared_image_distributor", "vhd_distributor"}
+
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.ImageTemplateName,
+		},
+
+		"resource_group_name": commonschema.ResourceGroupName(),
+
+		"location": commonschema.Location(),
+
+		"identity": commonschema.UserAssignedIdentityRequired(),
+
+		"source_platform_image": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: sources,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"publisher": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"offer": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"sku": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"version": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						Default:      "latest",
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"plan": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"name": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"product": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"publisher": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"source_managed_image_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: images.ValidateImageID,
+			ExactlyOneOf: sources,
+		},
+
+		"source_shared_image_version_id": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ValidateFunc: galleryimageversions.ValidateImageVersionID,
+			ExactlyOneOf: sources,
+		},
+
+		"customizer": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			ForceNew: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"shell": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"inline": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									ForceNew: true,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+
+								"script_uri": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+								},
+
+								"sha256_checksum": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validate.Sha256Checksum,
+								},
+							},
+						},
+					},
+
+					"powershell": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"inline": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									ForceNew: true,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+
+								"script_uri": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+								},
+
+								"sha256_checksum": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validate.Sha256Checksum,
+								},
+
+								"run_elevated": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									ForceNew: true,
+									Default:  false,
+								},
+
+								"run_as_system": {
+									Type:     pluginsdk.TypeBool,
+									Optional: true,
+									ForceNew: true,
+									Default:  false,
+								},
+
+								"valid_exit_codes": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									ForceNew: true,
+									Elem: &pluginsdk.Schema{
+										Type: pluginsdk.TypeInt,
+									},
+								},
+							},
+						},
+					},
+
+					"file": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"source_uri": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+								},
+
+								"destination": {
+									Type:         pluginsdk.TypeString,
+									Required:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"sha256_checksum": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validate.Sha256Checksum,
+								},
+							},
+						},
+					},
+
+					"windows_restart": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"restart_command": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"restart_check_command": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"restart_timeout": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validate.RestartTimeout,
+								},
+							},
+						},
+					},
+
+					"windows_update": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						MaxItems: 1,
+						Elem: &pluginsdk.Resource{
+							Schema: map[string]*pluginsdk.Schema{
+								"search_criteria": {
+									Type:         pluginsdk.TypeString,
+									Optional:     true,
+									ForceNew:     true,
+									ValidateFunc: validation.StringIsNotEmpty,
+								},
+
+								"filters": {
+									Type:     pluginsdk.TypeList,
+									Optional: true,
+									ForceNew: true,
+									Elem: &pluginsdk.Schema{
+										Type:         pluginsdk.TypeString,
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+								},
+
+								"update_limit": {
+									Type:         pluginsdk.TypeInt,
+									Optional:     true,
+									ForceNew:     true,
+									Default:      1000,
+									ValidateFunc: validation.IntAtLeast(1),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+
+		"managed_image_distributor": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: distributors,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"run_output_name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validate.RunOutputName,
+					},
+
+					"image_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: images.ValidateImageID,
+					},
+
+					"location": commonschema.Location(),
+
+					"artifact_tags": {
+						Type:     pluginsdk.TypeMap,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"shared_image_distributor": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: distributors,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"run_output_name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validate.RunOutputName,
+					},
+
+					"gallery_image_id": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: galleryimages.ValidateGalleryImageID,
+					},
+
+					"replication_regions": {
+						Type:     pluginsdk.TypeList,
+						Required: true,
+						ForceNew: true,
+						MinItems: 1,
+						Elem: &pluginsdk.Schema{
+							Type:             pluginsdk.TypeString,
+							ValidateFunc:     location.EnhancedValidate,
+							StateFunc:        location.StateFunc,
+							DiffSuppressFunc: location.DiffSuppressFunc,
+						},
+					},
+
+					"exclude_from_latest": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						ForceNew: true,
+						Default:  false,
+					},
+
+					"storage_account_type": {
+						Type:     pluginsdk.TypeString,
+						Optional: true,
+						ForceNew: true,
+						Default:  string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardLRS),
+						ValidateFunc: validation.StringInSlice([]string{
+							string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardLRS),
+							string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardZRS),
+						}, false),
+					},
+
+					"artifact_tags": {
+						Type:     pluginsdk.TypeMap,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"vhd_distributor": {
+			Type:         pluginsdk.TypeList,
+			Optional:     true,
+			ForceNew:     true,
+			AtLeastOneOf: distributors,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"run_output_name": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ForceNew:     true,
+						ValidateFunc: validate.RunOutputName,
+					},
+
+					"artifact_tags": {
+						Type:     pluginsdk.TypeMap,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+
+		"build_timeout_in_minutes": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      240,
+			ValidateFunc: validation.IntBetween(1, 960),
+		},
+
+		"vm_profile": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					// the API defaults the size of the build VM and its OS Disk when these aren't specified
+					"vm_size": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						Computed:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"os_disk_size_in_gb": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						Computed:     true,
+						ForceNew:     true,
+						ValidateFunc: validation.IntBetween(1, 4095),
+					},
+
+					"subnet_id": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ForceNew:     true,
+						ValidateFunc: commonids.ValidateSubnetID,
+					},
+
+					"user_assigned_identity_ids": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						ForceNew: true,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: commonids.ValidateUserAssignedIdentityID,
+						},
+					},
+				},
+			},
+		},
+
+		"run_on_create": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r ImageBuilderTemplateResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"run_output": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"name": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"artifact_id": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"artifact_uri": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		// when `run_on_create` is enabled this includes the time taken to build the image, which defaults to 4 hours
+		Timeout: 5 * time.Hour,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ImageBuilder.VirtualMachineImageTemplatesClient
+			subscriptionId := metadata.Client.Account.SubscriptionId
+
+			var config ImageBuilderTemplateModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			id := parse.NewImageTemplateID(subscriptionId, config.ResourceGroupName, config.Name)
+
+			existing, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil && !utils.ResponseWasNotFound(existing.Response) {
+				return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+			}
+			if !utils.ResponseWasNotFound(existing.Response) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			payload := virtualmachineimagebuilder.ImageTemplate{
+				Location: pointer.To(location.Normalize(config.Location)),
+				Identity: expandImageTemplateIdentity(config.Identity),
+				ImageTemplateProperties: &virtualmachineimagebuilder.ImageTemplateProperties{
+					Source:                expandImageTemplateSource(config),
+					Customize:             expandImageTemplateCustomizers(config.Customizer),
+					Distribute:            expandImageTemplateDistributors(config),
+					BuildTimeoutInMinutes: pointer.To(int32(config.BuildTimeoutInMinutes)),
+					VMProfile:             expandImageTemplateVMProfile(config.VMProfile),
+				},
+				Tags: tags.FromTypedObject(config.Tags),
+			}
+
+			future, err := client.CreateOrUpdate(ctx, payload, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the creation of %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+
+			if config.RunOnCreate {
+				if err := runImageTemplate(ctx, client, id); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ImageBuilder.VirtualMachineImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				if utils.ResponseWasNotFound(resp.Response) {
+					return metadata.MarkAsGone(id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", id, err)
+			}
+
+			state := ImageBuilderTemplateModel{
+				Name:              id.Name,
+				ResourceGroupName: id.ResourceGroup,
+				Location:          location.NormalizeNilable(resp.Location),
+				Tags:              tags.ToTypedObject(resp.Tags),
+				// `run_on_create` only applies when the Image Builder Template is created, so can't be retrieved
+				RunOnCreate: metadata.ResourceData.Get("run_on_create").(bool),
+			}
+
+			identityIds, err := flattenImageTemplateIdentity(resp.Identity)
+			if err != nil {
+				return err
+			}
+			state.Identity = identityIds
+
+			if props := resp.ImageTemplateProperties; props != nil {
+				if err := flattenImageTemplateSource(props.Source, &state); err != nil {
+					return err
+				}
+
+				state.Customizer = flattenImageTemplateCustomizers(props.Customize)
+				flattenImageTemplateDistributors(props.Distribute, &state)
+				state.BuildTimeoutInMinutes = int64(pointer.From(props.BuildTimeoutInMinutes))
+				// the API returns `0` when the default timeout of 240 minutes is used
+				if state.BuildTimeoutInMinutes == 0 {
+					state.BuildTimeoutInMinutes = 240
+				}
+				state.VMProfile = flattenImageTemplateVMProfile(props.VMProfile)
+			}
+
+			runOutputs, err := client.ListRunOutputsComplete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("listing the run outputs for %s: %+v", id, err)
+			}
+			state.RunOutput = make([]ImageBuilderTemplateRunOutput, 0)
+			for runOutputs.NotDone() {
+				item := runOutputs.Value()
+				output := ImageBuilderTemplateRunOutput{
+					Name: pointer.From(item.Name),
+				}
+				if props := item.RunOutputProperties; props != nil {
+					output.ArtifactId = pointer.From(props.ArtifactID)
+					output.ArtifactUri = pointer.From(props.ArtifactURI)
+				}
+				state.RunOutput = append(state.RunOutput, output)
+
+				if err := runOutputs.NextWithContext(ctx); err != nil {
+					return fmt.Errorf("listing the run outputs for %s: %+v", id, err)
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ImageBuilder.VirtualMachineImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config ImageBuilderTemplateModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			// only the `identity` and `tags` can be updated, all other changes (besides `run_on_create`) force a new resource
+			if !metadata.ResourceData.HasChanges("identity", "tags") {
+				return nil
+			}
+
+			payload := virtualmachineimagebuilder.ImageTemplateUpdateParameters{
+				Identity: expandImageTemplateIdentity(config.Identity),
+				Tags:     tags.FromTypedObject(config.Tags),
+			}
+
+			future, err := client.Update(ctx, payload, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("updating %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the update of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 60 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.ImageBuilder.VirtualMachineImageTemplatesClient
+
+			id, err := parse.ImageTemplateID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			future, err := client.Delete(ctx, id.ResourceGroup, id.Name)
+			if err != nil {
+				return fmt.Errorf("deleting %s: %+v", id, err)
+			}
+			if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+				return fmt.Errorf("waiting for the deletion of %s: %+v", id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r ImageBuilderTemplateResource) CustomizeDiff() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			var config ImageBuilderTemplateModel
+			if err := metadata.DecodeDiff(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			if len(config.Identity) > 0 && len(config.Identity[0].IdentityIds) > 1 {
+				return fmt.Errorf("only a single User Assigned Identity can be specified within the `identity` block")
+			}
+
+			for i, customizer := range config.Customizer {
+				if err := validateImageTemplateCustomizer(customizer); err != nil {
+					return fmt.Errorf("`customizer.%d`: %+v", i, err)
+				}
+			}
+
+			runOutputNames := make(map[string]struct{})
+			for _, name := range imageTemplateRunOutputNames(config) {
+				if name == "" {
+					continue
+				}
+				if _, exists := runOutputNames[strings.ToLower(name)]; exists {
+					return fmt.Errorf("the `run_output_name` %q is used by more than one distributor, `run_output_name` must be unique", name)
+				}
+				runOutputNames[strings.ToLower(name)] = struct{}{}
+			}
+
+			// the Platform Image is only checked when it's set or changed, since older image versions may since have been removed
+			if len(config.SourcePlatformImage) == 0 {
+				return nil
+			}
+			if metadata.ResourceDiff.Id() != "" && !metadata.ResourceDiff.HasChanges("location", "source_platform_image") {
+				return nil
+			}
+			for _, key := range []string{"location", "source_platform_image.0.publisher", "source_platform_image.0.offer", "source_platform_image.0.sku", "source_platform_image.0.version"} {
+				if !metadata.ResourceDiff.NewValueKnown(key) {
+					return nil
+				}
+			}
+
+			return validatePlatformImage(ctx, metadata, config.Location, config.SourcePlatformImage[0])
+		},
+	}
+}
+
+// runImageTemplate builds the image using the Image Builder Template and waits for the build to complete
+func runImageTemplate(ctx context.Context, client *virtualmachineimagebuilder.VirtualMachineImageTemplatesClient, id parse.ImageTemplateId) error {
+	future, err := client.Run(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("running %s: %+v", id, err)
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return fmt.Errorf("waiting for %s to finish running: %+v", id, err)
+	}
+
+	// the operation can complete successfully even though the build itself failed
+	resp, err := client.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		return fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	if props := resp.ImageTemplateProperties; props != nil && props.LastRunStatus != nil {
+		switch status := props.LastRunStatus; status.RunState {
+		case virtualmachineimagebuilder.RunStateFailed, virtualmachineimagebuilder.RunStateCanceled:
+			return fmt.Errorf("running %s: the run finished with the state %q: %s", id, string(status.RunState), pointer.From(status.Message))
+		case virtualmachineimagebuilder.RunStatePartiallySucceeded:
+			log.Printf("[WARN] the run of %s partially succeeded: %s", id, pointer.From(status.Message))
+		}
+	}
+
+	return nil
+}
+
+// validatePlatformImage checks that the specified Platform Image (and version) is available in the specified location
+func validatePlatformImage(ctx context.Context, metadata sdk.ResourceMetaData, loc string, input ImageBuilderTemplatePlatformImage) error {
+	client := metadata.Client.Compute.VirtualMachineImagesClient
+	subscriptionId := metadata.Client.Account.SubscriptionId
+
+	id := virtualmachineimages.NewSkuID(subscriptionId, location.Normalize(loc), input.Publisher, input.Offer, input.Sku)
+	resp, err := client.List(ctx, id, virtualmachineimages.DefaultListOperationOptions())
+	if err != nil {
+		return fmt.Errorf("retrieving the versions of the Platform Image %s: %+v", id, err)
+	}
+
+	versions := make([]string, 0)
+	if model := resp.Model; model != nil {
+		for _, item := range *model {
+			versions = append(versions, item.Name)
+		}
+	}
+
+	if len(versions) == 0 {
+		return fmt.Errorf("the Platform Image %s was not found - check the `publisher`, `offer` and `sku` (available images can be found using the `azurerm_platform_image` Data Source)", id)
+	}
+
+	if strings.EqualFold(input.Version, "latest") {
+		return nil
+	}
+	for _, version := range versions {
+		if strings.EqualFold(version, input.Version) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("version %q of the Platform Image %s was not found - the latest version is %q", input.Version, id, versions[len(versions)-1])
+}
+
+func validateImageTemplateCustomizer(input ImageBuilderTemplateCustomizer) error {
+	types := 0
+	for _, count := range []int{len(input.Shell), len(input.PowerShell), len(input.File), len(input.WindowsRestart), len(input.WindowsUpdate)} {
+		types += count
+	}
+	if types != 1 {
+		return fmt.Errorf("exactly one of `shell`, `powershell`, `file`, `windows_restart` or `windows_update` must be specified")
+	}
+
+	type script struct {
+		kind           string
+		inline         []string
+		scriptUri      string
+		sha256Checksum string
+	}
+	scripts := make([]script, 0)
+	for _, v := range input.Shell {
+		scripts = append(scripts, script{"shell", v.Inline, v.ScriptUri, v.Sha256Checksum})
+	}
+	for _, v := range input.PowerShell {
+		scripts = append(scripts, script{"powershell", v.Inline, v.ScriptUri, v.Sha256Checksum})
+	}
+
+	for _, v := range scripts {
+		if (len(v.inline) > 0) == (v.scriptUri != "") {
+			return fmt.Errorf("exactly one of `inline` or `script_uri` must be specified within the `%s` block", v.kind)
+		}
+		if v.sha256Checksum != "" && v.scriptUri == "" {
+			return fmt.Errorf("`sha256_checksum` can only be specified with `script_uri` within the `%s` block", v.kind)
+		}
+	}
+
+	return nil
+}
+
+func imageTemplateRunOutputNames(input ImageBuilderTemplateModel) []string {
+	names := make([]string, 0)
+	for _, v := range input.ManagedImageDistributor {
+		names = append(names, v.RunOutputName)
+	}
+	for _, v := range input.SharedImageDistributor {
+		names = append(names, v.RunOutputName)
+	}
+	for _, v := range input.VhdDistributor {
+		names = append(names, v.RunOutputName)
+	}
+	return names
+}
+
+func expandImageTemplateIdentity(input []identity.ModelUserAssigned) *virtualmachineimagebuilder.ImageTemplateIdentity {
+	if len(input) == 0 {
+		return nil
+	}
+
+	identityIds := make(map[string]*virtualmachineimagebuilder.ImageTemplateIdentityUserAssignedIdentitiesValue)
+	for _, id := range input[0].IdentityIds {
+		identityIds[id] = &virtualmachineimagebuilder.ImageTemplateIdentityUserAssignedIdentitiesValue{}
+	}
+
+	return &virtualmachineimagebuilder.ImageTemplateIdentity{
+		Type:                   virtualmachineimagebuilder.ResourceIdentityTypeUserAssigned,
+		UserAssignedIdentities: identityIds,
+	}
+}
+
+func flattenImageTemplateIdentity(input *virtualmachineimagebuilder.ImageTemplateIdentity) ([]identity.ModelUserAssigned, error) {
+	if input == nil || input.Type == virtualmachineimagebuilder.ResourceIdentityTypeNone {
+		return []identity.ModelUserAssigned{}, nil
+	}
+
+	identityIds := make([]string, 0)
+	for k := range input.UserAssignedIdentities {
+		id, err := commonids.ParseUserAssignedIdentityIDInsensitively(k)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q as a User Assigned Identity ID: %+v", k, err)
+		}
+		identityIds = append(identityIds, id.ID())
+	}
+
+	return []identity.ModelUserAssigned{
+		{
+			Type:        identity.TypeUserAssigned,
+			IdentityIds: identityIds,
+		},
+	}, nil
+}
+
+func expandImageTemplateSource(input ImageBuilderTemplateModel) virtualmachineimagebuilder.BasicImageTemplateSource {
+	if len(input.SourcePlatformImage) > 0 {
+		image := input.SourcePlatformImage[0]
+		source := virtualmachineimagebuilder.ImageTemplatePlatformImageSource{
+			Publisher: pointer.To(image.Publisher),
+			Offer:     pointer.To(image.Offer),
+			Sku:       pointer.To(image.Sku),
+			Version:   pointer.To(image.Version),
+			Type:      virtualmachineimagebuilder.TypePlatformImage,
+		}
+		if len(image.Plan) > 0 {
+			source.PlanInfo = &virtualmachineimagebuilder.PlatformImagePurchasePlan{
+				PlanName:      pointer.To(image.Plan[0].Name),
+				PlanProduct:   pointer.To(image.Plan[0].Product),
+				PlanPublisher: pointer.To(image.Plan[0].Publisher),
+			}
+		}
+		return source
+	}
+
+	if input.SourceManagedImageId != "" {
+		return virtualmachineimagebuilder.ImageTemplateManagedImageSource{
+			ImageID: pointer.To(input.SourceManagedImageId),
+			Type:    virtualmachineimagebuilder.TypeManagedImage,
+		}
+	}
+
+	return virtualmachineimagebuilder.ImageTemplateSharedImageVersionSource{
+		ImageVersionID: pointer.To(input.SourceSharedImageVersionId),
+		Type:           virtualmachineimagebuilder.TypeSharedImageVersion,
+	}
+}
+
+func flattenImageTemplateSource(input virtualmachineimagebuilder.BasicImageTemplateSource, state *ImageBuilderTemplateModel) error {
+	if input == nil {
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplatePlatformImageSource(); ok && source != nil {
+		image := ImageBuilderTemplatePlatformImage{
+			Publisher: pointer.From(source.Publisher),
+			Offer:     pointer.From(source.Offer),
+			Sku:       pointer.From(source.Sku),
+			Version:   pointer.From(source.Version),
+			Plan:      []ImageBuilderTemplatePlatformImagePlan{},
+		}
+		if plan := source.PlanInfo; plan != nil {
+			image.Plan = append(image.Plan, ImageBuilderTemplatePlatformImagePlan{
+				Name:      pointer.From(plan.PlanName),
+				Product:   pointer.From(plan.PlanProduct),
+				Publisher: pointer.From(plan.PlanPublisher),
+			})
+		}
+		state.SourcePlatformImage = []ImageBuilderTemplatePlatformImage{image}
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplateManagedImageSource(); ok && source != nil {
+		id, err := images.ParseImageIDInsensitively(pointer.From(source.ImageID))
+		if err != nil {
+			return err
+		}
+		state.SourceManagedImageId = id.ID()
+		return nil
+	}
+
+	if source, ok := input.AsImageTemplateSharedImageVersionSource(); ok && source != nil {
+		id, err := galleryimageversions.ParseImageVersionIDInsensitively(pointer.From(source.ImageVersionID))
+		if err != nil {
+			return err
+		}
+		state.SourceSharedImageVersionId = id.ID()
+		return nil
+	}
+
+	return nil
+}
+
+func expandImageTemplateCustomizers(input []ImageBuilderTemplateCustomizer) *[]virtualmachineimagebuilder.BasicImageTemplateCustomizer {
+	customizers := make([]virtualmachineimagebuilder.BasicImageTemplateCustomizer, 0)
+
+	for _, v := range input {
+		var name *string
+		if v.Name != "" {
+			name = pointer.To(v.Name)
+		}
+
+		switch {
+		case len(v.Shell) > 0:
+			shell := v.Shell[0]
+			customizer := virtualmachineimagebuilder.ImageTemplateShellCustomizer{
+				Name: name,
+				Type: virtualmachineimagebuilder.TypeBasicImageTemplateCustomizerTypeShell,
+			}
+			if len(shell.Inline) > 0 {
+				customizer.Inline = pointer.To(shell.Inline)
+			}
+			if shell.ScriptUri != "" {
+				customizer.ScriptURI = pointer.To(shell.ScriptUri)
+			}
+			if shell.Sha256Checksum != "" {
+				customizer.Sha256Checksum = pointer.To(shell.Sha256Checksum)
+			}
+			customizers = append(customizers, customizer)
+
+		case len(v.PowerShell) > 0:
+			powershell := v.PowerShell[0]
+			customizer := virtualmachineimagebuilder.ImageTemplatePowerShellCustomizer{
+				Name:        name,
+				RunElevated: pointer.To(powershell.RunElevated),
+				RunAsSystem: pointer.To(powershell.RunAsSystem),
+				Type:        virtualmachineimagebuilder.TypeBasicImageTemplateCustomizerTypePowerShell,
+			}
+			if len(powershell.Inline) > 0 {
+				customizer.Inline = pointer.To(powershell.Inline)
+			}
+			if powershell.ScriptUri != "" {
+				customizer.ScriptURI = pointer.To(powershell.ScriptUri)
+			}
+			if powershell.Sha256Checksum != "" {
+				customizer.Sha256Checksum = pointer.To(powershell.Sha256Checksum)
+			}
+			if len(powershell.ValidExitCodes) > 0 {
+				exitCodes := make([]int32, 0)
+				for _, code := range powershell.ValidExitCodes {
+					exitCodes = append(exitCodes, int32(code))
+				}
+				customizer.ValidExitCodes = &exitCodes
+			}
+			customizers = append(customizers, customizer)
+
+		case len(v.File) > 0:
+			file := v.File[0]
+			customizer := virtualmachineimagebuilder.ImageTemplateFileCustomizer{
+				Name:        name,
+				SourceURI:   pointer.To(file.SourceUri),
+				Destination: pointer.To(file.Destination),
+				Type:        virtualmachineimagebuilder.TypeBasicImageTemplateCustomizerTypeFile,
+			}
+			if file.Sha256Checksum != "" {
+				customizer.Sha256Checksum = pointer.To(file.Sha256Checksum)
+			}
+			customizers = append(customizers, customizer)
+
+		case len(v.WindowsRestart) > 0:
+			restart := v.WindowsRestart[0]
+			customizer := virtualmachineimagebuilder.ImageTemplateRestartCustomizer{
+				Name: name,
+				Type: virtualmachineimagebuilder.TypeBasicImageTemplateCustomizerTypeWindowsRestart,
+			}
+			if restart.RestartCommand != "" {
+				customizer.RestartCommand = pointer.To(restart.RestartCommand)
+			}
+			if restart.RestartCheckCommand != "" {
+				customizer.RestartCheckCommand = pointer.To(restart.RestartCheckCommand)
+			}
+			if restart.RestartTimeout != "" {
+				customizer.RestartTimeout = pointer.To(restart.RestartTimeout)
+			}
+			customizers = append(customizers, customizer)
+
+		case len(v.WindowsUpdate) > 0:
+			update := v.WindowsUpdate[0]
+			customizer := virtualmachineimagebuilder.ImageTemplateWindowsUpdateCustomizer{
+				Name:        name,
+				UpdateLimit: pointer.To(int32(update.UpdateLimit)),
+				Type:        virtualmachineimagebuilder.TypeBasicImageTemplateCustomizerTypeWindowsUpdate,
+			}
+			if update.SearchCriteria != "" {
+				customizer.SearchCriteria = pointer.To(update.SearchCriteria)
+			}
+			if len(update.Filters) > 0 {
+				customizer.Filters = pointer.To(update.Filters)
+			}
+			customizers = append(customizers, customizer)
+		}
+	}
+
+	return &customizers
+}
+
+func flattenImageTemplateCustomizers(input *[]virtualmachineimagebuilder.BasicImageTemplateCustomizer) []ImageBuilderTemplateCustomizer {
+	customizers := make([]ImageBuilderTemplateCustomizer, 0)
+	if input == nil {
+		return customizers
+	}
+
+	for _, item := range *input {
+		if v, ok := item.AsImageTemplateShellCustomizer(); ok && v != nil {
+			customizers = append(customizers, ImageBuilderTemplateCustomizer{
+				Name: pointer.From(v.Name),
+				Shell: []ImageBuilderTemplateShellCustomizer{
+					{
+						Inline:         pointer.From(v.Inline),
+						ScriptUri:      pointer.From(v.ScriptURI),
+						Sha256Checksum: pointer.From(v.Sha256Checksum),
+					},
+				},
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplatePowerShellCustomizer(); ok && v != nil {
+			exitCodes := make([]int64, 0)
+			if v.ValidExitCodes != nil {
+				for _, code := range *v.ValidExitCodes {
+					exitCodes = append(exitCodes, int64(code))
+				}
+			}
+			customizers = append(customizers, ImageBuilderTemplateCustomizer{
+				Name: pointer.From(v.Name),
+				PowerShell: []ImageBuilderTemplatePowerShellCustomizer{
+					{
+						Inline:         pointer.From(v.Inline),
+						ScriptUri:      pointer.From(v.ScriptURI),
+						Sha256Checksum: pointer.From(v.Sha256Checksum),
+						RunElevated:    pointer.From(v.RunElevated),
+						RunAsSystem:    pointer.From(v.RunAsSystem),
+						ValidExitCodes: exitCodes,
+					},
+				},
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplateFileCustomizer(); ok && v != nil {
+			customizers = append(customizers, ImageBuilderTemplateCustomizer{
+				Name: pointer.From(v.Name),
+				File: []ImageBuilderTemplateFileCustomizer{
+					{
+						SourceUri:      pointer.From(v.SourceURI),
+						Destination:    pointer.From(v.Destination),
+						Sha256Checksum: pointer.From(v.Sha256Checksum),
+					},
+				},
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplateRestartCustomizer(); ok && v != nil {
+			customizers = append(customizers, ImageBuilderTemplateCustomizer{
+				Name: pointer.From(v.Name),
+				WindowsRestart: []ImageBuilderTemplateWindowsRestartCustomizer{
+					{
+						RestartCommand:      pointer.From(v.RestartCommand),
+						RestartCheckCommand: pointer.From(v.RestartCheckCommand),
+						RestartTimeout:      pointer.From(v.RestartTimeout),
+					},
+				},
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplateWindowsUpdateCustomizer(); ok && v != nil {
+			customizers = append(customizers, ImageBuilderTemplateCustomizer{
+				Name: pointer.From(v.Name),
+				WindowsUpdate: []ImageBuilderTemplateWindowsUpdateCustomizer{
+					{
+						SearchCriteria: pointer.From(v.SearchCriteria),
+						Filters:        pointer.From(v.Filters),
+						UpdateLimit:    int64(pointer.From(v.UpdateLimit)),
+					},
+				},
+			})
+		}
+	}
+
+	return customizers
+}
+
+func expandImageTemplateDistributors(input ImageBuilderTemplateModel) *[]virtualmachineimagebuilder.BasicImageTemplateDistributor {
+	distributors := make([]virtualmachineimagebuilder.BasicImageTemplateDistributor, 0)
+
+	for _, v := range input.ManagedImageDistributor {
+		distributors = append(distributors, virtualmachineimagebuilder.ImageTemplateManagedImageDistributor{
+			RunOutputName: pointer.To(v.RunOutputName),
+			ImageID:       pointer.To(v.ImageId),
+			Location:      pointer.To(location.Normalize(v.Location)),
+			ArtifactTags:  tags.FromTypedObject(v.ArtifactTags),
+			Type:          virtualmachineimagebuilder.TypeBasicImageTemplateDistributorTypeManagedImage,
+		})
+	}
+
+	for _, v := range input.SharedImageDistributor {
+		regions := make([]string, 0)
+		for _, region := range v.ReplicationRegions {
+			regions = append(regions, location.Normalize(region))
+		}
+		distributors = append(distributors, virtualmachineimagebuilder.ImageTemplateSharedImageDistributor{
+			RunOutputName:      pointer.To(v.RunOutputName),
+			GalleryImageID:     pointer.To(v.GalleryImageId),
+			ReplicationRegions: &regions,
+			ExcludeFromLatest:  pointer.To(v.ExcludeFromLatest),
+			StorageAccountType: virtualmachineimagebuilder.SharedImageStorageAccountType(v.StorageAccountType),
+			ArtifactTags:       tags.FromTypedObject(v.ArtifactTags),
+			Type:               virtualmachineimagebuilder.TypeBasicImageTemplateDistributorTypeSharedImage,
+		})
+	}
+
+	for _, v := range input.VhdDistributor {
+		distributors = append(distributors, virtualmachineimagebuilder.ImageTemplateVhdDistributor{
+			RunOutputName: pointer.To(v.RunOutputName),
+			ArtifactTags:  tags.FromTypedObject(v.ArtifactTags),
+			Type:          virtualmachineimagebuilder.TypeBasicImageTemplateDistributorTypeVHD,
+		})
+	}
+
+	return &distributors
+}
+
+func flattenImageTemplateDistributors(input *[]virtualmachineimagebuilder.BasicImageTemplateDistributor, state *ImageBuilderTemplateModel) {
+	state.ManagedImageDistributor = make([]ImageBuilderTemplateManagedImageDistributor, 0)
+	state.SharedImageDistributor = make([]ImageBuilderTemplateSharedImageDistributor, 0)
+	state.VhdDistributor = make([]ImageBuilderTemplateVhdDistributor, 0)
+	if input == nil {
+		return
+	}
+
+	for _, item := range *input {
+		if v, ok := item.AsImageTemplateManagedImageDistributor(); ok && v != nil {
+			imageId := pointer.From(v.ImageID)
+			if id, err := images.ParseImageIDInsensitively(imageId); err == nil {
+				imageId = id.ID()
+			}
+			state.ManagedImageDistributor = append(state.ManagedImageDistributor, ImageBuilderTemplateManagedImageDistributor{
+				RunOutputName: pointer.From(v.RunOutputName),
+				ImageId:       imageId,
+				Location:      location.NormalizeNilable(v.Location),
+				ArtifactTags:  tags.ToTypedObject(v.ArtifactTags),
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplateSharedImageDistributor(); ok && v != nil {
+			galleryImageId := pointer.From(v.GalleryImageID)
+			if id, err := galleryimages.ParseGalleryImageIDInsensitively(galleryImageId); err == nil {
+				galleryImageId = id.ID()
+			}
+			regions := make([]string, 0)
+			for _, region := range pointer.From(v.ReplicationRegions) {
+				regions = append(regions, location.Normalize(region))
+			}
+			storageAccountType := string(v.StorageAccountType)
+			if storageAccountType == "" {
+				storageAccountType = string(virtualmachineimagebuilder.SharedImageStorageAccountTypeStandardLRS)
+			}
+			state.SharedImageDistributor = append(state.SharedImageDistributor, ImageBuilderTemplateSharedImageDistributor{
+				RunOutputName:      pointer.From(v.RunOutputName),
+				GalleryImageId:     galleryImageId,
+				ReplicationRegions: regions,
+				ExcludeFromLatest:  pointer.From(v.ExcludeFromLatest),
+				StorageAccountType: storageAccountType,
+				ArtifactTags:       tags.ToTypedObject(v.ArtifactTags),
+			})
+			continue
+		}
+
+		if v, ok := item.AsImageTemplateVhdDistributor(); ok && v != nil {
+			state.VhdDistributor = append(state.VhdDistributor, ImageBuilderTemplateVhdDistributor{
+				RunOutputName: pointer.From(v.RunOutputName),
+				ArtifactTags:  tags.ToTypedObject(v.ArtifactTags),
+			})
+		}
+	}
+}
+
+func expandImageTemplateVMProfile(input []ImageBuilderTemplateVMProfile) *virtualmachineimagebuilder.ImageTemplateVMProfile {
+	if len(input) == 0 {
+		return nil
+	}
+
+	v := input[0]
+	profile := virtualmachineimagebuilder.ImageTemplateVMProfile{}
+	if v.VMSize != "" {
+		profile.VMSize = pointer.To(v.VMSize)
+	}
+	if v.OsDiskSizeInGB > 0 {
+		profile.OsDiskSizeGB = pointer.To(int32(v.OsDiskSizeInGB))
+	}
+	if v.SubnetId != "" {
+		profile.VnetConfig = &virtualmachineimagebuilder.VirtualNetworkConfig{
+			SubnetID: pointer.To(v.SubnetId),
+		}
+	}
+	if len(v.UserAssignedIdentityIds) > 0 {
+		profile.UserAssignedIdentities = pointer.To(v.UserAssignedIdentityIds)
+	}
+
+	return &profile
+}
+
+func flattenImageTemplateVMProfile(input *virtualmachineimagebuilder.ImageTemplateVMProfile) []ImageBuilderTemplateVMProfile {
+	if input == nil {
+		return []ImageBuilderTemplateVMProfile{}
+	}
+
+	profile := ImageBuilderTemplateVMProfile{
+		VMSize:                  pointer.From(input.VMSize),
+		OsDiskSizeInGB:          int64(pointer.From(input.OsDiskSizeGB)),
+		UserAssignedIdentityIds: make([]string, 0),
+	}
+	if input.VnetConfig != nil && input.VnetConfig.SubnetID != nil {
+		if id, err := commonids.ParseSubnetIDInsensitively(*input.VnetConfig.SubnetID); err == nil {
+			profile.SubnetId = id.ID()
+		}
+	}
+	for _, v := range pointer.From(input.UserAssignedIdentities) {
+		if id, err := commonids.ParseUserAssignedIdentityIDInsensitively(v); err == nil {
+			profile.UserAssignedIdentityIds = append(profile.UserAssignedIdentityIds, id.ID())
+		}
+	}
+
+	return []ImageBuilderTemplateVMProfile{profile}
+}
@@ -0,0 +1,546 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package imagebuilder_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/imagebuilder/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+type ImageBuilderTemplateResource struct{}
+
+func TestAccImageBuilderTemplate_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+	})
+}
+
+func TestAccImageBuilderTemplate_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccImageBuilderTemplate_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+	})
+}
+
+func TestAccImageBuilderTemplate_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+		{
+			Config: r.updateTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+	})
+}
+
+func TestAccImageBuilderTemplate_windows(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.windows(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep("run_on_create"),
+	})
+}
+
+func TestAccImageBuilderTemplate_sharedImageRunOnCreate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.sharedImageRunOnCreate(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("run_output.#").HasValue("1"),
+				check.That(data.ResourceName).Key("run_output.0.artifact_id").IsNotEmpty(),
+			),
+		},
+		data.ImportStep("run_on_create"),
+	})
+}
+
+func TestAccImageBuilderTemplate_invalidPlatformImage(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.invalidPlatformImage(data),
+			ExpectError: regexp.MustCompile("of the Platform Image .* was not found"),
+		},
+	})
+}
+
+func TestAccImageBuilderTemplate_invalidCustomizer(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_image_builder_template", "test")
+	r := ImageBuilderTemplateResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.invalidCustomizer(data),
+			ExpectError: regexp.MustCompile("exactly one of `shell`, `powershell`, `file`, `windows_restart` or `windows_update` must be specified"),
+		},
+	})
+}
+
+func (ImageBuilderTemplateResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := parse.ImageTemplateID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.ImageBuilder.VirtualMachineImageTemplatesClient.Get(ctx, id.ResourceGroup, id.Name)
+	if err != nil {
+		if utils.ResponseWasNotFound(resp.Response) {
+			return pointer.To(false), nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.ImageTemplateProperties != nil), nil
+}
+
+func (r ImageBuilderTemplateResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "import" {
+  name                = azurerm_image_builder_template.test.name
+  resource_group_name = azurerm_image_builder_template.test.resource_group_name
+  location            = azurerm_image_builder_template.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+}
+`, r.basic(data))
+}
+
+func (r ImageBuilderTemplateResource) updateTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+
+  tags = {
+    environment = "test"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_virtual_network" "test" {
+  name                = "acctestvn-%[2]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+}
+
+resource "azurerm_subnet" "test" {
+  name                                          = "internal"
+  resource_group_name                           = azurerm_resource_group.test.name
+  virtual_network_name                          = azurerm_virtual_network.test.name
+  address_prefixes                              = ["10.0.2.0/24"]
+  private_link_service_network_policies_enabled = false
+}
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "latest"
+  }
+
+  customizer {
+    name = "update"
+
+    shell {
+      inline = [
+        "sudo apt-get update",
+        "sudo apt-get upgrade -y",
+      ]
+    }
+  }
+
+  customizer {
+    name = "motd"
+
+    file {
+      source_uri  = "https://raw.githubusercontent.com/hashicorp/terraform-provider-azurerm/main/README.md"
+      destination = "/tmp/README.md"
+    }
+  }
+
+  managed_image_distributor {
+    run_output_name = "managed-image"
+    image_id        = "${azurerm_resource_group.test.id}/providers/Microsoft.Compute/images/acctest-image-%[2]d"
+    location        = azurerm_resource_group.test.location
+
+    artifact_tags = {
+      source = "image-builder"
+    }
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+
+  build_timeout_in_minutes = 120
+
+  vm_profile {
+    vm_size            = "Standard_D2s_v3"
+    os_disk_size_in_gb = 64
+    subnet_id          = azurerm_subnet.test.id
+  }
+
+  tags = {
+    environment = "test"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) windows(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "MicrosoftWindowsServer"
+    offer     = "WindowsServer"
+    sku       = "2022-datacenter-azure-edition"
+  }
+
+  customizer {
+    powershell {
+      inline           = ["New-Item -Path C:\\buildArtifacts -ItemType Directory"]
+      run_elevated     = true
+      valid_exit_codes = [0, 3010]
+    }
+  }
+
+  customizer {
+    windows_update {
+      search_criteria = "IsInstalled=0"
+      filters         = ["exclude:$_.Title -like '*Preview*'", "include:$true"]
+      update_limit    = 20
+    }
+  }
+
+  customizer {
+    windows_restart {
+      restart_timeout = "10m"
+    }
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) sharedImageRunOnCreate(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%[1]s
+
+resource "azurerm_shared_image_gallery" "test" {
+  name                = "acctestsig%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_shared_image" "test" {
+  name                = "acctestimg%[2]d"
+  gallery_name        = azurerm_shared_image_gallery.test.name
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+  os_type             = "Linux"
+  hyper_v_generation  = "V2"
+
+  identifier {
+    publisher = "AccTesPublisher%[2]d"
+    offer     = "AccTesOffer%[2]d"
+    sku       = "AccTesSku%[2]d"
+  }
+}
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts-gen2"
+  }
+
+  customizer {
+    shell {
+      inline = ["echo 'built by image builder' | sudo tee /etc/motd"]
+    }
+  }
+
+  shared_image_distributor {
+    run_output_name     = "shared-image"
+    gallery_image_id    = azurerm_shared_image.test.id
+    replication_regions = [azurerm_resource_group.test.location]
+  }
+
+  run_on_create = true
+
+  depends_on = [azurerm_role_assignment.test]
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) invalidPlatformImage(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+    version   = "0.0.0"
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ImageBuilderTemplateResource) invalidCustomizer(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_image_builder_template" "test" {
+  name                = "acctest-ibt-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+
+  identity {
+    type         = "UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.test.id]
+  }
+
+  source_platform_image {
+    publisher = "Canonical"
+    offer     = "0001-com-ubuntu-server-jammy"
+    sku       = "22_04-lts"
+  }
+
+  customizer {
+    shell {
+      inline = ["echo hello"]
+    }
+
+    file {
+      source_uri  = "https://example.com/file.txt"
+      destination = "/tmp/file.txt"
+    }
+  }
+
+  vhd_distributor {
+    run_output_name = "vhd"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (ImageBuilderTemplateResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-ibt-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_user_assigned_identity" "test" {
+  name                = "acctestuai-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_role_assignment" "test" {
+  scope                = azurerm_resource_group.test.id
+  role_definition_name = "Contributor"
+  principal_id         = azurerm_user_assigned_identity.test.principal_id
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func ImageTemplateName(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^[A-Za-z0-9-_.]{1,64}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be between 1 and 64 characters long, and can only contain alphanumerics, hyphens, underscores and periods", k))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,36 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestImageTemplateName(t *testing.T) {
+	validNames := []string{
+		"a",
+		"ubuntu-golden_image.2024",
+		strings.Repeat("a", 64),
+	}
+	for _, v := range validNames {
+		_, errors := ImageTemplateName(v, "name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Image Builder Template Name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"golden image",
+		"golden/image",
+		strings.Repeat("a", 65),
+	}
+	for _, v := range invalidNames {
+		_, errors := ImageTemplateName(v, "name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Image Builder Template Name", v)
+		}
+	}
+}
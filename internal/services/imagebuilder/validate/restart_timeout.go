@@ -0,0 +1,20 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// RestartTimeout validates the timeout used by the Windows Restart customizer, which is a duration such as `5m` or `1h30m`
+func RestartTimeout(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if value == "" || !regexp.MustCompile(`^(\d+h)?(\d+m)?(\d+s)?$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a duration consisting of hours, minutes and/or seconds, for example `5m` or `1h30m`", k))
+	}
+
+	return warnings, errors
+}
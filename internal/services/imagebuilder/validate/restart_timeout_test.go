@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import "testing"
+
+func TestRestartTimeout(t *testing.T) {
+	validValues := []string{
+		"5m",
+		"1h30m",
+		"90s",
+		"2h",
+	}
+	for _, v := range validValues {
+		_, errors := RestartTimeout(v, "restart_timeout")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Restart Timeout: %q", v, errors)
+		}
+	}
+
+	invalidValues := []string{
+		"",
+		"5",
+		"5 minutes",
+		"30m1h",
+	}
+	for _, v := range invalidValues {
+		_, errors := RestartTimeout(v, "restart_timeout")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Restart Timeout", v)
+		}
+	}
+}
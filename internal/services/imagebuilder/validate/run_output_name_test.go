@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunOutputName(t *testing.T) {
+	validNames := []string{
+		"managed-image",
+		"shared_image.v1",
+	}
+	for _, v := range validNames {
+		_, errors := RunOutputName(v, "run_output_name")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid Run Output Name: %q", v, errors)
+		}
+	}
+
+	invalidNames := []string{
+		"",
+		"run output",
+		strings.Repeat("a", 65),
+	}
+	for _, v := range invalidNames {
+		_, errors := RunOutputName(v, "run_output_name")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid Run Output Name", v)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func Sha256Checksum(v interface{}, k string) (warnings []string, errors []error) {
+	value := v.(string)
+
+	if !regexp.MustCompile(`^[A-Fa-f0-9]{64}$`).MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a SHA256 checksum consisting of 64 hexadecimal characters", k))
+	}
+
+	return warnings, errors
+}
@@ -0,0 +1,34 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package validate
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSha256Checksum(t *testing.T) {
+	validValues := []string{
+		strings.Repeat("a", 64),
+		"E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855",
+	}
+	for _, v := range validValues {
+		_, errors := Sha256Checksum(v, "sha256_checksum")
+		if len(errors) != 0 {
+			t.Fatalf("%q should be a valid SHA256 checksum: %q", v, errors)
+		}
+	}
+
+	invalidValues := []string{
+		"",
+		strings.Repeat("a", 63),
+		strings.Repeat("g", 64),
+	}
+	for _, v := range invalidValues {
+		_, errors := Sha256Checksum(v, "sha256_checksum")
+		if len(errors) == 0 {
+			t.Fatalf("%q should be an invalid SHA256 checksum", v)
+		}
+	}
+}
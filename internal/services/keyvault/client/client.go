@@ -8,7 +8,7 @@ import (
 
 	"github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-02-01/vaults"
 	vaults20230701 "github.com/hashicorp/go-azure-sdk/resource-manager/keyvault/2023-07-01/vaults"
-	resources20151101 "github.com/hashicorp/go-azure-sdk/resource-manager/resources/2015-11-01/resources"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/common"
 	dataplane "github.com/tombuildsstuff/kermit/sdk/keyvault/7.4/keyvault"
 )
@@ -24,8 +24,8 @@ type Client struct {
 
 	ManagementClient *dataplane.BaseClient // TODO: we should rename this DataPlaneClient in time
 
-	// NOTE: @tombuildsstuff: this client is intentionally internal-only so that it's not used directly
-	resources20151101Client *resources20151101.ResourcesClient
+	// NOTE: used to look up the ID of a Key Vault by name, across the Subscriptions available to the current credentials
+	resourceGraphClient *resourcegraph.ResourcesClient
 
 	// @tombuildsstuff: I'm intentionally vendoring this API version separately to take advantage
 	// of the updated List API behaviour/new base layer (since the API now always returns a nextLink)
@@ -45,11 +45,11 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 	}
 	o.Configure(updatedVaultsClient.Client, o.Authorizers.ResourceManager)
 
-	resources20151101Client, err := resources20151101.NewResourcesClientWithBaseURI(o.Environment.ResourceManager)
+	resourceGraphClient, err := resourcegraph.NewResourcesClientWithBaseURI(o.Environment.ResourceManager)
 	if err != nil {
-		return nil, fmt.Errorf("building legacy Resources client: %+v", err)
+		return nil, fmt.Errorf("building ResourceGraph client: %+v", err)
 	}
-	o.Configure(resources20151101Client.Client, o.Authorizers.ResourceManager)
+	o.Configure(resourceGraphClient.Client, o.Authorizers.ResourceManager)
 
 	// These clients use `Azure/azure-sdk-for-go` and/or `Azure/go-autorest`
 	vaultsClient := vaults.NewVaultsClientWithBaseURI(o.ResourceManagerEndpoint)
@@ -62,7 +62,7 @@ func NewClient(o *common.ClientOptions) (*Client, error) {
 		VaultsClient:     &vaultsClient,
 
 		// intentionally internal to this package for now, see above.
-		resources20151101Client: resources20151101Client,
-		vaults20230701Client:    updatedVaultsClient,
+		resourceGraphClient:  resourceGraphClient,
+		vaults20230701Client: updatedVaultsClient,
 	}, nil
 }
@@ -110,6 +110,26 @@ func (c *Client) Exists(ctx context.Context, keyVaultId commonids.KeyVaultId) (b
 }
 
 func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultBaseUrl string) (*string, error) {
+	return c.keyVaultIDFromBaseUrl(ctx, subscriptionId, keyVaultBaseUrl, nil)
+}
+
+// KeyVaultIDFromBaseUrlAndExistingId returns the ID of the Key Vault at the specified Base URL in the same way as
+// KeyVaultIDFromBaseUrl - however when the ID of the Key Vault is already known (for example from the `key_vault_id`
+// field within the State) the Key Vault is retrieved directly, rather than being looked up using the Resource Graph.
+func (c *Client) KeyVaultIDFromBaseUrlAndExistingId(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultBaseUrl string, existingKeyVaultId string) (*string, error) {
+	var existingId *commonids.KeyVaultId
+	if existingKeyVaultId != "" {
+		id, err := commonids.ParseKeyVaultIDInsensitively(existingKeyVaultId)
+		if err != nil {
+			return nil, err
+		}
+		existingId = id
+	}
+
+	return c.keyVaultIDFromBaseUrl(ctx, subscriptionId, keyVaultBaseUrl, existingId)
+}
+
+func (c *Client) keyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultBaseUrl string, existingId *commonids.KeyVaultId) (*string, error) {
 	keyVaultName, err := c.parseNameFromBaseUrl(keyVaultBaseUrl)
 	if err != nil {
 		return nil, err
@@ -129,13 +149,12 @@ func (c *Client) KeyVaultIDFromBaseUrl(ctx context.Context, subscriptionId commo
 		return &v.keyVaultId, nil
 	}
 
-	// Populate the cache
-	if err := c.populateCache(ctx, subscriptionId); err != nil {
-		return nil, fmt.Errorf("populating the Key Vaults cache for %s: %+v", subscriptionId, err)
+	// Look up the specific Key Vault, which populates the cache when found
+	v, err := c.lookupKeyVault(ctx, subscriptionId, *keyVaultName, existingId)
+	if err != nil {
+		return nil, fmt.Errorf("looking up Key Vault %q: %+v", *keyVaultName, err)
 	}
-
-	// Now that the cache has been repopulated, check if we have the key vault or not
-	if v, ok := keyVaultsCache[cacheKey]; ok {
+	if v != nil {
 		return &v.keyVaultId, nil
 	}
 
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
+)
+
+// lookupKeyVault resolves the Key Vault with the specified name on demand and adds it to the cache when found.
+//
+// When the ID of the Key Vault is already known (`existingId`) the Key Vault is retrieved directly using a GET.
+// Otherwise (or if it no longer exists) the ID of the Key Vault is looked up using a Resource Graph query filtered on
+// the Resource Type and Name - which spans every Subscription available to the credentials, allowing Key Vault items
+// to reference a Key Vault in a different Subscription - and then retrieved using a GET.
+func (c *Client) lookupKeyVault(ctx context.Context, subscriptionId commonids.SubscriptionId, keyVaultName string, existingId *commonids.KeyVaultId) (*keyVaultDetails, error) {
+	// the Resource Graph is eventually consistent, so the Key Vault is retrieved directly where possible
+	if existingId != nil && strings.EqualFold(existingId.VaultName, keyVaultName) {
+		details, err := c.retrieveKeyVault(ctx, *existingId)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			return details, nil
+		}
+
+		log.Printf("[DEBUG] %s no longer exists, looking up Key Vault %q using the Resource Graph", *existingId, keyVaultName)
+	}
+
+	ids, err := c.queryKeyVaultIds(ctx, keyVaultName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Key Vault names are globally unique, however the Resource Graph can briefly return a Key Vault which has
+	// since been deleted and recreated elsewhere - so the current Subscription is checked first
+	sort.SliceStable(ids, func(i, j int) bool {
+		return strings.EqualFold(ids[i].SubscriptionId, subscriptionId.SubscriptionId) && !strings.EqualFold(ids[j].SubscriptionId, subscriptionId.SubscriptionId)
+	})
+
+	for _, id := range ids {
+		details, err := c.retrieveKeyVault(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if details != nil {
+			return details, nil
+		}
+
+		log.Printf("[DEBUG] %s was returned from the Resource Graph but no longer exists, skipping", id)
+	}
+
+	return nil, nil
+}
+
+// queryKeyVaultIds returns the IDs of the Key Vaults with the specified name from the Resource Graph, across every
+// Subscription available to the credentials.
+func (c *Client) queryKeyVaultIds(ctx context.Context, keyVaultName string) ([]commonids.KeyVaultId, error) {
+	// NOTE: since the Resource Graph can return stale results, the Key Vaults returned are subsequently retrieved
+	// from the KeyVault Resource Provider to confirm that they exist and to obtain the Data Plane URI.
+	input := resourcegraph.QueryRequest{
+		Query: fmt.Sprintf("resources | where type =~ 'Microsoft.KeyVault/vaults' and name =~ '%s' | project id", strings.ReplaceAll(keyVaultName, "'", "\\'")),
+		Options: &resourcegraph.QueryRequestOptions{
+			ResultFormat: pointer.To(resourcegraph.ResultFormatObjectArray),
+		},
+	}
+	resp, err := c.resourceGraphClient.Resources(ctx, input)
+	if err != nil {
+		if response.WasForbidden(resp.HttpResponse) {
+			return nil, fmt.Errorf("querying the Resource Graph for Key Vault %q: the credentials used by Terraform require permission to read Key Vaults (`Microsoft.KeyVault/vaults/read`) in order to look these up: %+v", keyVaultName, err)
+		}
+		return nil, fmt.Errorf("querying the Resource Graph for Key Vault %q: %+v", keyVaultName, err)
+	}
+	if resp.Model == nil {
+		return nil, fmt.Errorf("querying the Resource Graph for Key Vault %q: `model` was nil", keyVaultName)
+	}
+
+	rows, ok := resp.Model.Data.([]interface{})
+	if !ok && resp.Model.Data != nil {
+		return nil, fmt.Errorf("querying the Resource Graph for Key Vault %q: expected a list of rows but got %T", keyVaultName, resp.Model.Data)
+	}
+
+	ids := make([]commonids.KeyVaultId, 0)
+	for _, row := range rows {
+		v, ok := row.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		rawId, ok := v["id"].(string)
+		if !ok || rawId == "" {
+			continue
+		}
+
+		id, err := commonids.ParseKeyVaultIDInsensitively(rawId)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q as a Key Vault ID: %+v", rawId, err)
+		}
+		ids = append(ids, *id)
+	}
+
+	return ids, nil
+}
+
+// retrieveKeyVault retrieves the specified Key Vault from the KeyVault Resource Provider and adds it to the
+// cache - returning nil if the Key Vault doesn't exist.
+func (c *Client) retrieveKeyVault(ctx context.Context, id commonids.KeyVaultId) (*keyVaultDetails, error) {
+	keyVault, err := c.vaults20230701Client.Get(ctx, id)
+	if err != nil {
+		if response.WasNotFound(keyVault.HttpResponse) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("retrieving %s: %+v", id, err)
+	}
+	if keyVault.Model == nil {
+		return nil, fmt.Errorf("retrieving %s: `model` was nil", id)
+	}
+	if keyVault.Model.Properties.VaultUri == nil {
+		return nil, fmt.Errorf("retrieving %s: `model.Properties.VaultUri` was nil", id)
+	}
+
+	dataPlaneUri := *keyVault.Model.Properties.VaultUri
+	c.AddToCache(id, dataPlaneUri)
+
+	return &keyVaultDetails{
+		keyVaultId:       id.ID(),
+		dataPlaneBaseUri: dataPlaneUri,
+		resourceGroup:    id.ResourceGroupName,
+	}, nil
+}
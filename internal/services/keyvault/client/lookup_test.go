@@ -0,0 +1,280 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+)
+
+// NOTE: the Key Vault cache is shared across the Provider, so each test uses distinct Subscription IDs
+// and Key Vault names to avoid observing entries cached by another test.
+
+func TestKeyVaultIDFromBaseUrl_currentSubscription(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000026")
+	first := server.putVault(subscriptionId, "lookup-current-1")
+	second := server.putVault(subscriptionId, "lookup-current-2")
+
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-current-1", &first)
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-current-2", &second)
+
+	// each Key Vault should be resolved using a targeted query, rather than by listing every Key Vault
+	listPath := fmt.Sprintf("%s/providers/Microsoft.KeyVault/vaults", subscriptionId.ID())
+	if count := server.countRequests("GET", listPath); count != 0 {
+		t.Fatalf("expected the Key Vaults within %s not to be listed but got %d requests", subscriptionId, count)
+	}
+	if count := server.countRequests("POST", testKeyVaultLookupResourceGraphPath); count != 2 {
+		t.Fatalf("expected 2 Resource Graph queries but got %d", count)
+	}
+	if count := server.countRequests("GET", first.ID()); count != 1 {
+		t.Fatalf("expected %s to be retrieved once but got %d requests", first, count)
+	}
+}
+
+func TestKeyVaultIDFromBaseUrl_otherSubscription(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000126")
+	otherSubscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000127")
+	expected := server.putVault(otherSubscriptionId, "lookup-other")
+
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-other", &expected)
+}
+
+func TestKeyVaultIDFromBaseUrl_notFound(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000226")
+	deleted := server.putVault(subscriptionId, "lookup-deleted")
+
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-missing", nil)
+
+	// a stale result from the Resource Graph should be ignored once the Key Vault no longer exists
+	server.Delete(deleted.ID())
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-deleted", nil)
+}
+
+func TestKeyVaultIDFromBaseUrl_createdAfterLookup(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000326")
+
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-created-later", nil)
+
+	// a Key Vault created after it was first looked up (e.g. outside of Terraform) should subsequently be found
+	expected := server.putVault(subscriptionId, "lookup-created-later")
+	testKeyVaultLookupExpectId(t, client, subscriptionId, "lookup-created-later", &expected)
+}
+
+func TestKeyVaultIDFromBaseUrl_forbidden(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000426")
+	forbidden := server.putVault(subscriptionId, "lookup-forbidden-vault")
+
+	// a Key Vault which can't be looked up must surface an error, rather than being treated as not found
+	server.forbidQueries(true)
+	testKeyVaultLookupExpectError(t, client, subscriptionId, "lookup-forbidden-query")
+	server.forbidQueries(false)
+
+	server.InjectError(http.MethodGet, forbidden.ID()+"$", 1, http.StatusForbidden, "AuthorizationFailed")
+	testKeyVaultLookupExpectError(t, client, subscriptionId, "lookup-forbidden-vault")
+}
+
+func TestKeyVaultIDFromBaseUrlAndExistingId(t *testing.T) {
+	server, client := testKeyVaultLookupServer(t, features.Default())
+	subscriptionId := commonids.NewSubscriptionID("00000000-0000-0000-0000-000000000526")
+	existing := server.putVault(subscriptionId, "lookup-existing")
+	moved := server.putVault(subscriptionId, "lookup-moved")
+	stale := commonids.NewKeyVaultID(subscriptionId.SubscriptionId, "deleted-resources", "lookup-moved")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// a known Key Vault should be retrieved directly rather than looked up using the Resource Graph
+	actual, err := client.KeyVault.KeyVaultIDFromBaseUrlAndExistingId(ctx, subscriptionId, "https://lookup-existing.vault.azure.net/", existing.ID())
+	if err != nil {
+		t.Fatalf("looking up %s: %+v", existing, err)
+	}
+	if actual == nil || !strings.EqualFold(*actual, existing.ID()) {
+		t.Fatalf("expected %s to be resolved but got %v", existing, actual)
+	}
+	if count := server.countRequests("POST", testKeyVaultLookupResourceGraphPath); count != 0 {
+		t.Fatalf("expected the Resource Graph not to be queried but got %d requests", count)
+	}
+
+	// whereas a Key Vault which no longer exists at the known ID (e.g. since it's been moved) should be looked up
+	actual, err = client.KeyVault.KeyVaultIDFromBaseUrlAndExistingId(ctx, subscriptionId, "https://lookup-moved.vault.azure.net/", stale.ID())
+	if err != nil {
+		t.Fatalf("looking up %s: %+v", moved, err)
+	}
+	if actual == nil || !strings.EqualFold(*actual, moved.ID()) {
+		t.Fatalf("expected %s to be resolved but got %v", moved, actual)
+	}
+	if count := server.countRequests("POST", testKeyVaultLookupResourceGraphPath); count != 1 {
+		t.Fatalf("expected the Resource Graph to be queried once but got %d requests", count)
+	}
+}
+
+const testKeyVaultLookupResourceGraphPath = "/providers/Microsoft.ResourceGraph/resources"
+
+var testKeyVaultLookupResourceGraphName = regexp.MustCompile(`name =~ '([^']+)'`)
+
+// testKeyVaultLookup wraps the mock ARM Server to additionally respond to Resource Graph queries for Key Vaults
+type testKeyVaultLookup struct {
+	*mockarm.Server
+
+	mutex    sync.Mutex
+	vaultIds []commonids.KeyVaultId
+
+	// queriesForbidden returns a 403 Forbidden for Resource Graph queries, as when the credentials lack permission
+	queriesForbidden bool
+}
+
+func testKeyVaultLookupServer(t *testing.T, userFeatures features.UserFeatures) (*testKeyVaultLookup, *clients.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	server := &testKeyVaultLookup{
+		Server: mockarm.NewServer(t),
+	}
+	server.AddHook(server.resourceGraphQuery)
+
+	client, err := server.Client(ctx, userFeatures)
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	return server, client
+}
+
+// resourceGraphQuery returns the IDs of the Key Vaults matching the name within the Resource Graph query - including
+// those which have since been deleted, since the Resource Graph can return stale results
+func (s *testKeyVaultLookup) resourceGraphQuery(r *http.Request) *mockarm.Response {
+	if r.Method != http.MethodPost || !strings.EqualFold(r.URL.Path, testKeyVaultLookupResourceGraphPath) {
+		return nil
+	}
+
+	s.mutex.Lock()
+	forbidden := s.queriesForbidden
+	s.mutex.Unlock()
+	if forbidden {
+		return &mockarm.Response{
+			StatusCode: http.StatusForbidden,
+			Body: map[string]interface{}{
+				"error": map[string]interface{}{
+					"code":    "AuthorizationFailed",
+					"message": "the client does not have authorization to perform a Resource Graph query",
+				},
+			},
+		}
+	}
+
+	var input struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		return &mockarm.Response{
+			StatusCode: http.StatusBadRequest,
+		}
+	}
+
+	rows := make([]interface{}, 0)
+	if match := testKeyVaultLookupResourceGraphName.FindStringSubmatch(input.Query); match != nil {
+		s.mutex.Lock()
+		for _, id := range s.vaultIds {
+			if strings.EqualFold(id.VaultName, match[1]) {
+				rows = append(rows, map[string]interface{}{
+					"id": id.ID(),
+				})
+			}
+		}
+		s.mutex.Unlock()
+	}
+
+	return &mockarm.Response{
+		StatusCode: http.StatusOK,
+		Body: map[string]interface{}{
+			"count":           len(rows),
+			"data":            rows,
+			"resultTruncated": "false",
+			"totalRecords":    len(rows),
+		},
+	}
+}
+
+func (s *testKeyVaultLookup) forbidQueries(forbidden bool) {
+	s.mutex.Lock()
+	s.queriesForbidden = forbidden
+	s.mutex.Unlock()
+}
+
+func (s *testKeyVaultLookup) putVault(subscriptionId commonids.SubscriptionId, name string) commonids.KeyVaultId {
+	id := commonids.NewKeyVaultID(subscriptionId.SubscriptionId, "resources", name)
+	s.Put(id.ID(), map[string]interface{}{
+		"location": "westeurope",
+		"properties": map[string]interface{}{
+			"tenantId": mockarm.TenantId,
+			"vaultUri": fmt.Sprintf("https://%s.vault.azure.net/", name),
+		},
+	})
+
+	s.mutex.Lock()
+	s.vaultIds = append(s.vaultIds, id)
+	s.mutex.Unlock()
+
+	return id
+}
+
+func (s *testKeyVaultLookup) countRequests(method, path string) int {
+	count := 0
+	for _, r := range s.Requests() {
+		if r.Method == method && strings.EqualFold(r.Path, path) {
+			count++
+		}
+	}
+	return count
+}
+
+func testKeyVaultLookupExpectId(t *testing.T, client *clients.Client, subscriptionId commonids.SubscriptionId, name string, expected *commonids.KeyVaultId) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	actual, err := client.KeyVault.KeyVaultIDFromBaseUrl(ctx, subscriptionId, fmt.Sprintf("https://%s.vault.azure.net/", name))
+	if err != nil {
+		t.Fatalf("looking up %q: %+v", name, err)
+	}
+
+	if expected == nil {
+		if actual != nil {
+			t.Fatalf("expected %q not to be found but got %q", name, *actual)
+		}
+		return
+	}
+	if actual == nil {
+		t.Fatalf("expected %q to be found but it wasn't", name)
+	}
+	if !strings.EqualFold(*actual, expected.ID()) {
+		t.Fatalf("expected %q to be resolved to %q but got %q", name, expected.ID(), *actual)
+	}
+}
+
+func testKeyVaultLookupExpectError(t *testing.T, client *clients.Client, subscriptionId commonids.SubscriptionId, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	actual, err := client.KeyVault.KeyVaultIDFromBaseUrl(ctx, subscriptionId, fmt.Sprintf("https://%s.vault.azure.net/", name))
+	if err == nil {
+		t.Fatalf("expected an error looking up %q but got %v", name, actual)
+	}
+}
@@ -199,7 +199,7 @@ func (r KeyVaultCertificateContactsResource) Read() sdk.ResourceFunc {
 			}
 
 			subscriptionResourceId := commonids.NewSubscriptionID(subscriptionId)
-			keyVaultIdRaw, err := vaultClient.KeyVaultIDFromBaseUrl(ctx, subscriptionResourceId, id.KeyVaultBaseUrl)
+			keyVaultIdRaw, err := vaultClient.KeyVaultIDFromBaseUrlAndExistingId(ctx, subscriptionResourceId, id.KeyVaultBaseUrl, metadata.ResourceData.Get("key_vault_id").(string))
 			if err != nil {
 				return fmt.Errorf("retrieving resource ID of the Key Vault at URL %s: %+v", id.KeyVaultBaseUrl, err)
 			}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package managedidentity_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+)
+
+func TestUserAssignedIdentity_mockUpdate(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_user_assigned_identity", "test")
+	data.UseMockServer(t, mockarm.NewServer(t))
+	r := UserAssignedIdentityTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("tags.%").HasValue("2"),
+				check.That(data.ResourceName).Key("tags.environment").HasValue("terraform-acctests"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestUserAssignedIdentity_mockRequiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_user_assigned_identity", "test")
+	data.UseMockServer(t, mockarm.NewServer(t))
+	r := UserAssignedIdentityTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestUserAssignedIdentity_mockCreateConflict(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_user_assigned_identity", "test")
+	server := mockarm.NewServer(t)
+	server.InjectConflict(http.MethodPut, "/userAssignedIdentities/", 1)
+	data.UseMockServer(t, server)
+	r := UserAssignedIdentityTestResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config:      r.basic(data),
+			ExpectError: regexp.MustCompile("Conflict"),
+		},
+	})
+}
@@ -0,0 +1,426 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/tags"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2024-03-01/deploymentstacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// deploymentStackSchema returns the schema shared between the Deployment Stack resources at each scope - where
+// `managementGroups` controls whether Management Groups can be managed by (and so unmanaged from) the Stack.
+func deploymentStackSchema(managementGroups bool) map[string]*pluginsdk.Schema {
+	actionOnUnmanage := map[string]*pluginsdk.Schema{
+		"resources": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(deploymentstacks.PossibleValuesForDeploymentStacksDeleteDetachEnum(), false),
+		},
+
+		"resource_groups": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      string(deploymentstacks.DeploymentStacksDeleteDetachEnumDetach),
+			ValidateFunc: validation.StringInSlice(deploymentstacks.PossibleValuesForDeploymentStacksDeleteDetachEnum(), false),
+		},
+	}
+	if managementGroups {
+		actionOnUnmanage["management_groups"] = &pluginsdk.Schema{
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			Default:      string(deploymentstacks.DeploymentStacksDeleteDetachEnumDetach),
+			ValidateFunc: validation.StringInSlice(deploymentstacks.PossibleValuesForDeploymentStacksDeleteDetachEnum(), false),
+		}
+	}
+
+	return map[string]*pluginsdk.Schema{
+		"action_on_unmanage": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: actionOnUnmanage,
+			},
+		},
+
+		"deny_settings": {
+			Type:     pluginsdk.TypeList,
+			Required: true,
+			MaxItems: 1,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"mode": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringInSlice(deploymentstacks.PossibleValuesForDenySettingsMode(), false),
+					},
+
+					"apply_to_child_scopes": {
+						Type:     pluginsdk.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+
+					"excluded_actions": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 200,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+
+					"excluded_principals": {
+						Type:     pluginsdk.TypeList,
+						Optional: true,
+						MaxItems: 5,
+						Elem: &pluginsdk.Schema{
+							Type:         pluginsdk.TypeString,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
+		},
+
+		"template_content": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			Computed: true,
+			ExactlyOneOf: []string{
+				"template_content",
+				"template_spec_version_id",
+			},
+			StateFunc: utils.NormalizeJson,
+		},
+
+		"template_spec_version_id": {
+			Type:     pluginsdk.TypeString,
+			Optional: true,
+			ExactlyOneOf: []string{
+				"template_content",
+				"template_spec_version_id",
+			},
+			ValidateFunc: validate.TemplateSpecVersionID,
+		},
+
+		"bypass_stack_out_of_sync_error": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 4096),
+		},
+
+		"parameters_content": {
+			Type:      pluginsdk.TypeString,
+			Optional:  true,
+			Computed:  true,
+			StateFunc: utils.NormalizeJson,
+		},
+
+		"tags": commonschema.Tags(),
+
+		"deployment_id": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"managed_resource_ids": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeString,
+			},
+		},
+
+		"output_content": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+			// NOTE: as with the Template Deployment resources, outputs can be strings, ints, objects etc - so these
+			// are exposed as JSON which can be parsed using `jsondecode`
+		},
+	}
+}
+
+func expandDeploymentStack(d *pluginsdk.ResourceData) (*deploymentstacks.DeploymentStack, error) {
+	properties := deploymentstacks.DeploymentStackProperties{
+		ActionOnUnmanage:          expandDeploymentStackActionOnUnmanage(d.Get("action_on_unmanage").([]interface{})),
+		BypassStackOutOfSyncError: pointer.To(d.Get("bypass_stack_out_of_sync_error").(bool)),
+		DenySettings:              expandDeploymentStackDenySettings(d.Get("deny_settings").([]interface{})),
+	}
+
+	if v := d.Get("description").(string); v != "" {
+		properties.Description = pointer.To(v)
+	}
+
+	if templateSpecVersionId := d.Get("template_spec_version_id").(string); templateSpecVersionId != "" {
+		properties.TemplateLink = &deploymentstacks.DeploymentStacksTemplateLink{
+			Id: pointer.To(templateSpecVersionId),
+		}
+	} else {
+		template, err := expandTemplateDeploymentContent(d.Get("template_content").(string))
+		if err != nil {
+			return nil, fmt.Errorf("expanding `template_content`: %+v", err)
+		}
+		properties.Template = template
+	}
+
+	if v := d.Get("parameters_content").(string); v != "" {
+		parameters := make(map[string]deploymentstacks.DeploymentParameter)
+		if err := json.Unmarshal([]byte(v), &parameters); err != nil {
+			return nil, fmt.Errorf("expanding `parameters_content`: %+v", err)
+		}
+		properties.Parameters = &parameters
+	}
+
+	return &deploymentstacks.DeploymentStack{
+		Properties: &properties,
+		Tags:       tags.Expand(d.Get("tags").(map[string]interface{})),
+	}, nil
+}
+
+func expandDeploymentStackActionOnUnmanage(input []interface{}) deploymentstacks.ActionOnUnmanage {
+	output := deploymentstacks.ActionOnUnmanage{
+		Resources: deploymentstacks.DeploymentStacksDeleteDetachEnumDetach,
+	}
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	output.Resources = deploymentstacks.DeploymentStacksDeleteDetachEnum(raw["resources"].(string))
+	if v, ok := raw["resource_groups"].(string); ok && v != "" {
+		output.ResourceGroups = pointer.To(deploymentstacks.DeploymentStacksDeleteDetachEnum(v))
+	}
+	if v, ok := raw["management_groups"].(string); ok && v != "" {
+		output.ManagementGroups = pointer.To(deploymentstacks.DeploymentStacksDeleteDetachEnum(v))
+	}
+
+	return output
+}
+
+func flattenDeploymentStackActionOnUnmanage(input deploymentstacks.ActionOnUnmanage, managementGroups bool) []interface{} {
+	output := map[string]interface{}{
+		"resources":       string(input.Resources),
+		"resource_groups": string(deploymentstacks.DeploymentStacksDeleteDetachEnumDetach),
+	}
+	if input.ResourceGroups != nil {
+		output["resource_groups"] = string(*input.ResourceGroups)
+	}
+	if managementGroups {
+		output["management_groups"] = string(deploymentstacks.DeploymentStacksDeleteDetachEnumDetach)
+		if input.ManagementGroups != nil {
+			output["management_groups"] = string(*input.ManagementGroups)
+		}
+	}
+
+	return []interface{}{
+		output,
+	}
+}
+
+func expandDeploymentStackDenySettings(input []interface{}) deploymentstacks.DenySettings {
+	output := deploymentstacks.DenySettings{
+		Mode: deploymentstacks.DenySettingsModeNone,
+	}
+	if len(input) == 0 || input[0] == nil {
+		return output
+	}
+
+	raw := input[0].(map[string]interface{})
+	output.Mode = deploymentstacks.DenySettingsMode(raw["mode"].(string))
+	output.ApplyToChildScopes = pointer.To(raw["apply_to_child_scopes"].(bool))
+	output.ExcludedActions = utils.ExpandStringSlice(raw["excluded_actions"].([]interface{}))
+	output.ExcludedPrincipals = utils.ExpandStringSlice(raw["excluded_principals"].([]interface{}))
+
+	return output
+}
+
+func flattenDeploymentStackDenySettings(input deploymentstacks.DenySettings) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"mode":                  string(input.Mode),
+			"apply_to_child_scopes": pointer.From(input.ApplyToChildScopes),
+			"excluded_actions":      utils.FlattenStringSlice(input.ExcludedActions),
+			"excluded_principals":   utils.FlattenStringSlice(input.ExcludedPrincipals),
+		},
+	}
+}
+
+// flattenDeploymentStack sets the fields shared between the Deployment Stack resources into the state, using the
+// Template exported from the Stack for `template_content`.
+func flattenDeploymentStack(d *pluginsdk.ResourceData, input *deploymentstacks.DeploymentStack, template *deploymentstacks.DeploymentStackTemplateDefinition, managementGroups bool) error {
+	if input == nil {
+		return nil
+	}
+
+	if props := input.Properties; props != nil {
+		d.Set("action_on_unmanage", flattenDeploymentStackActionOnUnmanage(props.ActionOnUnmanage, managementGroups))
+		d.Set("bypass_stack_out_of_sync_error", pointer.From(props.BypassStackOutOfSyncError))
+		d.Set("deny_settings", flattenDeploymentStackDenySettings(props.DenySettings))
+		d.Set("deployment_id", pointer.From(props.DeploymentId))
+		d.Set("description", pointer.From(props.Description))
+
+		managedResourceIds := make([]string, 0)
+		if props.Resources != nil {
+			for _, item := range *props.Resources {
+				if item.Id != nil {
+					managedResourceIds = append(managedResourceIds, *item.Id)
+				}
+			}
+		}
+		d.Set("managed_resource_ids", managedResourceIds)
+
+		flattenedOutputs, err := flattenTemplateDeploymentBody(pointer.From(props.Outputs))
+		if err != nil {
+			return fmt.Errorf("flattening `output_content`: %+v", err)
+		}
+		d.Set("output_content", flattenedOutputs)
+
+		// the parameters are round-tripped via JSON so that the `type` can be filtered out, as for Template Deployments
+		var parameters interface{}
+		if props.Parameters != nil {
+			raw, err := json.Marshal(*props.Parameters)
+			if err != nil {
+				return fmt.Errorf("marshalling `parameters_content`: %+v", err)
+			}
+			if err := json.Unmarshal(raw, &parameters); err != nil {
+				return fmt.Errorf("unmarshalling `parameters_content`: %+v", err)
+			}
+		}
+		flattenedParams, err := flattenTemplateDeploymentBody(filterOutTemplateDeploymentParameters(parameters))
+		if err != nil {
+			return fmt.Errorf("flattening `parameters_content`: %+v", err)
+		}
+		d.Set("parameters_content", flattenedParams)
+
+		templateSpecVersionId := ""
+		if props.TemplateLink != nil {
+			templateSpecVersionId = pointer.From(props.TemplateLink.Id)
+		}
+		d.Set("template_spec_version_id", templateSpecVersionId)
+	}
+
+	if template != nil {
+		flattenedTemplate, err := flattenTemplateDeploymentBody(pointer.From(template.Template))
+		if err != nil {
+			return fmt.Errorf("flattening `template_content`: %+v", err)
+		}
+		d.Set("template_content", flattenedTemplate)
+	}
+
+	return tags.FlattenAndSet(d, input.Tags)
+}
+
+// expandDeploymentStackDeleteOptions builds the options used when deleting a Deployment Stack from the
+// `action_on_unmanage` block, so that the managed resources are deleted or detached as configured.
+func expandDeploymentStackDeleteOptions(d *pluginsdk.ResourceData) deploymentstacks.DeleteAtSubscriptionOperationOptions {
+	actionOnUnmanage := expandDeploymentStackActionOnUnmanage(d.Get("action_on_unmanage").([]interface{}))
+
+	options := deploymentstacks.DeleteAtSubscriptionOperationOptions{
+		BypassStackOutOfSyncError: pointer.To(d.Get("bypass_stack_out_of_sync_error").(bool)),
+		UnmanageActionResources:   pointer.To(deploymentstacks.UnmanageActionResourceMode(actionOnUnmanage.Resources)),
+	}
+	if actionOnUnmanage.ResourceGroups != nil {
+		options.UnmanageActionResourceGroups = pointer.To(deploymentstacks.UnmanageActionResourceGroupMode(*actionOnUnmanage.ResourceGroups))
+	}
+	if actionOnUnmanage.ManagementGroups != nil {
+		options.UnmanageActionManagementGroups = pointer.To(deploymentstacks.UnmanageActionManagementGroupMode(*actionOnUnmanage.ManagementGroups))
+	}
+
+	return options
+}
+
+// deploymentStackError returns an error detailing why the Deployment Stack failed to be provisioned or deleted,
+// including any resources which couldn't be deployed, deleted or detached - or nil if the Stack hasn't failed.
+func deploymentStackError(input *deploymentstacks.DeploymentStack) error {
+	if input == nil || input.Properties == nil {
+		return nil
+	}
+	props := input.Properties
+	if props.ProvisioningState == nil || *props.ProvisioningState != deploymentstacks.DeploymentStackProvisioningStateFailed {
+		return nil
+	}
+
+	message := "the Deployment Stack is in a Failed state"
+	if props.Error != nil {
+		message = fmt.Sprintf("%s: %s", pointer.From(props.Error.Code), pointer.From(props.Error.Message))
+	}
+
+	failed := make([]string, 0)
+	if props.FailedResources != nil {
+		for _, item := range *props.FailedResources {
+			line := pointer.From(item.Id)
+			if item.Error != nil {
+				line = fmt.Sprintf("%s (%s)", line, pointer.From(item.Error.Message))
+			}
+			failed = append(failed, line)
+		}
+	}
+	if len(failed) > 0 {
+		message = fmt.Sprintf("%s\n\nThe following resources failed:\n\n%s", message, strings.Join(failed, "\n"))
+	}
+
+	return fmt.Errorf("%s", message)
+}
+
+// waitForDeploymentStackToBeDeleted waits for the Deployment Stack to be removed - since the deletion of the Stack
+// completes before any managed resources have been deleted, the Stack continues to exist (in a Deleting state)
+// until these are removed, or transitions to a Failed state if these can't be deleted.
+func waitForDeploymentStackToBeDeleted(ctx context.Context, id fmt.Stringer, get func(ctx context.Context) (*http.Response, *deploymentstacks.DeploymentStack, error)) error {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return fmt.Errorf("internal-error: context had no deadline")
+	}
+
+	stateConf := &pluginsdk.StateChangeConf{
+		Pending: []string{"Deleting"},
+		Target:  []string{"NotFound"},
+		Refresh: func() (interface{}, string, error) {
+			resp, model, err := get(ctx)
+			if err != nil {
+				if response.WasNotFound(resp) {
+					return "NotFound", "NotFound", nil
+				}
+				return nil, "Error", err
+			}
+
+			if err := deploymentStackError(model); err != nil {
+				return nil, "Failed", err
+			}
+
+			log.Printf("[DEBUG] %s still exists - waiting for the managed resources to be removed..", id)
+			return "Deleting", "Deleting", nil
+		},
+		MinTimeout:                10 * time.Second,
+		ContinuousTargetOccurence: 3,
+		Timeout:                   time.Until(deadline),
+	}
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("waiting for %s to be deleted: %+v", id, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import "time"
+
+// these allow the ordered deletion of the Resources within a Resource Group to be tested against the Mock ARM
+// Server, which can't be imported from within this package
+
+var (
+	ResourceGroupDeletionBatchForType       = resourceGroupDeletionBatchForType
+	DeleteResourceGroupNestedResourcesBatch = deleteResourceGroupNestedResourcesBatch
+)
+
+// SetResourceGroupDeletionRetryInterval overrides the time waited between each attempt to delete a batch, returning
+// a function which resets it
+func SetResourceGroupDeletionRetryInterval(interval time.Duration) func() {
+	previous := resourceGroupDeletionRetryInterval
+	resourceGroupDeletionRetryInterval = interval
+	return func() {
+		resourceGroupDeletionRetryInterval = previous
+	}
+}
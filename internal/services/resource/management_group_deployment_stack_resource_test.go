@@ -0,0 +1,125 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2024-03-01/deploymentstacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ManagementGroupDeploymentStackResource struct{}
+
+func TestAccManagementGroupDeploymentStack_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_management_group_deployment_stack", "test")
+	r := ManagementGroupDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withTags(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (ManagementGroupDeploymentStackResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := deploymentstacks.ParseProviders2DeploymentStackID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Resource.DeploymentStacksClient.GetAtManagementGroup(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (ManagementGroupDeploymentStackResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_management_group" "test" {
+  name = "TestAcc-Stack-%[1]d"
+}
+
+resource "azurerm_management_group_deployment_stack" "test" {
+  name                = "acctest-stack-%[1]d"
+  management_group_id = azurerm_management_group.test.id
+  location            = %[2]q
+
+  action_on_unmanage {
+    resources         = "delete"
+    management_groups = "detach"
+  }
+
+  deny_settings {
+    mode = "none"
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-08-01/managementGroupDeploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (ManagementGroupDeploymentStackResource) withTags(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_management_group" "test" {
+  name = "TestAcc-Stack-%[1]d"
+}
+
+resource "azurerm_management_group_deployment_stack" "test" {
+  name                = "acctest-stack-%[1]d"
+  management_group_id = azurerm_management_group.test.id
+  location            = %[2]q
+
+  action_on_unmanage {
+    resources         = "delete"
+    management_groups = "detach"
+  }
+
+  deny_settings {
+    mode = "none"
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-08-01/managementGroupDeploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+
+  tags = {
+    environment = "testing"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
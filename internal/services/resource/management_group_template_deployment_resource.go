@@ -108,6 +108,8 @@ func managementGroupTemplateDeploymentResource() *pluginsdk.Resource {
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
 		},
+
+		CustomizeDiff: templateDeploymentWhatIfCustomizeDiff("azurerm_management_group_template_deployment", resources.DeploymentModeIncremental, []string{"template_content", "template_spec_version_id", "parameters_content"}, managementGroupTemplateDeploymentWhatIf),
 	}
 }
 
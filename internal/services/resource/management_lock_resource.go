@@ -12,6 +12,7 @@ import (
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/managementlocks"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
@@ -21,8 +22,9 @@ import (
 
 func resourceManagementLock() *pluginsdk.Resource {
 	return &pluginsdk.Resource{
-		Create: resourceManagementLockCreate,
+		Create: resourceManagementLockCreateUpdate,
 		Read:   resourceManagementLockRead,
+		Update: resourceManagementLockCreateUpdate,
 		Delete: resourceManagementLockDelete,
 
 		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
@@ -33,6 +35,7 @@ func resourceManagementLock() *pluginsdk.Resource {
 		Timeouts: &pluginsdk.ResourceTimeout{
 			Create: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(30 * time.Minute),
 			Delete: pluginsdk.DefaultTimeout(30 * time.Minute),
 		},
 
@@ -66,13 +69,19 @@ func resourceManagementLock() *pluginsdk.Resource {
 				ForceNew:     true,
 				ValidateFunc: validation.StringLenBetween(0, 512),
 			},
+
+			"lift_during_nested_changes_enabled": {
+				Type:     pluginsdk.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 		},
 	}
 }
 
-func resourceManagementLockCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+func resourceManagementLockCreateUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
 	client := meta.(*clients.Client).Resource.LocksClient
-	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	ctx, cancel := timeouts.ForCreateUpdate(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
 	id := managementlocks.NewScopedLockID(d.Get("scope").(string), d.Get("name").(string))
@@ -96,10 +105,27 @@ func resourceManagementLockCreate(d *pluginsdk.ResourceData, meta interface{}) e
 		},
 	}
 
+	// the principal Terraform is running as is recorded as an Owner of the Management Lock, which marks it as one
+	// which Terraform may temporarily remove to modify the Resources within its scope
+	if d.Get("lift_during_nested_changes_enabled").(bool) {
+		payload.Properties.Owners = &[]managementlocks.ManagementLockOwner{
+			{
+				ApplicationId: utils.String(meta.(*clients.Client).Account.ClientId),
+			},
+		}
+	}
+
 	if _, err := client.CreateOrUpdateByScope(ctx, id, payload); err != nil {
+		if !d.IsNewResource() {
+			return fmt.Errorf("updating %s: %+v", id, err)
+		}
 		return fmt.Errorf("creating %s: %+v", id, err)
 	}
 
+	if !d.IsNewResource() {
+		return resourceManagementLockRead(d, meta)
+	}
+
 	deadline, ok := ctx.Deadline()
 	if !ok {
 		return fmt.Errorf("internal-error: context was missing a deadline")
@@ -148,6 +174,7 @@ func resourceManagementLockRead(d *pluginsdk.ResourceData, meta interface{}) err
 	if model := resp.Model; model != nil {
 		d.Set("lock_level", string(model.Properties.Level))
 		d.Set("notes", model.Properties.Notes)
+		d.Set("lift_during_nested_changes_enabled", locks.IsOwnedBy(model.Properties, meta.(*clients.Client).Account.ClientId))
 	}
 
 	return nil
@@ -94,6 +94,38 @@ func TestAccManagementLock_resourceGroupCanNotDeleteComplete(t *testing.T) {
 	})
 }
 
+func TestAccManagementLock_liftDuringNestedChanges(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_management_lock", "test")
+	r := ManagementLockResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.resourceGroupCanNotDeleteBasic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("lift_during_nested_changes_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.liftDuringNestedChanges(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("lift_during_nested_changes_enabled").HasValue("true"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.liftDuringNestedChanges(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("lift_during_nested_changes_enabled").HasValue("false"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
 func TestAccManagementLock_publicIPReadOnlyBasic(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_management_lock", "test")
 	r := ManagementLockResource{}
@@ -248,6 +280,26 @@ resource "azurerm_management_lock" "test" {
 `, data.RandomInteger, data.Locations.Primary, data.RandomInteger)
 }
 
+func (ManagementLockResource) liftDuringNestedChanges(data acceptance.TestData, enabled bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_management_lock" "test" {
+  name                               = "acctestlock-%d"
+  scope                              = azurerm_resource_group.test.id
+  lock_level                         = "CanNotDelete"
+  lift_during_nested_changes_enabled = %t
+}
+`, data.RandomInteger, data.Locations.Primary, data.RandomInteger, enabled)
+}
+
 func (ManagementLockResource) resourceGroupCanNotDeleteComplete(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -47,9 +47,12 @@ func (r Registration) SupportedDataSources() map[string]*pluginsdk.Resource {
 func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 	resources := map[string]*pluginsdk.Resource{
 		"azurerm_management_lock":                      resourceManagementLock(),
+		"azurerm_management_group_deployment_stack":    managementGroupDeploymentStackResource(),
 		"azurerm_management_group_template_deployment": managementGroupTemplateDeploymentResource(),
 		"azurerm_resource_group":                       resourceResourceGroup(),
+		"azurerm_resource_group_deployment_stack":      resourceGroupDeploymentStackResource(),
 		"azurerm_resource_group_template_deployment":   resourceGroupTemplateDeploymentResource(),
+		"azurerm_subscription_deployment_stack":        subscriptionDeploymentStackResource(),
 		"azurerm_subscription_template_deployment":     subscriptionTemplateDeploymentResource(),
 		"azurerm_tenant_template_deployment":           tenantTemplateDeploymentResource(),
 	}
@@ -63,7 +66,9 @@ func (r Registration) SupportedResources() map[string]*pluginsdk.Resource {
 
 // DataSources returns a list of Data Sources supported by this Service
 func (r Registration) DataSources() []sdk.DataSource {
-	return []sdk.DataSource{}
+	return []sdk.DataSource{
+		ResourceGraphQueryDataSource{},
+	}
 }
 
 // Resources returns a list of Resources supported by this Service
@@ -74,5 +79,7 @@ func (r Registration) Resources() []sdk.Resource {
 		ResourceManagementPrivateLinkResource{},
 		ResourceDeploymentScriptAzurePowerShellResource{},
 		ResourceDeploymentScriptAzureCliResource{},
+		TemplateSpecResource{},
+		TemplateSpecVersionResource{},
 	}
 }
@@ -0,0 +1,425 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	resourcegraph "github.com/hashicorp/go-azure-sdk/resource-manager/resourcegraph/2022-10-01/resources"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+// resourceGraphQueryPageSize is the maximum number of rows the Resource Graph API returns per request
+const resourceGraphQueryPageSize = 1000
+
+type ResourceGraphQueryDataSource struct{}
+
+var _ sdk.DataSource = ResourceGraphQueryDataSource{}
+
+type ResourceGraphQueryDataSourceModel struct {
+	Query              string                         `tfschema:"query"`
+	SubscriptionIds    []string                       `tfschema:"subscription_ids"`
+	ManagementGroupIds []string                       `tfschema:"management_group_ids"`
+	AllowPartialScopes bool                           `tfschema:"allow_partial_scopes"`
+	MaximumResults     int64                          `tfschema:"maximum_results"`
+	Facet              []ResourceGraphQueryFacetModel `tfschema:"facet"`
+
+	// NOTE: `results` is set directly, since the SDK doesn't support encoding a list of maps
+	ResultsJson     string                               `tfschema:"results_json"`
+	ResultTruncated bool                                 `tfschema:"result_truncated"`
+	TotalRecords    int64                                `tfschema:"total_records"`
+	FacetResults    []ResourceGraphQueryFacetResultModel `tfschema:"facet_result"`
+}
+
+type ResourceGraphQueryFacetModel struct {
+	Expression string `tfschema:"expression"`
+	Filter     string `tfschema:"filter"`
+	SortBy     string `tfschema:"sort_by"`
+	SortOrder  string `tfschema:"sort_order"`
+	Top        int64  `tfschema:"top"`
+}
+
+type ResourceGraphQueryFacetResultModel struct {
+	Expression   string   `tfschema:"expression"`
+	Count        int64    `tfschema:"count"`
+	TotalRecords int64    `tfschema:"total_records"`
+	DataJson     string   `tfschema:"data_json"`
+	Errors       []string `tfschema:"errors"`
+}
+
+func (r ResourceGraphQueryDataSource) ResourceType() string {
+	return "azurerm_resource_graph_query"
+}
+
+func (r ResourceGraphQueryDataSource) ModelObject() interface{} {
+	return &ResourceGraphQueryDataSourceModel{}
+}
+
+func (r ResourceGraphQueryDataSource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"query": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+
+		"subscription_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.IsUUID,
+			},
+			ConflictsWith: []string{"management_group_ids"},
+		},
+
+		"management_group_ids": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			MinItems: 1,
+			Elem: &pluginsdk.Schema{
+				Type:         pluginsdk.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			ConflictsWith: []string{"subscription_ids"},
+		},
+
+		"allow_partial_scopes": {
+			Type:     pluginsdk.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+
+		"maximum_results": {
+			Type:         pluginsdk.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+		},
+
+		"facet": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"expression": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"filter": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"sort_by": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"sort_order": {
+						Type:         pluginsdk.TypeString,
+						Optional:     true,
+						ValidateFunc: validation.StringInSlice(resourcegraph.PossibleValuesForFacetSortOrder(), false),
+					},
+
+					"top": {
+						Type:         pluginsdk.TypeInt,
+						Optional:     true,
+						ValidateFunc: validation.IntBetween(1, 1000),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ResourceGraphQueryDataSource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"results": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Schema{
+				Type: pluginsdk.TypeMap,
+				Elem: &pluginsdk.Schema{
+					Type: pluginsdk.TypeString,
+				},
+			},
+		},
+
+		"results_json": {
+			Type:     pluginsdk.TypeString,
+			Computed: true,
+		},
+
+		"result_truncated": {
+			Type:     pluginsdk.TypeBool,
+			Computed: true,
+		},
+
+		"total_records": {
+			Type:     pluginsdk.TypeInt,
+			Computed: true,
+		},
+
+		"facet_result": {
+			Type:     pluginsdk.TypeList,
+			Computed: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"expression": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"count": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"total_records": {
+						Type:     pluginsdk.TypeInt,
+						Computed: true,
+					},
+
+					"data_json": {
+						Type:     pluginsdk.TypeString,
+						Computed: true,
+					},
+
+					"errors": {
+						Type:     pluginsdk.TypeList,
+						Computed: true,
+						Elem: &pluginsdk.Schema{
+							Type: pluginsdk.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r ResourceGraphQueryDataSource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 10 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.ResourceGraphClient
+
+			var state ResourceGraphQueryDataSourceModel
+			if err := metadata.Decode(&state); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			input := resourcegraph.QueryRequest{
+				Query:  state.Query,
+				Facets: expandResourceGraphQueryFacets(state.Facet),
+				Options: &resourcegraph.QueryRequestOptions{
+					AllowPartialScopes: pointer.To(state.AllowPartialScopes),
+					ResultFormat:       pointer.To(resourcegraph.ResultFormatObjectArray),
+				},
+			}
+
+			switch {
+			case len(state.ManagementGroupIds) > 0:
+				managementGroups := make([]string, 0)
+				for _, v := range state.ManagementGroupIds {
+					// the API expects the Name of each Management Group, rather than the Resource ID
+					if id, err := commonids.ParseManagementGroupIDInsensitively(v); err == nil {
+						v = id.GroupId
+					}
+					managementGroups = append(managementGroups, v)
+				}
+				input.ManagementGroups = pointer.To(managementGroups)
+
+			case len(state.SubscriptionIds) > 0:
+				input.Subscriptions = pointer.To(state.SubscriptionIds)
+
+			default:
+				input.Subscriptions = pointer.To([]string{metadata.Client.Account.SubscriptionId})
+			}
+
+			rows := make([]interface{}, 0)
+			state.FacetResults = make([]ResourceGraphQueryFacetResultModel, 0)
+			for {
+				top := int64(resourceGraphQueryPageSize)
+				if state.MaximumResults > 0 && state.MaximumResults-int64(len(rows)) < top {
+					top = state.MaximumResults - int64(len(rows))
+				}
+				input.Options.Top = pointer.To(top)
+
+				resp, err := client.Resources(ctx, input)
+				if err != nil {
+					return fmt.Errorf("running the Resource Graph query: %+v", err)
+				}
+				if resp.Model == nil {
+					return fmt.Errorf("running the Resource Graph query: `model` was nil")
+				}
+
+				page, err := flattenResourceGraphQueryData(resp.Model.Data)
+				if err != nil {
+					return err
+				}
+				rows = append(rows, page...)
+
+				// facets are evaluated against the entire result set, so are only returned for the first page
+				if input.Options.SkipToken == nil {
+					state.TotalRecords = resp.Model.TotalRecords
+					state.ResultTruncated = resp.Model.ResultTruncated == resourcegraph.ResultTruncatedTrue
+					state.FacetResults, err = flattenResourceGraphQueryFacets(resp.Model.Facets)
+					if err != nil {
+						return err
+					}
+					input.Facets = nil
+				}
+
+				if resp.Model.SkipToken == nil || *resp.Model.SkipToken == "" {
+					break
+				}
+				if state.MaximumResults > 0 && int64(len(rows)) >= state.MaximumResults {
+					break
+				}
+				input.Options.SkipToken = resp.Model.SkipToken
+			}
+
+			results := make([]interface{}, 0)
+			for _, row := range rows {
+				result, err := flattenResourceGraphQueryRow(row)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+
+			resultsJson, err := json.Marshal(rows)
+			if err != nil {
+				return fmt.Errorf("serializing `results_json`: %+v", err)
+			}
+			state.ResultsJson = string(resultsJson)
+
+			metadata.ResourceData.SetId(fmt.Sprintf("resourceGraphQuery/%s", time.Now().UTC().String()))
+
+			if err := metadata.ResourceData.Set("results", results); err != nil {
+				return fmt.Errorf("setting `results`: %+v", err)
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func expandResourceGraphQueryFacets(input []ResourceGraphQueryFacetModel) *[]resourcegraph.FacetRequest {
+	if len(input) == 0 {
+		return nil
+	}
+
+	output := make([]resourcegraph.FacetRequest, 0)
+	for _, v := range input {
+		facet := resourcegraph.FacetRequest{
+			Expression: v.Expression,
+			Options:    &resourcegraph.FacetRequestOptions{},
+		}
+		if v.Filter != "" {
+			facet.Options.Filter = pointer.To(v.Filter)
+		}
+		if v.SortBy != "" {
+			facet.Options.SortBy = pointer.To(v.SortBy)
+		}
+		if v.SortOrder != "" {
+			facet.Options.SortOrder = pointer.To(resourcegraph.FacetSortOrder(v.SortOrder))
+		}
+		if v.Top > 0 {
+			facet.Options.Top = pointer.To(v.Top)
+		}
+		output = append(output, facet)
+	}
+
+	return &output
+}
+
+func flattenResourceGraphQueryFacets(input *[]resourcegraph.Facet) ([]ResourceGraphQueryFacetResultModel, error) {
+	output := make([]ResourceGraphQueryFacetResultModel, 0)
+	if input == nil {
+		return output, nil
+	}
+
+	for _, item := range *input {
+		switch v := item.(type) {
+		case resourcegraph.FacetResult:
+			data, err := json.Marshal(v.Data)
+			if err != nil {
+				return nil, fmt.Errorf("serializing the data for the facet %q: %+v", v.Expression, err)
+			}
+			output = append(output, ResourceGraphQueryFacetResultModel{
+				Expression:   v.Expression,
+				Count:        v.Count,
+				TotalRecords: v.TotalRecords,
+				DataJson:     string(data),
+				Errors:       make([]string, 0),
+			})
+
+		case resourcegraph.FacetError:
+			errors := make([]string, 0)
+			for _, e := range v.Errors {
+				errors = append(errors, fmt.Sprintf("%s: %s", e.Code, e.Message))
+			}
+			output = append(output, ResourceGraphQueryFacetResultModel{
+				Expression: v.Expression,
+				Errors:     errors,
+			})
+		}
+	}
+
+	return output, nil
+}
+
+func flattenResourceGraphQueryData(input interface{}) ([]interface{}, error) {
+	if input == nil {
+		return []interface{}{}, nil
+	}
+
+	rows, ok := input.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected the Resource Graph query to return a list of rows but got %T", input)
+	}
+
+	return rows, nil
+}
+
+// flattenResourceGraphQueryRow flattens a single row returned from the Resource Graph API into a map of strings,
+// where any values which aren't strings (e.g. numbers, or nested objects such as `properties`) are JSON-encoded.
+func flattenResourceGraphQueryRow(input interface{}) (map[string]interface{}, error) {
+	row, ok := input.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected each row returned from the Resource Graph query to be an object but got %T", input)
+	}
+
+	output := make(map[string]interface{})
+	for key, value := range row {
+		switch v := value.(type) {
+		case nil:
+			output[key] = ""
+		case string:
+			output[key] = v
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("serializing the column %q: %+v", key, err)
+			}
+			output[key] = string(encoded)
+		}
+	}
+
+	return output, nil
+}
@@ -0,0 +1,153 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+)
+
+type ResourceGraphQueryDataSource struct{}
+
+func TestAccDataSourceResourceGraphQuery_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("results.#").HasValue("2"),
+				check.That(data.ResourceName).Key("results.0.environment").HasValue("production"),
+				check.That(data.ResourceName).Key("results_json").IsNotEmpty(),
+				check.That(data.ResourceName).Key("total_records").HasValue("2"),
+				check.That(data.ResourceName).Key("result_truncated").HasValue("false"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceGraphQuery_paged(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.maximumResults(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("results.#").HasValue("1"),
+				check.That(data.ResourceName).Key("total_records").HasValue("2"),
+			),
+		},
+	})
+}
+
+func TestAccDataSourceResourceGraphQuery_facet(t *testing.T) {
+	data := acceptance.BuildTestData(t, "data.azurerm_resource_graph_query", "test")
+	r := ResourceGraphQueryDataSource{}
+
+	data.DataSourceTest(t, []acceptance.TestStep{
+		{
+			Config: r.template(data),
+		},
+		{
+			Config: r.facet(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).Key("facet_result.#").HasValue("1"),
+				check.That(data.ResourceName).Key("facet_result.0.expression").HasValue("type"),
+				check.That(data.ResourceName).Key("facet_result.0.count").HasValue("1"),
+				check.That(data.ResourceName).Key("facet_result.0.data_json").IsNotEmpty(),
+			),
+		},
+	})
+}
+
+func (r ResourceGraphQueryDataSource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query = <<QUERY
+Resources
+| where resourceGroup =~ '${azurerm_resource_group.test.name}'
+| project id, name, type, environment = tostring(tags['environment'])
+| order by name asc
+QUERY
+}
+`, r.template(data))
+}
+
+func (r ResourceGraphQueryDataSource) maximumResults(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query           = "Resources | where resourceGroup =~ '${azurerm_resource_group.test.name}' | project id, name"
+  maximum_results = 1
+}
+`, r.template(data))
+}
+
+func (r ResourceGraphQueryDataSource) facet(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_resource_graph_query" "test" {
+  query            = "Resources | where resourceGroup =~ '${azurerm_resource_group.test.name}' | project id, name, type"
+  subscription_ids = [data.azurerm_client_config.current.subscription_id]
+
+  facet {
+    expression = "type"
+    sort_order = "desc"
+    top        = 10
+  }
+}
+`, r.template(data))
+}
+
+func (ResourceGraphQueryDataSource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-rgq-%[1]d"
+  location = "%[2]s"
+}
+
+resource "azurerm_virtual_network" "first" {
+  name                = "acctestvnet1-%[1]d"
+  address_space       = ["10.0.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  tags = {
+    environment = "production"
+  }
+}
+
+resource "azurerm_virtual_network" "second" {
+  name                = "acctestvnet2-%[1]d"
+  address_space       = ["10.1.0.0/16"]
+  location            = azurerm_resource_group.test.location
+  resource_group_name = azurerm_resource_group.test.name
+
+  tags = {
+    environment = "staging"
+  }
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
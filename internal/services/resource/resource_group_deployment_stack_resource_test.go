@@ -0,0 +1,284 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2024-03-01/deploymentstacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type ResourceGroupDeploymentStackResource struct{}
+
+func TestAccResourceGroupDeploymentStack_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_deployment_stack", "test")
+	r := ResourceGroupDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccResourceGroupDeploymentStack_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_deployment_stack", "test")
+	r := ResourceGroupDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccResourceGroupDeploymentStack_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_deployment_stack", "test")
+	r := ResourceGroupDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withResources(data, "first", "denyDelete"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("managed_resource_ids.#").HasValue("1"),
+				check.That(data.ResourceName).Key("output_content").HasValue("{\"tagValue\":{\"type\":\"String\",\"value\":\"first\"}}"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.withResources(data, "second", "denyWriteAndDelete"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("output_content").HasValue("{\"tagValue\":{\"type\":\"String\",\"value\":\"second\"}}"),
+			),
+		},
+		data.ImportStep(),
+		{
+			// removing the resource from the template detaches/deletes it according to `action_on_unmanage`
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("managed_resource_ids.#").HasValue("0"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccResourceGroupDeploymentStack_templateSpecVersion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group_deployment_stack", "test")
+	r := ResourceGroupDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.templateSpecVersion(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (ResourceGroupDeploymentStackResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := deploymentstacks.ParseProviderDeploymentStackID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Resource.DeploymentStacksClient.GetAtResourceGroup(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r ResourceGroupDeploymentStackResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource_group_deployment_stack" "test" {
+  name                = "acctest-stack-%d"
+  resource_group_name = azurerm_resource_group.test.name
+
+  action_on_unmanage {
+    resources = "delete"
+  }
+
+  deny_settings {
+    mode = "none"
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r ResourceGroupDeploymentStackResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_resource_group_deployment_stack" "import" {
+  name                = azurerm_resource_group_deployment_stack.test.name
+  resource_group_name = azurerm_resource_group_deployment_stack.test.resource_group_name
+
+  action_on_unmanage {
+    resources = "delete"
+  }
+
+  deny_settings {
+    mode = "none"
+  }
+
+  template_content = azurerm_resource_group_deployment_stack.test.template_content
+}
+`, r.basic(data))
+}
+
+func (r ResourceGroupDeploymentStackResource) withResources(data acceptance.TestData, tagValue string, denyMode string) string {
+	return fmt.Sprintf(`
+%s
+
+data "azurerm_client_config" "current" {}
+
+resource "azurerm_resource_group_deployment_stack" "test" {
+  name                = "acctest-stack-%d"
+  resource_group_name = azurerm_resource_group.test.name
+  description         = "Acceptance Test Deployment Stack"
+
+  action_on_unmanage {
+    resources       = "delete"
+    resource_groups = "delete"
+  }
+
+  deny_settings {
+    mode                  = %q
+    apply_to_child_scopes = true
+    excluded_actions      = ["Microsoft.Resources/tags/write"]
+    excluded_principals   = [data.azurerm_client_config.current.object_id]
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    parameters = {
+      tagValue = {
+        type = "String"
+      }
+    }
+    resources = [
+      {
+        type       = "Microsoft.Network/publicIPAddresses"
+        apiVersion = "2023-09-01"
+        name       = "acctestpip-%d"
+        location   = "[resourceGroup().location]"
+        sku = {
+          name = "Standard"
+        }
+        properties = {
+          publicIPAllocationMethod = "Static"
+        }
+        tags = {
+          Hello = "[parameters('tagValue')]"
+        }
+      }
+    ]
+    outputs = {
+      tagValue = {
+        type  = "String"
+        value = "[parameters('tagValue')]"
+      }
+    }
+  })
+
+  parameters_content = jsonencode({
+    tagValue = {
+      value = %q
+    }
+  })
+
+  tags = {
+    environment = "testing"
+  }
+}
+`, r.template(data), data.RandomInteger, denyMode, data.RandomInteger, tagValue)
+}
+
+func (r ResourceGroupDeploymentStackResource) templateSpecVersion(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_template_spec" "test" {
+  name                = "acctest-ts-%[2]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+
+resource "azurerm_template_spec_version" "test" {
+  name             = "v1.0.0"
+  template_spec_id = azurerm_template_spec.test.id
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+}
+
+resource "azurerm_resource_group_deployment_stack" "test" {
+  name                     = "acctest-stack-%[2]d"
+  resource_group_name      = azurerm_resource_group.test.name
+  template_spec_version_id = azurerm_template_spec_version.test.id
+
+  action_on_unmanage {
+    resources = "detach"
+  }
+
+  deny_settings {
+    mode = "denyDelete"
+  }
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (ResourceGroupDeploymentStackResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = %q
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
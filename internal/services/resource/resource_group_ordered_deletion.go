@@ -0,0 +1,298 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// resourceGroupDeletionBatches defines the order in which the Resources within a Resource Group are deleted when
+// the `delete_nested_resources_in_order` feature is enabled - Resources which reference other Resources need to be
+// removed before the Resources they reference. Resource Types which aren't listed here are deleted in the default batch.
+var resourceGroupDeletionBatches = [][]string{
+	{
+		"Microsoft.Compute/virtualMachines/extensions",
+		"Microsoft.Network/connections",
+		"Microsoft.Network/privateDnsZones/virtualNetworkLinks",
+		"Microsoft.Network/privateEndpoints",
+	},
+	{
+		"Microsoft.ContainerService/managedClusters",
+		"Microsoft.Compute/virtualMachines",
+		"Microsoft.Compute/virtualMachineScaleSets",
+		"Microsoft.Network/applicationGateways",
+		"Microsoft.Network/azureFirewalls",
+		"Microsoft.Network/bastionHosts",
+		"Microsoft.Network/privateLinkServices",
+		"Microsoft.Network/virtualNetworkGateways",
+		"Microsoft.Web/sites",
+	},
+	// the default batch, for any Resource Type not listed explicitly
+	{},
+	{
+		"Microsoft.Compute/disks",
+		"Microsoft.Network/loadBalancers",
+		"Microsoft.Network/networkInterfaces",
+		"Microsoft.Web/serverFarms",
+	},
+	{
+		"Microsoft.Network/applicationSecurityGroups",
+		"Microsoft.Network/firewallPolicies",
+		"Microsoft.Network/natGateways",
+		"Microsoft.Network/networkSecurityGroups",
+		"Microsoft.Network/privateDnsZones",
+		"Microsoft.Network/publicIPAddresses",
+		"Microsoft.Network/publicIPPrefixes",
+		"Microsoft.Network/routeTables",
+	},
+	{
+		"Microsoft.Network/virtualNetworks",
+		"Microsoft.RecoveryServices/vaults",
+	},
+	{
+		"Microsoft.Network/ddosProtectionPlans",
+	},
+}
+
+const resourceGroupDeletionDefaultBatch = 2
+
+// resourceGroupDeletionAttemptsPerBatch is the number of times the deletion of a batch is attempted, since some
+// Resources (e.g. Network Interfaces previously attached to a Virtual Machine) remain reserved for a short while
+const resourceGroupDeletionAttemptsPerBatch = 3
+
+// resourceGroupDeletionParallelism is the maximum number of Resources within a batch which are deleted at once, to
+// avoid being throttled by Azure Resource Manager when the Resource Group contains a large number of Resources
+const resourceGroupDeletionParallelism = 10
+
+// resourceGroupDeletionRetryInterval is the time waited between each attempt to delete a batch
+var resourceGroupDeletionRetryInterval = 30 * time.Second
+
+func resourceGroupDeletionBatchForType(resourceType string) int {
+	for i, batch := range resourceGroupDeletionBatches {
+		for _, v := range batch {
+			if strings.EqualFold(v, resourceType) {
+				return i
+			}
+		}
+	}
+
+	return resourceGroupDeletionDefaultBatch
+}
+
+// deleteResourceGroupNestedResourcesInOrder deletes the Resources within the Resource Group in batches, stopping at the
+// first batch which can't be deleted - `removedLocks` specifies whether the Management Locks within the Resource Group
+// have been lifted, which is used to explain why a locked Resource couldn't be deleted.
+func deleteResourceGroupNestedResourcesInOrder(ctx context.Context, client *client.Client, subscriptionId string, id parse.ResourceGroupId, removedLocks bool) error {
+	nestedResources, err := listResourceGroupNestedResources(ctx, client.ResourcesClient, id)
+	if err != nil {
+		return err
+	}
+	if len(nestedResources) == 0 {
+		return nil
+	}
+
+	resourceProviders := make(map[string]*resources.Provider)
+	batches := make([][]resources.GenericResourceExpanded, len(resourceGroupDeletionBatches))
+	for _, nestedResource := range nestedResources {
+		segments := strings.SplitN(*nestedResource.Type, "/", 2)
+		if len(segments) != 2 {
+			return fmt.Errorf("parsing the Resource Type %q for the nested Resource %q", *nestedResource.Type, *nestedResource.ID)
+		}
+		namespace := strings.ToLower(segments[0])
+		if _, ok := resourceProviders[namespace]; !ok {
+			resourceProviders[namespace] = &resources.Provider{
+				Namespace:     pointer.To(segments[0]),
+				ResourceTypes: &[]resources.ProviderResourceType{},
+			}
+		}
+		*resourceProviders[namespace].ResourceTypes = append(*resourceProviders[namespace].ResourceTypes, resources.ProviderResourceType{
+			ResourceType: pointer.To(segments[1]),
+		})
+
+		batch := resourceGroupDeletionBatchForType(*nestedResource.Type)
+		batches[batch] = append(batches[batch], nestedResource)
+	}
+
+	providers := make([]resources.Provider, 0)
+	for _, v := range resourceProviders {
+		providers = append(providers, *v)
+	}
+
+	log.Printf("[DEBUG] Determining the API Versions used for the Resources within %s..", id)
+	resourceProviderApiVersions, err := determineResourceProviderAPIVersionsForResources(ctx, client.ResourceProvidersClient, providers, subscriptionId)
+	if err != nil {
+		return fmt.Errorf("determining API Versions for the Resources within %s: %+v", id, err)
+	}
+
+	for i, batch := range batches {
+		if len(batch) == 0 {
+			continue
+		}
+
+		log.Printf("[DEBUG] Deleting batch %d of the Resources within %s (%d Resources)..", i+1, id, len(batch))
+		failures := deleteResourceGroupNestedResourcesBatch(ctx, client.ResourcesClient, resourceProviderApiVersions, batch)
+		if len(failures) > 0 {
+			remaining := make([]string, 0)
+			for _, laterBatch := range batches[i+1:] {
+				for _, v := range laterBatch {
+					remaining = append(remaining, *v.ID)
+				}
+			}
+			return resourceGroupNestedResourcesBlockedError(id.ResourceGroup, failures, remaining, removedLocks)
+		}
+	}
+
+	return nil
+}
+
+func listResourceGroupNestedResources(ctx context.Context, client *resources.Client, id parse.ResourceGroupId) ([]resources.GenericResourceExpanded, error) {
+	results, err := client.ListByResourceGroupComplete(ctx, id.ResourceGroup, "", "", utils.Int32(500))
+	if err != nil {
+		return nil, fmt.Errorf("listing resources in %s: %+v", id, err)
+	}
+
+	nestedResources := make([]resources.GenericResourceExpanded, 0)
+	for results.NotDone() {
+		val := results.Value()
+		if val.ID != nil && val.Type != nil {
+			nestedResources = append(nestedResources, val)
+		}
+
+		if err := results.NextWithContext(ctx); err != nil {
+			return nil, fmt.Errorf("retrieving next page of nested items for %s: %+v", id, err)
+		}
+	}
+
+	return nestedResources, nil
+}
+
+// deleteResourceGroupNestedResourcesBatch deletes the Resources within a batch in parallel (up to
+// resourceGroupDeletionParallelism at once), returning the errors for the Resources which couldn't be deleted, keyed
+// by the Resource ID
+func deleteResourceGroupNestedResourcesBatch(ctx context.Context, client *resources.Client, resourceProviderApiVersions *map[string]string, batch []resources.GenericResourceExpanded) map[string]error {
+	pending := batch
+	failures := make(map[string]error)
+
+	for attempt := 1; attempt <= resourceGroupDeletionAttemptsPerBatch; attempt++ {
+		failures = make(map[string]error)
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		semaphore := make(chan struct{}, resourceGroupDeletionParallelism)
+
+		for _, nestedResource := range pending {
+			wg.Add(1)
+			semaphore <- struct{}{}
+			go func(nestedResource resources.GenericResourceExpanded) {
+				defer func() {
+					<-semaphore
+					wg.Done()
+				}()
+
+				reference := resources.Reference{
+					ID: nestedResource.ID,
+				}
+				if err := deleteNestedResource(ctx, client, resourceProviderApiVersions, reference); err != nil {
+					mutex.Lock()
+					failures[*nestedResource.ID] = err
+					mutex.Unlock()
+				}
+			}(nestedResource)
+		}
+		wg.Wait()
+
+		if len(failures) == 0 || attempt == resourceGroupDeletionAttemptsPerBatch {
+			break
+		}
+
+		remaining := make([]resources.GenericResourceExpanded, 0)
+		for _, v := range pending {
+			if _, ok := failures[*v.ID]; ok {
+				remaining = append(remaining, v)
+			}
+		}
+		pending = remaining
+
+		log.Printf("[DEBUG] %d Resources could not be deleted on attempt %d, retrying..", len(failures), attempt)
+		select {
+		case <-ctx.Done():
+			return failures
+		case <-time.After(resourceGroupDeletionRetryInterval):
+		}
+	}
+
+	return failures
+}
+
+func resourceGroupNestedResourcesBlockedError(name string, failures map[string]error, remaining []string, removedLocks bool) error {
+	blocked := make([]string, 0)
+	containsLockedResources := false
+	containsRecoveryServicesVaults := false
+	for id, err := range failures {
+		blocked = append(blocked, fmt.Sprintf("* `%s`: %+v", id, err))
+
+		if strings.Contains(err.Error(), "ScopeLocked") {
+			containsLockedResources = true
+		}
+		if strings.Contains(strings.ToLower(id), "/providers/microsoft.recoveryservices/vaults/") {
+			containsRecoveryServicesVaults = true
+		}
+	}
+	sort.Strings(blocked)
+
+	message := fmt.Sprintf(`deleting Resource Group %q: the following Resources within the Resource Group could not be deleted:
+
+%s
+`, name, strings.Join(blocked, "\n"))
+
+	if len(remaining) > 0 {
+		notAttempted := make([]string, 0)
+		for _, id := range remaining {
+			notAttempted = append(notAttempted, fmt.Sprintf("* `%s`", id))
+		}
+		sort.Strings(notAttempted)
+
+		message += fmt.Sprintf(`
+The following Resources were not deleted, since they may be referenced by the Resources above:
+
+%s
+`, strings.Join(notAttempted, "\n"))
+	}
+
+	if containsLockedResources {
+		if removedLocks {
+			message += `
+One or more Resources are locked by a Management Lock which is defined outside of the Resource Group (for example on
+the Subscription) - which must be removed before the Resource Group can be deleted.
+`
+		} else {
+			message += `
+One or more Resources are locked by a Management Lock. Terraform can remove the Management Locks defined within the
+Resource Group by setting the feature flag 'remove_management_locks_during_delete' within the 'resource_group' block,
+providing these were created using the 'azurerm_management_lock' resource with 'lift_during_nested_changes_enabled'
+set to 'true'.
+`
+		}
+	}
+
+	if containsRecoveryServicesVaults {
+		message += `
+Recovery Services Vaults cannot be deleted whilst they contain (soft-deleted) Backup Items - these must be removed
+from the Vault before the Resource Group can be deleted.
+`
+	}
+
+	return fmt.Errorf("%s", strings.ReplaceAll(message, "'", "`"))
+}
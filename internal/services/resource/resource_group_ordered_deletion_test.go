@@ -0,0 +1,180 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+func TestResourceGroupDeletionBatchForType(t *testing.T) {
+	cases := []struct {
+		ResourceType string
+		Expected     int
+	}{
+		{
+			ResourceType: "Microsoft.Network/privateEndpoints",
+			Expected:     0,
+		},
+		{
+			ResourceType: "Microsoft.Compute/virtualMachines",
+			Expected:     1,
+		},
+		{
+			// Resource Types which aren't listed are deleted in the default batch
+			ResourceType: "Microsoft.Storage/storageAccounts",
+			Expected:     2,
+		},
+		{
+			ResourceType: "Microsoft.Network/networkInterfaces",
+			Expected:     3,
+		},
+		{
+			ResourceType: "microsoft.network/VIRTUALNETWORKS",
+			Expected:     5,
+		},
+		{
+			ResourceType: "Microsoft.Network/ddosProtectionPlans",
+			Expected:     6,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Logf("[DEBUG] Testing %q", tc.ResourceType)
+		if actual := resource.ResourceGroupDeletionBatchForType(tc.ResourceType); actual != tc.Expected {
+			t.Fatalf("expected %q to be deleted in batch %d but got %d", tc.ResourceType, tc.Expected, actual)
+		}
+	}
+}
+
+func TestDeleteResourceGroupNestedResourcesBatch(t *testing.T) {
+	server, client := testResourceGroupOrderedDeletionServer(t)
+	batch := []resources.GenericResourceExpanded{
+		testResourceGroupOrderedDeletionPutNetwork(server, "network1"),
+		testResourceGroupOrderedDeletionPutNetwork(server, "network2"),
+	}
+
+	// the first attempt to delete the Virtual Network fails, but is retried
+	server.InjectConflict(http.MethodDelete, regexp.QuoteMeta(*batch[0].ID)+"$", 1)
+
+	failures := testResourceGroupOrderedDeletionDeleteBatch(client, batch)
+	if len(failures) != 0 {
+		t.Fatalf("expected no failures but got %+v", failures)
+	}
+	for _, v := range batch {
+		if _, exists := server.Get(*v.ID); exists {
+			t.Fatalf("expected %q to have been deleted", *v.ID)
+		}
+	}
+}
+
+func TestDeleteResourceGroupNestedResourcesBatch_failures(t *testing.T) {
+	server, client := testResourceGroupOrderedDeletionServer(t)
+	locked := testResourceGroupOrderedDeletionPutNetwork(server, "locked")
+	other := testResourceGroupOrderedDeletionPutNetwork(server, "other")
+
+	// the Virtual Network can't be deleted on any attempt
+	server.InjectError(http.MethodDelete, regexp.QuoteMeta(*locked.ID)+"$", 3, http.StatusConflict, "ScopeLocked")
+
+	failures := testResourceGroupOrderedDeletionDeleteBatch(client, []resources.GenericResourceExpanded{locked, other})
+	if len(failures) != 1 {
+		t.Fatalf("expected 1 failure but got %d: %+v", len(failures), failures)
+	}
+	err, ok := failures[*locked.ID]
+	if !ok {
+		t.Fatalf("expected a failure for %q but got %+v", *locked.ID, failures)
+	}
+	if !strings.Contains(err.Error(), "ScopeLocked") {
+		t.Fatalf("expected the failure to contain the error from the API but got %+v", err)
+	}
+
+	if _, exists := server.Get(*locked.ID); !exists {
+		t.Fatalf("expected %q to still exist", *locked.ID)
+	}
+	if _, exists := server.Get(*other.ID); exists {
+		t.Fatalf("expected %q to have been deleted", *other.ID)
+	}
+
+	// each attempt only retries the Resources which couldn't be deleted
+	deletes := 0
+	for _, r := range server.Requests() {
+		// the base URI is joined with a leading `/` by the Resources client, so there's an additional `/`
+		if r.Method == http.MethodDelete && strings.EqualFold(strings.TrimPrefix(r.Path, "/"), *other.ID) {
+			deletes++
+		}
+	}
+	if deletes != 1 {
+		t.Fatalf("expected %q to be deleted once but got %d requests", *other.ID, deletes)
+	}
+}
+
+func TestDeleteResourceGroupNestedResourcesBatch_cancelled(t *testing.T) {
+	server, client := testResourceGroupOrderedDeletionServer(t)
+	t.Cleanup(resource.SetResourceGroupDeletionRetryInterval(time.Hour))
+	locked := testResourceGroupOrderedDeletionPutNetwork(server, "locked")
+
+	server.InjectError(http.MethodDelete, regexp.QuoteMeta(*locked.ID)+"$", 3, http.StatusConflict, "ScopeLocked")
+
+	// the retry interval is longer than the timeout, so the retry is abandoned once the timeout has elapsed
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	apiVersions := map[string]string{
+		"microsoft.network": "2023-09-01",
+	}
+	failures := resource.DeleteResourceGroupNestedResourcesBatch(ctx, client, &apiVersions, []resources.GenericResourceExpanded{locked})
+	if _, ok := failures[*locked.ID]; !ok {
+		t.Fatalf("expected a failure for %q but got %+v", *locked.ID, failures)
+	}
+	if ctx.Err() == nil {
+		t.Fatalf("expected the retry to wait until the timeout elapsed")
+	}
+}
+
+func testResourceGroupOrderedDeletionServer(t *testing.T) (*mockarm.Server, *resources.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	t.Cleanup(resource.SetResourceGroupDeletionRetryInterval(0))
+
+	server := mockarm.NewServer(t)
+	client, err := server.Client(ctx, features.Default())
+	if err != nil {
+		t.Fatalf("building client: %+v", err)
+	}
+
+	return server, client.Resource.ResourcesClient
+}
+
+func testResourceGroupOrderedDeletionPutNetwork(server *mockarm.Server, name string) resources.GenericResourceExpanded {
+	id := "/subscriptions/" + mockarm.SubscriptionId + "/resourceGroups/group1/providers/Microsoft.Network/virtualNetworks/" + name
+	server.Put(id, map[string]interface{}{
+		"location": "westeurope",
+	})
+
+	return resources.GenericResourceExpanded{
+		ID:   utils.String(id),
+		Type: utils.String("Microsoft.Network/virtualNetworks"),
+	}
+}
+
+func testResourceGroupOrderedDeletionDeleteBatch(client *resources.Client, batch []resources.GenericResourceExpanded) map[string]error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	apiVersions := map[string]string{
+		"microsoft.network": "2023-09-01",
+	}
+	return resource.DeleteResourceGroupNestedResourcesBatch(ctx, client, &apiVersions, batch)
+}
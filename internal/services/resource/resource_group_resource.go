@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sort"
@@ -12,10 +13,12 @@ import (
 
 	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
 	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/locks"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/parse"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tags"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
@@ -179,7 +182,6 @@ func resourceResourceGroupRead(d *pluginsdk.ResourceData, meta interface{}) erro
 }
 
 func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) error {
-	client := meta.(*clients.Client).Resource.GroupsClient
 	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
 	defer cancel()
 
@@ -220,14 +222,46 @@ func resourceResourceGroupDelete(d *pluginsdk.ResourceData, meta interface{}) er
 		}
 	}
 
+	features := meta.(*clients.Client).Features.ResourceGroup
+	if features.DeleteNestedResourcesInOrder && features.RemoveManagementLocksDuringDelete {
+		// the Management Locks within the Resource Group are recreated if the Resource Group can't be deleted
+		resourceGroupId := commonids.NewResourceGroupID(id.SubscriptionId, id.ResourceGroup)
+		lifted, err := locks.LiftManagementLocksWithin(ctx, meta.(*clients.Client).Resource.LocksClient, resourceGroupId.ID(), meta.(*clients.Client).Account.ClientId)
+		if err != nil {
+			return fmt.Errorf("removing the Management Locks within %s: %+v", *id, err)
+		}
+
+		deleteErr := deleteResourceGroup(ctx, meta.(*clients.Client), *id)
+		if err := lifted.Restore(ctx); err != nil {
+			if deleteErr != nil {
+				return fmt.Errorf("%+v\n\nadditionally %+v", deleteErr, err)
+			}
+			return err
+		}
+		return deleteErr
+	}
+
+	return deleteResourceGroup(ctx, meta.(*clients.Client), *id)
+}
+
+func deleteResourceGroup(ctx context.Context, meta *clients.Client, id parse.ResourceGroupId) error {
+	client := meta.Resource.GroupsClient
+
+	// conditionally delete the nested resources in dependency order, so that we can report what's blocking deletion
+	if features := meta.Features.ResourceGroup; features.DeleteNestedResourcesInOrder {
+		if err := deleteResourceGroupNestedResourcesInOrder(ctx, meta.Resource, meta.Account.SubscriptionId, id, features.RemoveManagementLocksDuringDelete); err != nil {
+			return err
+		}
+	}
+
 	deleteFuture, err := client.Delete(ctx, id.ResourceGroup, "")
 	if err != nil {
-		return fmt.Errorf("deleting %s: %+v", *id, err)
+		return fmt.Errorf("deleting %s: %+v", id, err)
 	}
 
 	err = deleteFuture.WaitForCompletionRef(ctx, client.Client)
 	if err != nil {
-		return fmt.Errorf("waiting for the deletion of %s: %+v", *id, err)
+		return fmt.Errorf("waiting for the deletion of %s: %+v", id, err)
 	}
 
 	return nil
@@ -6,14 +6,17 @@ package resource_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
 	"github.com/hashicorp/go-azure-helpers/lang/pointer"
 	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonids"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2020-05-01/managementlocks"
 	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2023-07-01/resourcegroups"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/mockarm"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
 	"github.com/hashicorp/terraform-provider-azurerm/utils"
@@ -51,6 +54,28 @@ func TestAccResourceGroup_disappears(t *testing.T) {
 	})
 }
 
+func TestResourceGroup_mockBasic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	data.UseMockServer(t, mockarm.NewServer(t))
+	testResource := ResourceGroupResource{}
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		data.ApplyStep(testResource.basicConfig, testResource),
+		data.ImportStep(),
+	})
+}
+
+func TestResourceGroup_mockThrottled(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	server := mockarm.NewServer(t)
+	server.InjectThrottling(http.MethodPut, "/resourceGroups/acctestRG-", 2)
+	data.UseMockServer(t, server)
+	testResource := ResourceGroupResource{}
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		data.ApplyStep(testResource.basicConfig, testResource),
+		data.ImportStep(),
+	})
+}
+
 func TestAccResourceGroup_withTags(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	testResource := ResourceGroupResource{}
@@ -78,6 +103,30 @@ func TestAccResourceGroup_withTags(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_withTagsPlanChecks(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	testResource := ResourceGroupResource{}
+	data.ResourceTest(t, testResource, []acceptance.TestStep{
+		data.ApplyStep(testResource.withTagsConfig, testResource),
+		data.ImportStepWithComparer(),
+		{
+			Config: testResource.withTagsUpdatedConfig(data),
+			ConfigPlanChecks: acceptance.ConfigPlanChecks{
+				PreApply: []acceptance.PlanCheck{
+					check.Plan.ExpectUpdateInPlace(data.ResourceName, "tags.cost_center", "tags.environment"),
+				},
+				PostApplyPostRefresh: []acceptance.PlanCheck{
+					check.Plan.ExpectNoChanges(),
+				},
+			},
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(testResource),
+			),
+		},
+		data.ImportStepWithComparer(),
+	})
+}
+
 func TestAccResourceGroup_withManagedBy(t *testing.T) {
 	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
 	testResource := ResourceGroupResource{}
@@ -122,6 +171,60 @@ func TestAccResourceGroup_withNestedItemsAndFeatureFlag(t *testing.T) {
 	})
 }
 
+func TestAccResourceGroup_withNestedItemsDeletedInOrder(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	r := ResourceGroupResource{}
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withOrderedDeletion(data, false),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.createNetworkOutsideTerraform(fmt.Sprintf("acctestvnet-%d", data.RandomInteger))),
+				data.CheckWithClient(r.createLockOutsideTerraform(fmt.Sprintf("acctestlock-%d", data.RandomInteger), true)),
+			),
+		},
+		data.ImportStep(),
+		{
+			// the lock prevents the vnet from being deleted
+			Config:      r.withOrderedDeletion(data, false),
+			Destroy:     true,
+			ExpectError: regexp.MustCompile("remove_management_locks_during_delete"),
+		},
+		{
+			// removing the lock allows the RG and the Network to be deleted
+			Config:  r.withOrderedDeletion(data, true),
+			Destroy: true,
+		},
+	})
+}
+
+func TestAccResourceGroup_withUnownedLockBlockingOrderedDeletion(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_resource_group", "test")
+	r := ResourceGroupResource{}
+	lockName := fmt.Sprintf("acctestlock-%d", data.RandomInteger)
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.withOrderedDeletion(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				data.CheckWithClient(r.createLockOutsideTerraform(lockName, false)),
+			),
+		},
+		{
+			// a lock which Terraform doesn't own is never removed, so blocks deleting the RG
+			Config:      r.withOrderedDeletion(data, true),
+			Destroy:     true,
+			ExpectError: regexp.MustCompile("must be removed manually"),
+		},
+		{
+			Config: r.withOrderedDeletion(data, true),
+			Check: acceptance.ComposeTestCheckFunc(
+				data.CheckWithClient(r.deleteLockOutsideTerraform(lockName)),
+			),
+		},
+	})
+}
+
 func (t ResourceGroupResource) Destroy(ctx context.Context, client *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
 	// NOTE: Due to the Resource Group resource still using the old Azure SDK and sourcing the Resource Group ID
 	// from the Azure API, we need to support both `resourceGroups` and the legacy `resourcegroups` value here
@@ -193,6 +296,46 @@ func (t ResourceGroupResource) createNetworkOutsideTerraform(name string) func(c
 	}
 }
 
+// createLockOutsideTerraform creates a Management Lock on the Resource Group - when `owned` is set this records the
+// principal Terraform is running as as an Owner, as the `azurerm_management_lock` resource does when
+// `lift_during_nested_changes_enabled` is set
+func (t ResourceGroupResource) createLockOutsideTerraform(name string, owned bool) func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		client := clients.Resource.LocksClient
+		id := managementlocks.NewProviderLockID(clients.Account.SubscriptionId, state.Attributes["name"], name)
+		payload := managementlocks.ManagementLockObject{
+			Properties: managementlocks.ManagementLockProperties{
+				Level: managementlocks.LockLevelCanNotDelete,
+			},
+		}
+		if owned {
+			payload.Properties.Owners = &[]managementlocks.ManagementLockOwner{
+				{
+					ApplicationId: pointer.To(clients.Account.ClientId),
+				},
+			}
+		}
+		if _, err := client.CreateOrUpdateAtResourceGroupLevel(ctx, id, payload); err != nil {
+			return fmt.Errorf("creating nested %s: %+v", id, err)
+		}
+
+		return nil
+	}
+}
+
+// deleteLockOutsideTerraform deletes a Management Lock on the Resource Group created by createLockOutsideTerraform
+func (t ResourceGroupResource) deleteLockOutsideTerraform(name string) func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+	return func(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) error {
+		client := clients.Resource.LocksClient
+		id := managementlocks.NewProviderLockID(clients.Account.SubscriptionId, state.Attributes["name"], name)
+		if _, err := client.DeleteAtResourceGroupLevel(ctx, id); err != nil {
+			return fmt.Errorf("deleting nested %s: %+v", id, err)
+		}
+
+		return nil
+	}
+}
+
 func (t ResourceGroupResource) basicConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -235,6 +378,25 @@ resource "azurerm_resource_group" "test" {
 `, featureFlagEnabled, data.RandomInteger, data.Locations.Primary)
 }
 
+func (t ResourceGroupResource) withOrderedDeletion(data acceptance.TestData, removeLocks bool) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {
+    resource_group {
+      prevent_deletion_if_contains_resources = false
+      delete_nested_resources_in_order       = true
+      remove_management_locks_during_delete  = %t
+    }
+  }
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+`, removeLocks, data.RandomInteger, data.Locations.Primary)
+}
+
 func (t ResourceGroupResource) withTagsConfig(data acceptance.TestData) string {
 	return fmt.Sprintf(`
 provider "azurerm" {
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2024-03-01/deploymentstacks"
+	"github.com/hashicorp/terraform-provider-azurerm/helpers/tf"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/timeouts"
+)
+
+func subscriptionDeploymentStackResource() *pluginsdk.Resource {
+	resource := &pluginsdk.Resource{
+		Create: subscriptionDeploymentStackResourceCreate,
+		Read:   subscriptionDeploymentStackResourceRead,
+		Update: subscriptionDeploymentStackResourceUpdate,
+		Delete: subscriptionDeploymentStackResourceDelete,
+		Importer: pluginsdk.ImporterValidatingResourceId(func(id string) error {
+			_, err := deploymentstacks.ParseDeploymentStackID(id)
+			return err
+		}),
+
+		Timeouts: &pluginsdk.ResourceTimeout{
+			Create: pluginsdk.DefaultTimeout(180 * time.Minute),
+			Read:   pluginsdk.DefaultTimeout(5 * time.Minute),
+			Update: pluginsdk.DefaultTimeout(180 * time.Minute),
+			Delete: pluginsdk.DefaultTimeout(180 * time.Minute),
+		},
+
+		// lintignore:S033
+		Schema: map[string]*pluginsdk.Schema{
+			"name": {
+				Type:         pluginsdk.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validate.TemplateDeploymentName,
+			},
+
+			"location": commonschema.Location(),
+		},
+	}
+
+	for k, v := range deploymentStackSchema(false) {
+		resource.Schema[k] = v
+	}
+
+	return resource
+}
+
+func subscriptionDeploymentStackResourceCreate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Resource.DeploymentStacksClient
+	subscriptionId := meta.(*clients.Client).Account.SubscriptionId
+	ctx, cancel := timeouts.ForCreate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id := deploymentstacks.NewDeploymentStackID(subscriptionId, d.Get("name").(string))
+
+	existing, err := client.GetAtSubscription(ctx, id)
+	if err != nil {
+		if !response.WasNotFound(existing.HttpResponse) {
+			return fmt.Errorf("checking for presence of existing %s: %+v", id, err)
+		}
+	}
+	if !response.WasNotFound(existing.HttpResponse) {
+		return tf.ImportAsExistsError("azurerm_subscription_deployment_stack", id.ID())
+	}
+
+	payload, err := expandDeploymentStack(d)
+	if err != nil {
+		return err
+	}
+	payload.Location = pointer.To(location.Normalize(d.Get("location").(string)))
+
+	log.Printf("[DEBUG] Provisioning %s..", id)
+	if err := client.CreateOrUpdateAtSubscriptionThenPoll(ctx, id, *payload); err != nil {
+		return fmt.Errorf("creating %s: %+v", id, subscriptionDeploymentStackError(ctx, client, id, err))
+	}
+
+	d.SetId(id.ID())
+	return subscriptionDeploymentStackResourceRead(d, meta)
+}
+
+func subscriptionDeploymentStackResourceUpdate(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Resource.DeploymentStacksClient
+	ctx, cancel := timeouts.ForUpdate(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := deploymentstacks.ParseDeploymentStackID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// the API doesn't have a Patch operation, so the complete Stack is redeployed
+	payload, err := expandDeploymentStack(d)
+	if err != nil {
+		return err
+	}
+	payload.Location = pointer.To(location.Normalize(d.Get("location").(string)))
+
+	log.Printf("[DEBUG] Provisioning %s..", *id)
+	if err := client.CreateOrUpdateAtSubscriptionThenPoll(ctx, *id, *payload); err != nil {
+		return fmt.Errorf("updating %s: %+v", *id, subscriptionDeploymentStackError(ctx, client, *id, err))
+	}
+
+	return subscriptionDeploymentStackResourceRead(d, meta)
+}
+
+func subscriptionDeploymentStackResourceRead(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Resource.DeploymentStacksClient
+	ctx, cancel := timeouts.ForRead(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := deploymentstacks.ParseDeploymentStackID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.GetAtSubscription(ctx, *id)
+	if err != nil {
+		if response.WasNotFound(resp.HttpResponse) {
+			log.Printf("[DEBUG] %s was not found - removing from state", *id)
+			d.SetId("")
+			return nil
+		}
+
+		return fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	template, err := client.ExportTemplateAtSubscription(ctx, *id)
+	if err != nil {
+		return fmt.Errorf("retrieving the Template for %s: %+v", *id, err)
+	}
+
+	d.Set("name", id.DeploymentStackName)
+	if model := resp.Model; model != nil {
+		d.Set("location", location.NormalizeNilable(model.Location))
+	}
+
+	return flattenDeploymentStack(d, resp.Model, template.Model, false)
+}
+
+func subscriptionDeploymentStackResourceDelete(d *pluginsdk.ResourceData, meta interface{}) error {
+	client := meta.(*clients.Client).Resource.DeploymentStacksClient
+	ctx, cancel := timeouts.ForDelete(meta.(*clients.Client).StopContext, d)
+	defer cancel()
+
+	id, err := deploymentstacks.ParseDeploymentStackID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	options := expandDeploymentStackDeleteOptions(d)
+
+	log.Printf("[DEBUG] Deleting %s..", *id)
+	if err := client.DeleteAtSubscriptionThenPoll(ctx, *id, options); err != nil {
+		return fmt.Errorf("deleting %s: %+v", *id, subscriptionDeploymentStackError(ctx, client, *id, err))
+	}
+
+	return waitForDeploymentStackToBeDeleted(ctx, *id, func(ctx context.Context) (*http.Response, *deploymentstacks.DeploymentStack, error) {
+		resp, err := client.GetAtSubscription(ctx, *id)
+		return resp.HttpResponse, resp.Model, err
+	})
+}
+
+// subscriptionDeploymentStackError returns the details of why the Deployment Stack failed (when available),
+// falling back to the original error returned from the long-running operation.
+func subscriptionDeploymentStackError(ctx context.Context, client *deploymentstacks.DeploymentStacksClient, id deploymentstacks.DeploymentStackId, original error) error {
+	resp, err := client.GetAtSubscription(ctx, id)
+	if err != nil {
+		return original
+	}
+	if stackErr := deploymentStackError(resp.Model); stackErr != nil {
+		return stackErr
+	}
+
+	return original
+}
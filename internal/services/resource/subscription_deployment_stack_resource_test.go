@@ -0,0 +1,136 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2024-03-01/deploymentstacks"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type SubscriptionDeploymentStackResource struct{}
+
+func TestAccSubscriptionDeploymentStack_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subscription_deployment_stack", "test")
+	r := SubscriptionDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccSubscriptionDeploymentStack_resourceGroup(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_subscription_deployment_stack", "test")
+	r := SubscriptionDeploymentStackResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.resourceGroup(data, "first"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("managed_resource_ids.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.resourceGroup(data, "second"),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func (SubscriptionDeploymentStackResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := deploymentstacks.ParseDeploymentStackID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Resource.DeploymentStacksClient.GetAtSubscription(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (SubscriptionDeploymentStackResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_subscription_deployment_stack" "test" {
+  name     = "acctest-stack-%d"
+  location = %q
+
+  action_on_unmanage {
+    resources = "delete"
+  }
+
+  deny_settings {
+    mode = "none"
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
+
+func (SubscriptionDeploymentStackResource) resourceGroup(data acceptance.TestData, tagValue string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_subscription_deployment_stack" "test" {
+  name     = "acctest-stack-%[1]d"
+  location = %[2]q
+
+  action_on_unmanage {
+    resources       = "delete"
+    resource_groups = "delete"
+  }
+
+  deny_settings {
+    mode = "denyDelete"
+  }
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources = [
+      {
+        type       = "Microsoft.Resources/resourceGroups"
+        apiVersion = "2021-04-01"
+        name       = "acctestRG-stack-%[1]d"
+        location   = %[2]q
+        tags = {
+          Hello = %[3]q
+        }
+      }
+    ]
+  })
+}
+`, data.RandomInteger, data.Locations.Primary, tagValue)
+}
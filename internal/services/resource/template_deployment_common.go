@@ -73,6 +73,18 @@ func expandTemplateDeploymentBody(input string) (*map[string]interface{}, error)
 	return &output, nil
 }
 
+// expandTemplateDeploymentContent parses a JSON document (e.g. a Template) into the shape used by the
+// `hashicorp/go-azure-sdk` models, which expose these as an `*interface{}`.
+func expandTemplateDeploymentContent(input string) (*interface{}, error) {
+	body, err := expandTemplateDeploymentBody(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var output interface{} = *body
+	return &output, nil
+}
+
 func flattenTemplateDeploymentBody(input interface{}) (*string, error) {
 	output := "{}" // since this should be json
 
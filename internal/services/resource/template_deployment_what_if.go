@@ -0,0 +1,277 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/location"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	mgParse "github.com/hashicorp/terraform-provider-azurerm/internal/services/managementgroup/parse"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+// templateDeploymentWhatIfFunc runs the What-If operation for a Template Deployment at the relevant scope, returning
+// nil when the What-If operation can't be run during this plan (e.g. as the scope isn't known until apply).
+type templateDeploymentWhatIfFunc func(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error)
+
+// templateDeploymentWhatIfCustomizeDiff returns a CustomizeDiff which (when the `what_if_during_plan` feature is
+// enabled) runs the What-If operation for the Template Deployment whenever it's being created, or when any of the
+// specified fields have changed - surfacing the predicted changes as Warnings in the plan.
+//
+// NOTE: the predicted changes are intentionally not exposed as an attribute, since the plan is recalculated during
+// the apply (which runs the What-If operation again) and the result of the What-If operation can differ between
+// these - which would cause Terraform to report that the Provider produced an inconsistent final plan.
+func templateDeploymentWhatIfCustomizeDiff(resourceType string, mode resources.DeploymentMode, fields []string, whatIf templateDeploymentWhatIfFunc) pluginsdk.CustomizeDiffFunc {
+	return func(ctx context.Context, d *pluginsdk.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*clients.Client)
+		if !ok || !client.Features.TemplateDeployment.WhatIfDuringPlan {
+			return nil
+		}
+
+		if d.Id() != "" && !d.HasChanges(fields...) {
+			return nil
+		}
+
+		name := d.Get("name").(string)
+		properties, err := expandTemplateDeploymentWhatIfProperties(d, mode)
+		if err != nil {
+			return err
+		}
+		if properties == nil {
+			log.Printf("[DEBUG] Skipping the What-If operation for the %s %q since the template isn't known until apply", resourceType, name)
+			return nil
+		}
+
+		result, err := whatIf(ctx, client.Resource.DeploymentsClient, d, properties)
+		if err != nil {
+			// the What-If operation is best-effort, so this shouldn't block the plan
+			pluginsdk.AddPlanWarning(ctx, fmt.Sprintf("Unable to preview the changes for the %s %q", resourceType, name), fmt.Sprintf("running the What-If operation: %+v", err))
+			return nil
+		}
+		if result == nil {
+			log.Printf("[DEBUG] Skipping the What-If operation for the %s %q since the scope isn't known until apply", resourceType, name)
+			return nil
+		}
+		if result.Error != nil {
+			message := ""
+			if result.Error.Message != nil {
+				message = *result.Error.Message
+			}
+			pluginsdk.AddPlanWarning(ctx, fmt.Sprintf("Unable to preview the changes for the %s %q", resourceType, name), fmt.Sprintf("the What-If operation failed: %s", message))
+			return nil
+		}
+
+		if summary := flattenTemplateDeploymentWhatIfChanges(result.WhatIfOperationProperties); len(summary) > 0 {
+			pluginsdk.AddPlanWarning(ctx, fmt.Sprintf("The %s %q is predicted to make the following changes", resourceType, name), strings.Join(summary, "\n"))
+		}
+
+		return nil
+	}
+}
+
+// expandTemplateDeploymentWhatIfProperties builds the request for the What-If operation from the planned values,
+// returning nil if any of these aren't known until apply.
+func expandTemplateDeploymentWhatIfProperties(d *pluginsdk.ResourceDiff, mode resources.DeploymentMode) (*resources.DeploymentWhatIfProperties, error) {
+	if mode == "" {
+		if !d.NewValueKnown("deployment_mode") {
+			return nil, nil
+		}
+		mode = resources.DeploymentMode(d.Get("deployment_mode").(string))
+	}
+	properties := resources.DeploymentWhatIfProperties{
+		Mode: mode,
+	}
+
+	// `template_content` and `parameters_content` are Optional & Computed, so the value within the config
+	// is used to determine whether these are specified, rather than being unknown until apply
+	config := d.GetRawConfig()
+	switch {
+	case !config.GetAttr("template_spec_version_id").IsNull():
+		if !d.NewValueKnown("template_spec_version_id") {
+			return nil, nil
+		}
+		properties.TemplateLink = &resources.TemplateLink{
+			ID: utils.String(d.Get("template_spec_version_id").(string)),
+		}
+
+	default:
+		if !d.NewValueKnown("template_content") {
+			return nil, nil
+		}
+		template, err := expandTemplateDeploymentBody(d.Get("template_content").(string))
+		if err != nil {
+			return nil, fmt.Errorf("expanding `template_content`: %+v", err)
+		}
+		properties.Template = template
+	}
+
+	if !config.GetAttr("parameters_content").IsNull() {
+		if !d.NewValueKnown("parameters_content") {
+			return nil, nil
+		}
+		if v := d.Get("parameters_content").(string); v != "" {
+			parameters, err := expandTemplateDeploymentBody(v)
+			if err != nil {
+				return nil, fmt.Errorf("expanding `parameters_content`: %+v", err)
+			}
+			properties.Parameters = parameters
+		}
+	}
+
+	return &properties, nil
+}
+
+func flattenTemplateDeploymentWhatIfChanges(input *resources.WhatIfOperationProperties) []string {
+	summary := make([]string, 0)
+	if input == nil || input.Changes == nil {
+		return summary
+	}
+
+	items := *input.Changes
+	sort.SliceStable(items, func(i, j int) bool {
+		return utils.NormalizeNilableString(items[i].ResourceID) < utils.NormalizeNilableString(items[j].ResourceID)
+	})
+
+	for _, item := range items {
+		// resources which won't be changed by the deployment are omitted from the Warning
+		if item.ChangeType == resources.ChangeTypeNoChange || item.ChangeType == resources.ChangeTypeIgnore {
+			continue
+		}
+
+		line := fmt.Sprintf("%s %s", string(item.ChangeType), utils.NormalizeNilableString(item.ResourceID))
+		if item.Delta != nil {
+			if properties := flattenTemplateDeploymentWhatIfPropertyChanges("", *item.Delta); len(properties) > 0 {
+				line = fmt.Sprintf("%s (%s)", line, strings.Join(properties, ", "))
+			}
+		}
+		summary = append(summary, line)
+	}
+
+	return summary
+}
+
+func flattenTemplateDeploymentWhatIfPropertyChanges(prefix string, input []resources.WhatIfPropertyChange) []string {
+	output := make([]string, 0)
+	for _, item := range input {
+		path := utils.NormalizeNilableString(item.Path)
+		if prefix != "" {
+			path = fmt.Sprintf("%s.%s", prefix, path)
+		}
+
+		if item.Children != nil && len(*item.Children) > 0 {
+			output = append(output, flattenTemplateDeploymentWhatIfPropertyChanges(path, *item.Children)...)
+			continue
+		}
+		output = append(output, path)
+	}
+
+	return output
+}
+
+func resourceGroupTemplateDeploymentWhatIf(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+	if !d.NewValueKnown("resource_group_name") {
+		return nil, nil
+	}
+
+	future, err := client.WhatIf(ctx, d.Get("resource_group_name").(string), d.Get("name").(string), resources.DeploymentWhatIf{
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, fmt.Errorf("waiting for the What-If operation: %+v", err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the result of the What-If operation: %+v", err)
+	}
+
+	return &result, nil
+}
+
+func subscriptionTemplateDeploymentWhatIf(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+	if !d.NewValueKnown("location") {
+		return nil, nil
+	}
+
+	future, err := client.WhatIfAtSubscriptionScope(ctx, d.Get("name").(string), resources.DeploymentWhatIf{
+		Location:   utils.String(location.Normalize(d.Get("location").(string))),
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, fmt.Errorf("waiting for the What-If operation: %+v", err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the result of the What-If operation: %+v", err)
+	}
+
+	return &result, nil
+}
+
+func managementGroupTemplateDeploymentWhatIf(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+	if !d.NewValueKnown("location") || !d.NewValueKnown("management_group_id") {
+		return nil, nil
+	}
+
+	managementGroupId, err := mgParse.ManagementGroupID(d.Get("management_group_id").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	future, err := client.WhatIfAtManagementGroupScope(ctx, managementGroupId.Name, d.Get("name").(string), resources.ScopedDeploymentWhatIf{
+		Location:   utils.String(location.Normalize(d.Get("location").(string))),
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, fmt.Errorf("waiting for the What-If operation: %+v", err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the result of the What-If operation: %+v", err)
+	}
+
+	return &result, nil
+}
+
+func tenantTemplateDeploymentWhatIf(ctx context.Context, client *resources.DeploymentsClient, d *pluginsdk.ResourceDiff, properties *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+	if !d.NewValueKnown("location") {
+		return nil, nil
+	}
+
+	future, err := client.WhatIfAtTenantScope(ctx, d.Get("name").(string), resources.ScopedDeploymentWhatIf{
+		Location:   utils.String(location.Normalize(d.Get("location").(string))),
+		Properties: properties,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := future.WaitForCompletionRef(ctx, client.Client); err != nil {
+		return nil, fmt.Errorf("waiting for the What-If operation: %+v", err)
+	}
+
+	result, err := future.Result(*client)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving the result of the What-If operation: %+v", err)
+	}
+
+	return &result, nil
+}
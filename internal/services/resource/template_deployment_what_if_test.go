@@ -0,0 +1,174 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2020-06-01/resources" // nolint: staticcheck
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/features"
+	resourceClient "github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/client"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/utils"
+)
+
+const testTemplateDeploymentWhatIfResourceType = "azurerm_subscription_template_deployment"
+
+func TestTemplateDeploymentWhatIfCustomizeDiff_disabled(t *testing.T) {
+	calls := 0
+	server, ty := testTemplateDeploymentWhatIfServer(t, false, &calls)
+
+	config := testTemplateDeploymentWhatIfConfig(ty, nil)
+	resp := testTemplateDeploymentWhatIfPlan(t, server, ty, cty.NullVal(ty), config, config)
+
+	if calls != 0 {
+		t.Fatalf("expected the What-If operation not to be run but it was run %d times", calls)
+	}
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics but got %d", len(resp.Diagnostics))
+	}
+}
+
+func TestTemplateDeploymentWhatIfCustomizeDiff_create(t *testing.T) {
+	calls := 0
+	server, ty := testTemplateDeploymentWhatIfServer(t, true, &calls)
+
+	config := testTemplateDeploymentWhatIfConfig(ty, nil)
+	resp := testTemplateDeploymentWhatIfPlan(t, server, ty, cty.NullVal(ty), config, config)
+
+	if calls != 1 {
+		t.Fatalf("expected the What-If operation to be run once but it was run %d times", calls)
+	}
+
+	// the Warning is added within the CustomizeDiff, so is only returned when served using WithPlanWarnings
+	if len(resp.Diagnostics) != 1 {
+		t.Fatalf("expected 1 diagnostic but got %d", len(resp.Diagnostics))
+	}
+	diagnostic := resp.Diagnostics[0]
+	if diagnostic.Severity != tfprotov5.DiagnosticSeverityWarning {
+		t.Fatalf("expected a Warning but got %s", diagnostic.Severity)
+	}
+	if !strings.Contains(diagnostic.Detail, "Create /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example") {
+		t.Fatalf("expected the Warning to contain the predicted changes but got %q", diagnostic.Detail)
+	}
+}
+
+func TestTemplateDeploymentWhatIfCustomizeDiff_unrelatedUpdate(t *testing.T) {
+	calls := 0
+	server, ty := testTemplateDeploymentWhatIfServer(t, true, &calls)
+
+	prior := testTemplateDeploymentWhatIfConfig(ty, map[string]cty.Value{
+		"id":             cty.StringVal("/subscriptions/00000000-0000-0000-0000-000000000000/providers/Microsoft.Resources/deployments/acctest"),
+		"output_content": cty.StringVal("{}"),
+	})
+	config := testTemplateDeploymentWhatIfConfig(ty, map[string]cty.Value{
+		"tags": cty.MapVal(map[string]cty.Value{
+			"environment": cty.StringVal("Production"),
+		}),
+	})
+	proposed := testTemplateDeploymentWhatIfConfig(ty, map[string]cty.Value{
+		"id":             prior.GetAttr("id"),
+		"output_content": prior.GetAttr("output_content"),
+		"tags":           config.GetAttr("tags"),
+	})
+	resp := testTemplateDeploymentWhatIfPlan(t, server, ty, prior, proposed, config)
+
+	// only the tags have changed, which don't affect the deployment, so the What-If operation shouldn't be run
+	if calls != 0 {
+		t.Fatalf("expected the What-If operation not to be run but it was run %d times", calls)
+	}
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("expected no diagnostics but got %d", len(resp.Diagnostics))
+	}
+}
+
+func testTemplateDeploymentWhatIfServer(t *testing.T, enabled bool, calls *int) (tfprotov5.ProviderServer, cty.Type) {
+	whatIf := func(_ context.Context, _ *resources.DeploymentsClient, _ *pluginsdk.ResourceDiff, _ *resources.DeploymentWhatIfProperties) (*resources.WhatIfOperationResult, error) {
+		*calls++
+		return &resources.WhatIfOperationResult{
+			WhatIfOperationProperties: &resources.WhatIfOperationProperties{
+				Changes: &[]resources.WhatIfChange{
+					{
+						ResourceID: utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"),
+						ChangeType: resources.ChangeTypeCreate,
+					},
+				},
+			},
+		}, nil
+	}
+
+	r := subscriptionTemplateDeploymentResource()
+	r.CustomizeDiff = templateDeploymentWhatIfCustomizeDiff(testTemplateDeploymentWhatIfResourceType, resources.DeploymentModeIncremental, []string{"template_content", "template_spec_version_id", "parameters_content"}, whatIf)
+
+	userFeatures := features.Default()
+	userFeatures.TemplateDeployment.WhatIfDuringPlan = enabled
+
+	provider := &schema.Provider{
+		ResourcesMap: map[string]*schema.Resource{
+			testTemplateDeploymentWhatIfResourceType: r,
+		},
+	}
+	provider.SetMeta(&clients.Client{
+		Features: userFeatures,
+		Resource: &resourceClient.Client{},
+	})
+
+	// the Provider is served in the same way as within `main.go`
+	return pluginsdk.WithPlanWarnings(schema.NewGRPCProviderServer(provider)), r.CoreConfigSchema().ImpliedType()
+}
+
+func testTemplateDeploymentWhatIfConfig(ty cty.Type, overrides map[string]cty.Value) cty.Value {
+	values := map[string]cty.Value{
+		"name":             cty.StringVal("acctest"),
+		"location":         cty.StringVal("westeurope"),
+		"template_content": cty.StringVal(`{"$schema":"https://schema.management.azure.com/schemas/2018-05-01/subscriptionDeploymentTemplate.json#","contentVersion":"1.0.0.0","resources":[]}`),
+	}
+	for k, v := range overrides {
+		values[k] = v
+	}
+
+	for k, attributeType := range ty.AttributeTypes() {
+		if _, ok := values[k]; !ok {
+			values[k] = cty.NullVal(attributeType)
+		}
+	}
+
+	return cty.ObjectVal(values)
+}
+
+func testTemplateDeploymentWhatIfPlan(t *testing.T, server tfprotov5.ProviderServer, ty cty.Type, prior cty.Value, proposed cty.Value, config cty.Value) *tfprotov5.PlanResourceChangeResponse {
+	encode := func(v cty.Value) *tfprotov5.DynamicValue {
+		b, err := msgpack.Marshal(v, ty)
+		if err != nil {
+			t.Fatalf("encoding %#v: %+v", v, err)
+		}
+		return &tfprotov5.DynamicValue{
+			MsgPack: b,
+		}
+	}
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+		TypeName:         testTemplateDeploymentWhatIfResourceType,
+		PriorState:       encode(prior),
+		ProposedNewState: encode(proposed),
+		Config:           encode(config),
+	})
+	if err != nil {
+		t.Fatalf("planning: %+v", err)
+	}
+	for _, diagnostic := range resp.Diagnostics {
+		if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Fatalf("planning: %s: %s", diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+
+	return resp
+}
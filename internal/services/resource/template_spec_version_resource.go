@@ -0,0 +1,384 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-helpers/lang/response"
+	"github.com/hashicorp/go-azure-helpers/resourcemanager/commonschema"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-02-01/templatespecs"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-02-01/templatespecversions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/sdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/services/resource/validate"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/validation"
+)
+
+var _ sdk.ResourceWithUpdate = TemplateSpecVersionResource{}
+
+type TemplateSpecVersionResource struct{}
+
+type TemplateSpecVersionResourceModel struct {
+	Name                    string                              `tfschema:"name"`
+	TemplateSpecId          string                              `tfschema:"template_spec_id"`
+	Description             string                              `tfschema:"description"`
+	TemplateContent         string                              `tfschema:"template_content"`
+	LinkedTemplate          []TemplateSpecVersionLinkedTemplate `tfschema:"linked_template"`
+	UiFormDefinitionContent string                              `tfschema:"ui_form_definition_content"`
+	MetadataContent         string                              `tfschema:"metadata_content"`
+	Tags                    map[string]string                   `tfschema:"tags"`
+}
+
+type TemplateSpecVersionLinkedTemplate struct {
+	Path            string `tfschema:"path"`
+	TemplateContent string `tfschema:"template_content"`
+}
+
+func (r TemplateSpecVersionResource) ModelObject() interface{} {
+	return &TemplateSpecVersionResourceModel{}
+}
+
+func (r TemplateSpecVersionResource) IDValidationFunc() pluginsdk.SchemaValidateFunc {
+	return templatespecversions.ValidateTemplateSpecVersionID
+}
+
+func (r TemplateSpecVersionResource) ResourceType() string {
+	return "azurerm_template_spec_version"
+}
+
+func (r TemplateSpecVersionResource) Arguments() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{
+		"name": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validate.TemplateSpecVersionName,
+		},
+
+		"template_spec_id": {
+			Type:         pluginsdk.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: templatespecs.ValidateTemplateSpecID,
+		},
+
+		"template_content": {
+			Type:             pluginsdk.TypeString,
+			Required:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
+		},
+
+		"description": {
+			Type:         pluginsdk.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 4096),
+		},
+
+		"linked_template": {
+			Type:     pluginsdk.TypeList,
+			Optional: true,
+			Elem: &pluginsdk.Resource{
+				Schema: map[string]*pluginsdk.Schema{
+					"path": {
+						Type:         pluginsdk.TypeString,
+						Required:     true,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+
+					"template_content": {
+						Type:             pluginsdk.TypeString,
+						Required:         true,
+						ValidateFunc:     validation.StringIsJSON,
+						DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
+					},
+				},
+			},
+		},
+
+		"metadata_content": {
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
+		},
+
+		"ui_form_definition_content": {
+			Type:             pluginsdk.TypeString,
+			Optional:         true,
+			ValidateFunc:     validation.StringIsJSON,
+			DiffSuppressFunc: pluginsdk.SuppressJsonDiff,
+		},
+
+		"tags": commonschema.Tags(),
+	}
+}
+
+func (r TemplateSpecVersionResource) Attributes() map[string]*pluginsdk.Schema {
+	return map[string]*pluginsdk.Schema{}
+}
+
+func (r TemplateSpecVersionResource) Create() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.TemplateSpecsVersionsClient
+			templateSpecsClient := metadata.Client.Resource.TemplateSpecsClient
+
+			var config TemplateSpecVersionResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			templateSpecId, err := templatespecs.ParseTemplateSpecID(config.TemplateSpecId)
+			if err != nil {
+				return err
+			}
+
+			id := templatespecversions.NewTemplateSpecVersionID(templateSpecId.SubscriptionId, templateSpecId.ResourceGroupName, templateSpecId.TemplateSpecName, config.Name)
+
+			existing, err := client.Get(ctx, id)
+			if err != nil {
+				if !response.WasNotFound(existing.HttpResponse) {
+					return fmt.Errorf("checking for the presence of an existing %s: %+v", id, err)
+				}
+			}
+			if !response.WasNotFound(existing.HttpResponse) {
+				return metadata.ResourceRequiresImport(r.ResourceType(), id)
+			}
+
+			// a Version must be created in the same location as the Template Spec it belongs to
+			templateSpec, err := templateSpecsClient.Get(ctx, *templateSpecId, templatespecs.DefaultGetOperationOptions())
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *templateSpecId, err)
+			}
+			if templateSpec.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *templateSpecId)
+			}
+
+			properties, err := expandTemplateSpecVersionProperties(config)
+			if err != nil {
+				return err
+			}
+
+			payload := templatespecversions.TemplateSpecVersion{
+				Location:   templateSpec.Model.Location,
+				Properties: *properties,
+				Tags:       pointer.To(config.Tags),
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, id, payload); err != nil {
+				return fmt.Errorf("creating %s: %+v", id, err)
+			}
+
+			metadata.SetID(id)
+			return nil
+		},
+	}
+}
+
+func (r TemplateSpecVersionResource) Read() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 5 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.TemplateSpecsVersionsClient
+
+			id, err := templatespecversions.ParseTemplateSpecVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			resp, err := client.Get(ctx, *id)
+			if err != nil {
+				if response.WasNotFound(resp.HttpResponse) {
+					return metadata.MarkAsGone(*id)
+				}
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+
+			state := TemplateSpecVersionResourceModel{
+				Name:           id.VersionName,
+				TemplateSpecId: templatespecs.NewTemplateSpecID(id.SubscriptionId, id.ResourceGroupName, id.TemplateSpecName).ID(),
+			}
+
+			if model := resp.Model; model != nil {
+				if model.Tags != nil {
+					state.Tags = *model.Tags
+				}
+
+				props := model.Properties
+				state.Description = pointer.From(props.Description)
+
+				templateContent, err := flattenTemplateDeploymentBody(pointer.From(props.MainTemplate))
+				if err != nil {
+					return fmt.Errorf("flattening `template_content`: %+v", err)
+				}
+				state.TemplateContent = *templateContent
+
+				linkedTemplates, err := flattenTemplateSpecVersionLinkedTemplates(props.LinkedTemplates)
+				if err != nil {
+					return fmt.Errorf("flattening `linked_template`: %+v", err)
+				}
+				state.LinkedTemplate = linkedTemplates
+
+				if props.Metadata != nil {
+					metadataContent, err := flattenTemplateDeploymentBody(*props.Metadata)
+					if err != nil {
+						return fmt.Errorf("flattening `metadata_content`: %+v", err)
+					}
+					state.MetadataContent = *metadataContent
+				}
+
+				if props.UiFormDefinition != nil {
+					uiFormDefinitionContent, err := flattenTemplateDeploymentBody(*props.UiFormDefinition)
+					if err != nil {
+						return fmt.Errorf("flattening `ui_form_definition_content`: %+v", err)
+					}
+					state.UiFormDefinitionContent = *uiFormDefinitionContent
+				}
+			}
+
+			return metadata.Encode(&state)
+		},
+	}
+}
+
+func (r TemplateSpecVersionResource) Update() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.TemplateSpecsVersionsClient
+
+			id, err := templatespecversions.ParseTemplateSpecVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			var config TemplateSpecVersionResourceModel
+			if err := metadata.Decode(&config); err != nil {
+				return fmt.Errorf("decoding: %+v", err)
+			}
+
+			existing, err := client.Get(ctx, *id)
+			if err != nil {
+				return fmt.Errorf("retrieving %s: %+v", *id, err)
+			}
+			if existing.Model == nil {
+				return fmt.Errorf("retrieving %s: `model` was nil", *id)
+			}
+
+			// the contents of a Version are replaced in-place, so the complete set of properties is sent
+			properties, err := expandTemplateSpecVersionProperties(config)
+			if err != nil {
+				return err
+			}
+
+			payload := *existing.Model
+			payload.Properties = *properties
+
+			if metadata.ResourceData.HasChange("tags") {
+				payload.Tags = pointer.To(config.Tags)
+			}
+
+			if _, err := client.CreateOrUpdate(ctx, *id, payload); err != nil {
+				return fmt.Errorf("updating %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func (r TemplateSpecVersionResource) Delete() sdk.ResourceFunc {
+	return sdk.ResourceFunc{
+		Timeout: 30 * time.Minute,
+		Func: func(ctx context.Context, metadata sdk.ResourceMetaData) error {
+			client := metadata.Client.Resource.TemplateSpecsVersionsClient
+
+			id, err := templatespecversions.ParseTemplateSpecVersionID(metadata.ResourceData.Id())
+			if err != nil {
+				return err
+			}
+
+			if _, err := client.Delete(ctx, *id); err != nil {
+				return fmt.Errorf("deleting %s: %+v", *id, err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func expandTemplateSpecVersionProperties(input TemplateSpecVersionResourceModel) (*templatespecversions.TemplateSpecVersionProperties, error) {
+	properties := templatespecversions.TemplateSpecVersionProperties{}
+
+	if input.Description != "" {
+		properties.Description = pointer.To(input.Description)
+	}
+
+	mainTemplate, err := expandTemplateDeploymentContent(input.TemplateContent)
+	if err != nil {
+		return nil, fmt.Errorf("expanding `template_content`: %+v", err)
+	}
+	properties.MainTemplate = mainTemplate
+
+	if len(input.LinkedTemplate) > 0 {
+		linkedTemplates := make([]templatespecversions.LinkedTemplateArtifact, 0)
+		for _, item := range input.LinkedTemplate {
+			template, err := expandTemplateDeploymentBody(item.TemplateContent)
+			if err != nil {
+				return nil, fmt.Errorf("expanding the `template_content` for the `linked_template` %q: %+v", item.Path, err)
+			}
+
+			linkedTemplates = append(linkedTemplates, templatespecversions.LinkedTemplateArtifact{
+				Path:     item.Path,
+				Template: *template,
+			})
+		}
+		properties.LinkedTemplates = &linkedTemplates
+	}
+
+	if input.MetadataContent != "" {
+		metadataContent, err := expandTemplateDeploymentContent(input.MetadataContent)
+		if err != nil {
+			return nil, fmt.Errorf("expanding `metadata_content`: %+v", err)
+		}
+		properties.Metadata = metadataContent
+	}
+
+	if input.UiFormDefinitionContent != "" {
+		uiFormDefinition, err := expandTemplateDeploymentContent(input.UiFormDefinitionContent)
+		if err != nil {
+			return nil, fmt.Errorf("expanding `ui_form_definition_content`: %+v", err)
+		}
+		properties.UiFormDefinition = uiFormDefinition
+	}
+
+	return &properties, nil
+}
+
+func flattenTemplateSpecVersionLinkedTemplates(input *[]templatespecversions.LinkedTemplateArtifact) ([]TemplateSpecVersionLinkedTemplate, error) {
+	output := make([]TemplateSpecVersionLinkedTemplate, 0)
+	if input == nil {
+		return output, nil
+	}
+
+	for _, item := range *input {
+		templateContent, err := flattenTemplateDeploymentBody(item.Template)
+		if err != nil {
+			return nil, fmt.Errorf("flattening the template for %q: %+v", item.Path, err)
+		}
+
+		output = append(output, TemplateSpecVersionLinkedTemplate{
+			Path:            item.Path,
+			TemplateContent: *templateContent,
+		})
+	}
+
+	return output, nil
+}
@@ -0,0 +1,270 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package resource_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-azure-helpers/lang/pointer"
+	"github.com/hashicorp/go-azure-sdk/resource-manager/resources/2022-02-01/templatespecversions"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/check"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/clients"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+)
+
+type TemplateSpecVersionResource struct{}
+
+func TestAccTemplateSpecVersion_basic(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_template_spec_version", "test")
+	r := TemplateSpecVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccTemplateSpecVersion_requiresImport(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_template_spec_version", "test")
+	r := TemplateSpecVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.RequiresImportErrorStep(r.requiresImport),
+	})
+}
+
+func TestAccTemplateSpecVersion_complete(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_template_spec_version", "test")
+	r := TemplateSpecVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That(data.ResourceName).Key("linked_template.#").HasValue("1"),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccTemplateSpecVersion_update(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_template_spec_version", "test")
+	r := TemplateSpecVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.complete(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+		{
+			Config: r.basic(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+			),
+		},
+		data.ImportStep(),
+	})
+}
+
+func TestAccTemplateSpecVersion_deployment(t *testing.T) {
+	data := acceptance.BuildTestData(t, "azurerm_template_spec_version", "test")
+	r := TemplateSpecVersionResource{}
+
+	data.ResourceTest(t, r, []acceptance.TestStep{
+		{
+			Config: r.deployment(data),
+			Check: acceptance.ComposeTestCheckFunc(
+				check.That(data.ResourceName).ExistsInAzure(r),
+				check.That("azurerm_resource_group_template_deployment.test").Key("output_content").HasValue("{\"greeting\":{\"type\":\"String\",\"value\":\"hello world\"}}"),
+			),
+		},
+	})
+}
+
+func (r TemplateSpecVersionResource) Exists(ctx context.Context, clients *clients.Client, state *pluginsdk.InstanceState) (*bool, error) {
+	id, err := templatespecversions.ParseTemplateSpecVersionID(state.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := clients.Resource.TemplateSpecsVersionsClient.Get(ctx, *id)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving %s: %+v", *id, err)
+	}
+
+	return pointer.To(resp.Model != nil), nil
+}
+
+func (r TemplateSpecVersionResource) basic(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_template_spec_version" "test" {
+  name             = "v1.0.0"
+  template_spec_id = azurerm_template_spec.test.id
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+  })
+}
+`, r.template(data))
+}
+
+func (r TemplateSpecVersionResource) requiresImport(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_template_spec_version" "import" {
+  name             = azurerm_template_spec_version.test.name
+  template_spec_id = azurerm_template_spec_version.test.template_spec_id
+  template_content = azurerm_template_spec_version.test.template_content
+}
+`, r.basic(data))
+}
+
+func (r TemplateSpecVersionResource) complete(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_template_spec_version" "test" {
+  name             = "v1.0.0"
+  template_spec_id = azurerm_template_spec.test.id
+  description      = "Acceptance Test Version"
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources = [
+      {
+        type       = "Microsoft.Resources/deployments"
+        apiVersion = "2021-04-01"
+        name       = "nested"
+        properties = {
+          mode = "Incremental"
+          templateLink = {
+            relativePath = "artifacts/nested.json"
+          }
+        }
+      }
+    ]
+  })
+
+  linked_template {
+    path = "artifacts/nested.json"
+    template_content = jsonencode({
+      "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+      contentVersion = "1.0.0.0"
+      resources      = []
+    })
+  }
+
+  metadata_content = jsonencode({
+    owner = "acctest"
+  })
+
+  ui_form_definition_content = jsonencode({
+    "$schema" = "https://schema.management.azure.com/schemas/2021-09-09/uiFormDefinition.schema.json"
+    view = {
+      kind = "Form"
+      properties = {
+        title = "Acceptance Test"
+        steps = [
+          {
+            name  = "basics"
+            label = "Basics"
+            elements = [
+              {
+                name = "resourceScope"
+                type = "Microsoft.Common.ResourceScope"
+              }
+            ]
+          }
+        ]
+      }
+    }
+  })
+
+  tags = {
+    environment = "testing"
+  }
+}
+`, r.template(data))
+}
+
+func (r TemplateSpecVersionResource) deployment(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+%s
+
+resource "azurerm_template_spec_version" "test" {
+  name             = "v1.0.0"
+  template_spec_id = azurerm_template_spec.test.id
+
+  template_content = jsonencode({
+    "$schema"      = "https://schema.management.azure.com/schemas/2019-04-01/deploymentTemplate.json#"
+    contentVersion = "1.0.0.0"
+    resources      = []
+    outputs = {
+      greeting = {
+        type  = "String"
+        value = "hello world"
+      }
+    }
+  })
+}
+
+resource "azurerm_resource_group_template_deployment" "test" {
+  name                     = "acctestRGdeploy-%d"
+  resource_group_name      = azurerm_resource_group.test.name
+  deployment_mode          = "Incremental"
+  template_spec_version_id = azurerm_template_spec_version.test.id
+}
+`, r.template(data), data.RandomInteger)
+}
+
+func (r TemplateSpecVersionResource) template(data acceptance.TestData) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  features {}
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%[1]d"
+  location = %[2]q
+}
+
+resource "azurerm_template_spec" "test" {
+  name                = "acctest-ts-%[1]d"
+  resource_group_name = azurerm_resource_group.test.name
+  location            = azurerm_resource_group.test.location
+}
+`, data.RandomInteger, data.Locations.Primary)
+}
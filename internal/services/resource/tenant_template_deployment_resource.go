@@ -99,6 +99,8 @@ func tenantTemplateDeploymentResource() *pluginsdk.Resource {
 				// parsing the JSON using `jsondecode` allows the users to interact with/map objects as required
 			},
 		},
+
+		CustomizeDiff: templateDeploymentWhatIfCustomizeDiff("azurerm_tenant_template_deployment", resources.DeploymentModeIncremental, []string{"template_content", "template_spec_version_id", "parameters_content"}, tenantTemplateDeploymentWhatIf),
 	}
 }
 
@@ -426,7 +426,7 @@ func resourceSubscriptionDelete(d *pluginsdk.ResourceData, meta interface{}) err
 	if !meta.(*clients.Client).Features.Subscription.PreventCancellationOnDestroy {
 		log.Printf("[DEBUG] Cancelling subscription %s", subscriptionId)
 
-		if _, err := aliasClient.SubscriptionCancel(ctx, subscriptionResourceId); err != nil {
+		if _, err := aliasClient.SubscriptionCancel(ctx, subscriptionResourceId, subscriptionAlias.DefaultSubscriptionCancelOperationOptions()); err != nil {
 			return fmt.Errorf("failed to cancel Subscription: %+v", err)
 		}
 
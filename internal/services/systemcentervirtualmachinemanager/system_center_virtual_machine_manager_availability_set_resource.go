@@ -107,7 +107,7 @@ func (r SystemCenterVirtualMachineManagerAvailabilitySetResource) Create() sdk.R
 					Type: utils.String("customLocation"),
 					Name: utils.String(model.CustomLocationId),
 				},
-				Properties: availabilitysets.AvailabilitySetProperties{
+				Properties: &availabilitysets.AvailabilitySetProperties{
 					AvailabilitySetName: utils.String(id.AvailabilitySetName),
 					VMmServerId:         utils.String(scvmmServerId.ID()),
 				},
@@ -151,11 +151,13 @@ func (r SystemCenterVirtualMachineManagerAvailabilitySetResource) Read() sdk.Res
 				state.CustomLocationId = pointer.From(model.ExtendedLocation.Name)
 				state.Tags = pointer.From(model.Tags)
 
-				scvmmServerId, err := vmmservers.ParseVMmServerIDInsensitively(pointer.From(model.Properties.VMmServerId))
-				if err != nil {
-					return err
+				if props := model.Properties; props != nil {
+					scvmmServerId, err := vmmservers.ParseVMmServerIDInsensitively(pointer.From(props.VMmServerId))
+					if err != nil {
+						return err
+					}
+					state.SystemCenterVirtualMachineManagerServerId = scvmmServerId.ID()
 				}
-				state.SystemCenterVirtualMachineManagerServerId = scvmmServerId.ID()
 			}
 
 			return metadata.Encode(&state)
@@ -179,7 +181,7 @@ func (r SystemCenterVirtualMachineManagerAvailabilitySetResource) Update() sdk.R
 				return fmt.Errorf("decoding: %+v", err)
 			}
 
-			parameters := availabilitysets.ResourcePatch{}
+			parameters := availabilitysets.AvailabilitySetTagsUpdate{}
 
 			if metadata.ResourceData.HasChange("tags") {
 				parameters.Tags = pointer.To(model.Tags)
@@ -205,7 +207,7 @@ func (r SystemCenterVirtualMachineManagerAvailabilitySetResource) Delete() sdk.R
 				return err
 			}
 
-			if err := client.DeleteThenPoll(ctx, *id, availabilitysets.DeleteOperationOptions{Force: pointer.To(availabilitysets.ForceTrue)}); err != nil {
+			if err := client.DeleteThenPoll(ctx, *id, availabilitysets.DeleteOperationOptions{Force: pointer.To(availabilitysets.ForceDeleteTrue)}); err != nil {
 				return fmt.Errorf("deleting %s: %+v", *id, err)
 			}
 
@@ -98,7 +98,7 @@ func (l SystemCenterVirtualMachineManagerInventoryItemsDataSource) Read() sdk.Re
 				return err
 			}
 
-			resp, err := client.ListByVMMServer(ctx, *scvmmServerId)
+			resp, err := client.ListByVMmServer(ctx, *scvmmServerId)
 			if err != nil {
 				if response.WasNotFound(resp.HttpResponse) {
 					return fmt.Errorf("%s was not found", scvmmServerId)
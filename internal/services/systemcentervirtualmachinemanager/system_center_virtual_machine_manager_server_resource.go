@@ -125,14 +125,14 @@ func (r SystemCenterVirtualMachineManagerServerResource) Create() sdk.ResourceFu
 				return metadata.ResourceRequiresImport(r.ResourceType(), id)
 			}
 
-			parameters := &vmmservers.VMMServer{
+			parameters := &vmmservers.VMmServer{
 				Location: location.Normalize(model.Location),
 				ExtendedLocation: vmmservers.ExtendedLocation{
 					Type: pointer.To("customLocation"),
 					Name: pointer.To(model.CustomLocationId),
 				},
-				Properties: vmmservers.VMMServerProperties{
-					Credentials: &vmmservers.VMMCredential{
+				Properties: &vmmservers.VMmServerProperties{
+					Credentials: &vmmservers.VMmCredential{
 						Username: pointer.To(model.Username),
 						Password: pointer.To(model.Password),
 					},
@@ -180,15 +180,17 @@ func (r SystemCenterVirtualMachineManagerServerResource) Read() sdk.ResourceFunc
 				state.ResourceGroupName = id.ResourceGroupName
 				state.Location = location.Normalize(model.Location)
 				state.CustomLocationId = pointer.From(model.ExtendedLocation.Name)
-				state.Fqdn = model.Properties.Fqdn
 				state.Password = metadata.ResourceData.Get("password").(string)
-				state.Port = pointer.From(model.Properties.Port)
 				state.Tags = pointer.From(model.Tags)
 
-				if v := model.Properties.Credentials; v != nil {
-					state.Username = pointer.From(v.Username)
-				}
+				if props := model.Properties; props != nil {
+					state.Fqdn = props.Fqdn
+					state.Port = pointer.From(props.Port)
 
+					if v := props.Credentials; v != nil {
+						state.Username = pointer.From(v.Username)
+					}
+				}
 			}
 
 			return metadata.Encode(&state)
@@ -212,7 +214,7 @@ func (r SystemCenterVirtualMachineManagerServerResource) Update() sdk.ResourceFu
 				return fmt.Errorf("decoding: %+v", err)
 			}
 
-			parameters := vmmservers.ResourcePatch{
+			parameters := vmmservers.VMmServerTagsUpdate{
 				Tags: pointer.To(model.Tags),
 			}
 
@@ -236,7 +238,7 @@ func (r SystemCenterVirtualMachineManagerServerResource) Delete() sdk.ResourceFu
 				return err
 			}
 
-			if err := client.DeleteThenPoll(ctx, *id, vmmservers.DeleteOperationOptions{Force: pointer.To(vmmservers.ForceTrue)}); err != nil {
+			if err := client.DeleteThenPoll(ctx, *id, vmmservers.DeleteOperationOptions{Force: pointer.To(vmmservers.ForceDeleteTrue)}); err != nil {
 				return fmt.Errorf("deleting %s: %+v", *id, err)
 			}
 
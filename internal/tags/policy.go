@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Policy is a set of rules which the tags of each resource must comply with, which is configured
+// using the `tag_policy` block within the Provider block and checked during the plan.
+type Policy struct {
+	// RequiredKeys is a list of the tags which must be specified for each resource
+	RequiredKeys []string
+
+	// AllowedValues is a map of tag name to a regular expression which the value for that tag must match
+	AllowedValues map[string]*regexp.Regexp
+
+	// CaseSensitiveKeys specifies whether tag names are compared case-sensitively - by default these are
+	// compared case-insensitively, since tag names are case-insensitive in Azure
+	CaseSensitiveKeys bool
+
+	// EnforceLowerCaseKeys specifies whether all tag names must be lower-case
+	EnforceLowerCaseKeys bool
+}
+
+// Validate returns an error for each rule within the Policy that the specified tags don't comply with
+func (p Policy) Validate(input map[string]interface{}) []error {
+	errors := make([]error, 0)
+
+	if p.EnforceLowerCaseKeys {
+		_, errs := EnforceLowerCaseKeys(input, "tags")
+		errors = append(errors, errs...)
+	}
+
+	for _, key := range p.RequiredKeys {
+		if _, _, ok := p.find(input, key); !ok {
+			errors = append(errors, fmt.Errorf("the tag %q is required", key))
+		}
+	}
+
+	allowedKeys := make([]string, 0, len(p.AllowedValues))
+	for key := range p.AllowedValues {
+		allowedKeys = append(allowedKeys, key)
+	}
+	sort.Strings(allowedKeys)
+	for _, key := range allowedKeys {
+		actualKey, raw, ok := p.find(input, key)
+		if !ok {
+			continue
+		}
+
+		value, err := TagValueToString(raw)
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+
+		pattern := p.AllowedValues[key]
+		if !pattern.MatchString(value) {
+			errors = append(errors, fmt.Errorf("the value %q for the tag %q doesn't match the pattern %q", value, actualKey, pattern.String()))
+		}
+	}
+
+	return errors
+}
+
+// find returns the tag with the specified name, taking into account whether tag names are case-sensitive
+func (p Policy) find(input map[string]interface{}, key string) (string, interface{}, bool) {
+	if v, ok := input[key]; ok {
+		return key, v, true
+	}
+
+	if !p.CaseSensitiveKeys {
+		for k, v := range input {
+			if strings.EqualFold(k, key) {
+				return k, v, true
+			}
+		}
+	}
+
+	return "", nil, false
+}
@@ -0,0 +1,106 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tags
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPolicyValidate(t *testing.T) {
+	policy := Policy{
+		RequiredKeys: []string{"cost-center", "owner"},
+		AllowedValues: map[string]*regexp.Regexp{
+			"environment": regexp.MustCompile("^(dev|test|prod)$"),
+		},
+	}
+
+	testData := []struct {
+		name     string
+		policy   Policy
+		input    map[string]interface{}
+		expected []string
+	}{
+		{
+			name:   "compliant",
+			policy: policy,
+			input: map[string]interface{}{
+				"cost-center": "1234",
+				"owner":       "platform",
+				"environment": "prod",
+			},
+		},
+		{
+			name:   "missing required tags",
+			policy: policy,
+			input: map[string]interface{}{
+				"environment": "prod",
+			},
+			expected: []string{
+				`the tag "cost-center" is required`,
+				`the tag "owner" is required`,
+			},
+		},
+		{
+			name:   "value not allowed",
+			policy: policy,
+			input: map[string]interface{}{
+				"cost-center": "1234",
+				"owner":       "platform",
+				"Environment": "staging",
+			},
+			expected: []string{
+				`the value "staging" for the tag "Environment" doesn't match the pattern "^(dev|test|prod)$"`,
+			},
+		},
+		{
+			name:   "case-insensitive keys",
+			policy: policy,
+			input: map[string]interface{}{
+				"Cost-Center": "1234",
+				"OWNER":       "platform",
+			},
+		},
+		{
+			name: "case-sensitive keys",
+			policy: Policy{
+				RequiredKeys:      []string{"owner"},
+				CaseSensitiveKeys: true,
+			},
+			input: map[string]interface{}{
+				"Owner": "platform",
+			},
+			expected: []string{
+				`the tag "owner" is required`,
+			},
+		},
+		{
+			name: "lower-case keys",
+			policy: Policy{
+				EnforceLowerCaseKeys: true,
+			},
+			input: map[string]interface{}{
+				"Owner": "platform",
+			},
+			expected: []string{
+				`a tag key "Owner" expected to be all in lowercase`,
+			},
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		errors := v.policy.Validate(v.input)
+		actual := make([]string, 0)
+		for _, err := range errors {
+			actual = append(actual, err.Error())
+		}
+
+		if strings.Join(actual, "\n") != strings.Join(v.expected, "\n") {
+			t.Fatalf("expected the errors:\n\n%s\n\nbut got:\n\n%s", strings.Join(v.expected, "\n"), strings.Join(actual, "\n"))
+		}
+	}
+}
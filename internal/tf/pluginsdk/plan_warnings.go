@@ -0,0 +1,66 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+type planWarningsKey struct{}
+
+type planWarnings struct {
+	sync.Mutex
+	diagnostics []*tfprotov5.Diagnostic
+}
+
+// AddPlanWarning surfaces a Warning to the user during the plan, for use within a CustomizeDiff - since the
+// Plugin SDK only supports returning an error from a CustomizeDiff.
+//
+// This requires that the Provider is served using WithPlanWarnings - otherwise the Warning is only logged.
+func AddPlanWarning(ctx context.Context, summary string, detail string) {
+	log.Printf("[WARN] %s: %s", summary, detail)
+
+	warnings, ok := ctx.Value(planWarningsKey{}).(*planWarnings)
+	if !ok {
+		return
+	}
+
+	warnings.Lock()
+	defer warnings.Unlock()
+	warnings.diagnostics = append(warnings.diagnostics, &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// WithPlanWarnings wraps the gRPC Provider Server so that any Warnings added during a plan using AddPlanWarning
+// are returned to Terraform as part of the plan.
+func WithPlanWarnings(server tfprotov5.ProviderServer) tfprotov5.ProviderServer {
+	return planWarningsServer{
+		ProviderServer: server,
+	}
+}
+
+type planWarningsServer struct {
+	tfprotov5.ProviderServer
+}
+
+func (s planWarningsServer) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	warnings := &planWarnings{}
+	resp, err := s.ProviderServer.PlanResourceChange(context.WithValue(ctx, planWarningsKey{}, warnings), req)
+	if resp == nil {
+		return resp, err
+	}
+
+	warnings.Lock()
+	defer warnings.Unlock()
+	resp.Diagnostics = append(resp.Diagnostics, warnings.diagnostics...)
+
+	return resp, err
+}
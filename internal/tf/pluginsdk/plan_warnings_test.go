@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+type planWarningsTestServer struct {
+	tfprotov5.ProviderServer
+
+	warnings []string
+}
+
+func (s planWarningsTestServer) PlanResourceChange(ctx context.Context, _ *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
+	for _, v := range s.warnings {
+		AddPlanWarning(ctx, v, "some detail")
+	}
+
+	return &tfprotov5.PlanResourceChangeResponse{
+		Diagnostics: []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityWarning,
+				Summary:  "existing",
+			},
+		},
+	}, nil
+}
+
+func TestWithPlanWarnings(t *testing.T) {
+	server := WithPlanWarnings(planWarningsTestServer{
+		warnings: []string{"first", "second"},
+	})
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+
+	expected := []string{"existing", "first", "second"}
+	if len(resp.Diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics but got %d", len(expected), len(resp.Diagnostics))
+	}
+	for i, v := range expected {
+		diagnostic := resp.Diagnostics[i]
+		if diagnostic.Summary != v {
+			t.Fatalf("expected diagnostic %d to be %q but got %q", i, v, diagnostic.Summary)
+		}
+		if diagnostic.Severity != tfprotov5.DiagnosticSeverityWarning {
+			t.Fatalf("expected diagnostic %d to be a Warning but got %s", i, diagnostic.Severity)
+		}
+	}
+}
+
+func TestAddPlanWarning_NotConfigured(t *testing.T) {
+	// when the Provider isn't served using WithPlanWarnings the Warning is only logged
+	AddPlanWarning(context.Background(), "summary", "detail")
+}
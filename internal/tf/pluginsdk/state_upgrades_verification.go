@@ -0,0 +1,285 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+)
+
+// VerifyStateUpgraders validates that the State Upgraders defined for a Resource are consistent with
+// its Schema Version - that is, that there's a State Upgrader for each prior Schema Version (so that
+// bumping the Schema Version without adding a State Upgrader is caught) and that each State Upgrader
+// has both a point-in-time Schema and an Upgrade function.
+func VerifyStateUpgraders(resource *Resource) error {
+	if resource.SchemaVersion == 0 {
+		if len(resource.StateUpgraders) > 0 {
+			return fmt.Errorf("%d State Upgraders are defined but the Schema Version is 0", len(resource.StateUpgraders))
+		}
+		return nil
+	}
+
+	if len(resource.StateUpgraders) != resource.SchemaVersion {
+		return fmt.Errorf("the Schema Version is %d but %d State Upgraders are defined - a State Upgrader is required for each prior Schema Version", resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+
+	for i, upgrader := range resource.StateUpgraders {
+		if upgrader.Version != i {
+			return fmt.Errorf("expected the State Upgrader at index %d to be for Schema Version %d but got %d", i, i, upgrader.Version)
+		}
+		if upgrader.Upgrade == nil {
+			return fmt.Errorf("the State Upgrader for Schema Version %d has no Upgrade function", i)
+		}
+		if upgrader.Type == cty.NilType || !upgrader.Type.IsObjectType() {
+			return fmt.Errorf("the State Upgrader for Schema Version %d has no point-in-time Schema", i)
+		}
+	}
+
+	return nil
+}
+
+// StateUpgradeFixture is a point-in-time copy of the State for a Resource at a given Schema Version, used to
+// verify that the State Upgraders for a Resource upgrade the State to the current Schema without losing data.
+type StateUpgradeFixture struct {
+	// Name is the name of the file this Fixture was loaded from
+	Name string `json:"-"`
+
+	// Version is the Schema Version of the State, parsed from the file name (e.g. `v1.json` or `v1_some_description.json`)
+	Version int `json:"-"`
+
+	// Attributes are the attributes of the Resource, as found within the `attributes` block of the State file
+	Attributes map[string]interface{} `json:"attributes"`
+
+	// Expected are the (optional) attributes of the Resource once the State has been upgraded, which when
+	// specified must match the upgraded State exactly
+	Expected map[string]interface{} `json:"expected,omitempty"`
+
+	// Removed is a list of the top-level attributes which are intentionally removed during the upgrade
+	Removed []string `json:"removed,omitempty"`
+}
+
+var stateUpgradeFixtureFileName = regexp.MustCompile(`^v([0-9]+)(_[a-z0-9_]+)?\.json$`)
+
+// LoadStateUpgradeFixtures loads the State Upgrade Fixtures for a Resource from the specified directory,
+// returning no fixtures if the directory doesn't exist.
+func LoadStateUpgradeFixtures(directory string) ([]StateUpgradeFixture, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading the directory %q: %+v", directory, err)
+	}
+
+	fixtures := make([]StateUpgradeFixture, 0)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		matches := stateUpgradeFixtureFileName.FindStringSubmatch(entry.Name())
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("the file name %q is invalid - expected `v{version}.json` or `v{version}_{description}.json`", entry.Name())
+		}
+		version, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return nil, fmt.Errorf("parsing the version from %q: %+v", entry.Name(), err)
+		}
+
+		contents, err := os.ReadFile(filepath.Join(directory, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading %q: %+v", entry.Name(), err)
+		}
+
+		var fixture StateUpgradeFixture
+		if err := json.Unmarshal(contents, &fixture); err != nil {
+			return nil, fmt.Errorf("parsing %q: %+v", entry.Name(), err)
+		}
+		if len(fixture.Attributes) == 0 {
+			return nil, fmt.Errorf("the fixture %q contains no `attributes`", entry.Name())
+		}
+		fixture.Name = entry.Name()
+		fixture.Version = version
+
+		fixtures = append(fixtures, fixture)
+	}
+
+	sort.Slice(fixtures, func(i, j int) bool {
+		return fixtures[i].Name < fixtures[j].Name
+	})
+
+	return fixtures, nil
+}
+
+// MissingStateUpgradeFixtureVersions returns the prior Schema Versions of the Resource which aren't covered by
+// any of the specified State Upgrade Fixtures.
+func MissingStateUpgradeFixtureVersions(resource *Resource, fixtures []StateUpgradeFixture) []int {
+	covered := make(map[int]struct{})
+	for _, fixture := range fixtures {
+		covered[fixture.Version] = struct{}{}
+	}
+
+	missing := make([]int, 0)
+	for version := 0; version < resource.SchemaVersion; version++ {
+		if _, ok := covered[version]; !ok {
+			missing = append(missing, version)
+		}
+	}
+
+	return missing
+}
+
+// UpgradeState runs the State for a Resource at the specified Schema Version through each of the subsequent
+// State Upgraders, returning the State at the current Schema Version.
+//
+// The specified `meta` is passed to each State Upgrader, however since no credentials are available when
+// verifying State Upgraders, those which make API calls can't be verified.
+func UpgradeState(ctx context.Context, resource *Resource, version int, rawState map[string]interface{}, meta interface{}) (output map[string]interface{}, err error) {
+	if version > resource.SchemaVersion {
+		return nil, fmt.Errorf("the State is for Schema Version %d but the current Schema Version is %d", version, resource.SchemaVersion)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("a State Upgrader panicked: %+v", r)
+		}
+	}()
+
+	output = copyState(rawState)
+	for _, upgrader := range resource.StateUpgraders {
+		if upgrader.Version < version {
+			continue
+		}
+
+		// confirm the State matches the point-in-time Schema this State Upgrader expects - noting that the
+		// point-in-time Schemas don't include the `timeouts` block, which is present in the State
+		stateToCheck := output
+		if _, ok := stateToCheck["timeouts"]; ok && !upgrader.Type.HasAttribute("timeouts") {
+			stateToCheck = copyState(output)
+			delete(stateToCheck, "timeouts")
+		}
+		if err := stateConformsToType(stateToCheck, upgrader.Type); err != nil {
+			return nil, fmt.Errorf("the State doesn't match the Schema for Version %d: %+v", upgrader.Version, err)
+		}
+
+		output, err = upgrader.Upgrade(ctx, output, meta)
+		if err != nil {
+			return nil, fmt.Errorf("upgrading from Schema Version %d: %+v", upgrader.Version, err)
+		}
+		if output == nil {
+			return nil, fmt.Errorf("the State Upgrader for Schema Version %d returned no State", upgrader.Version)
+		}
+	}
+
+	return output, nil
+}
+
+// VerifyStateUpgradeFixture upgrades the State within the Fixture to the current Schema Version, then
+// validates that the upgraded State matches the current Schema of the Resource - and that no attributes
+// which exist in the current Schema have been removed, unless these are listed in `removed`.
+func VerifyStateUpgradeFixture(ctx context.Context, resource *Resource, fixture StateUpgradeFixture, meta interface{}) error {
+	upgraded, err := UpgradeState(ctx, resource, fixture.Version, fixture.Attributes, meta)
+	if err != nil {
+		return err
+	}
+
+	// any attributes which aren't in the current Schema are silently dropped by the Plugin SDK, so these
+	// are surfaced here rather than when the attribute is found to be missing later
+	currentType := resource.CoreConfigSchema().ImpliedType()
+	if err := stateConformsToType(upgraded, currentType); err != nil {
+		return fmt.Errorf("the upgraded State doesn't match the current Schema: %+v", err)
+	}
+
+	removed := make(map[string]struct{})
+	for _, v := range fixture.Removed {
+		removed[v] = struct{}{}
+	}
+	wiped := make([]string, 0)
+	for key, value := range fixture.Attributes {
+		if value == nil || !currentType.HasAttribute(key) {
+			continue
+		}
+		if _, ok := removed[key]; ok {
+			continue
+		}
+		if v, ok := upgraded[key]; !ok || v == nil {
+			wiped = append(wiped, key)
+		}
+	}
+	if len(wiped) > 0 {
+		sort.Strings(wiped)
+		return fmt.Errorf("the attribute(s) %s were removed from the State during the upgrade - if this is intentional these should be listed in `removed`", strings.Join(wiped, ", "))
+	}
+
+	if fixture.Expected != nil {
+		// normalize both values via JSON, so that e.g. ints and float64s compare equally
+		expected, err := normalizeState(fixture.Expected)
+		if err != nil {
+			return fmt.Errorf("normalizing the expected State: %+v", err)
+		}
+		actual, err := normalizeState(upgraded)
+		if err != nil {
+			return fmt.Errorf("normalizing the upgraded State: %+v", err)
+		}
+		if !reflect.DeepEqual(expected, actual) {
+			return fmt.Errorf("expected the upgraded State to be:\n\n%s\n\nbut got:\n\n%s", prettyState(expected), prettyState(actual))
+		}
+	}
+
+	return nil
+}
+
+func stateConformsToType(state map[string]interface{}, stateType cty.Type) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshaling the State: %+v", err)
+	}
+
+	if _, err := ctyjson.Unmarshal(contents, stateType); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func copyState(input map[string]interface{}) map[string]interface{} {
+	output, err := normalizeState(input)
+	if err != nil {
+		// the State was loaded from JSON, so this shouldn't happen
+		panic(fmt.Sprintf("copying the State: %+v", err))
+	}
+	return output
+}
+
+func normalizeState(input map[string]interface{}) (map[string]interface{}, error) {
+	contents, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+
+	var output map[string]interface{}
+	if err := json.Unmarshal(contents, &output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func prettyState(input map[string]interface{}) string {
+	contents, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", input)
+	}
+	return string(contents)
+}
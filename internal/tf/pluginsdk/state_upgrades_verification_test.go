@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package pluginsdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testStateUpgradeV0ToV1 struct{}
+
+func (testStateUpgradeV0ToV1) Schema() map[string]*Schema {
+	return map[string]*Schema{
+		"name": {
+			Type:     TypeString,
+			Required: true,
+		},
+		"sku": {
+			Type:     TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (testStateUpgradeV0ToV1) UpgradeFunc() StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		// `sku` was renamed to `sku_name`
+		rawState["sku_name"] = rawState["sku"]
+		delete(rawState, "sku")
+		return rawState, nil
+	}
+}
+
+type testStateUpgradeV1ToV2 struct {
+	wipesName bool
+}
+
+func (testStateUpgradeV1ToV2) Schema() map[string]*Schema {
+	return map[string]*Schema{
+		"name": {
+			Type:     TypeString,
+			Required: true,
+		},
+		"sku_name": {
+			Type:     TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func (u testStateUpgradeV1ToV2) UpgradeFunc() StateUpgraderFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		if u.wipesName {
+			delete(rawState, "name")
+		}
+		rawState["id"] = fmt.Sprintf("/examples/%s", rawState["name"])
+		return rawState, nil
+	}
+}
+
+func testResourceForStateUpgrades(wipesName bool) *Resource {
+	return &Resource{
+		SchemaVersion: 2,
+		StateUpgraders: StateUpgrades(map[int]StateUpgrade{
+			0: testStateUpgradeV0ToV1{},
+			1: testStateUpgradeV1ToV2{wipesName: wipesName},
+		}),
+		Schema: map[string]*Schema{
+			"name": {
+				Type:     TypeString,
+				Required: true,
+			},
+			"sku_name": {
+				Type:     TypeString,
+				Optional: true,
+			},
+		},
+		Timeouts: &ResourceTimeout{
+			Read: DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+func TestVerifyStateUpgraders(t *testing.T) {
+	resource := testResourceForStateUpgrades(false)
+	if err := VerifyStateUpgraders(resource); err != nil {
+		t.Fatalf("expected no error but got: %+v", err)
+	}
+
+	// bumping the Schema Version without adding a State Upgrader
+	resource.SchemaVersion = 3
+	if err := VerifyStateUpgraders(resource); err == nil {
+		t.Fatalf("expected an error when the Schema Version was bumped without a State Upgrader")
+	}
+
+	resource = testResourceForStateUpgrades(false)
+	resource.SchemaVersion = 0
+	if err := VerifyStateUpgraders(resource); err == nil {
+		t.Fatalf("expected an error when State Upgraders are defined for Schema Version 0")
+	}
+}
+
+func TestMissingStateUpgradeFixtureVersions(t *testing.T) {
+	resource := testResourceForStateUpgrades(false)
+
+	missing := MissingStateUpgradeFixtureVersions(resource, []StateUpgradeFixture{
+		{Version: 1},
+	})
+	if len(missing) != 1 || missing[0] != 0 {
+		t.Fatalf("expected Schema Version 0 to be missing but got %+v", missing)
+	}
+
+	missing = MissingStateUpgradeFixtureVersions(resource, []StateUpgradeFixture{
+		{Version: 0},
+		{Version: 1},
+		{Version: 1},
+	})
+	if len(missing) != 0 {
+		t.Fatalf("expected no Schema Versions to be missing but got %+v", missing)
+	}
+}
+
+func TestVerifyStateUpgradeFixture(t *testing.T) {
+	testData := []struct {
+		name      string
+		wipesName bool
+		fixture   StateUpgradeFixture
+		expectErr string
+	}{
+		{
+			name: "v0",
+			fixture: StateUpgradeFixture{
+				Version: 0,
+				Attributes: map[string]interface{}{
+					"name": "example",
+					"sku":  "Standard",
+				},
+				Expected: map[string]interface{}{
+					"id":       "/examples/example",
+					"name":     "example",
+					"sku_name": "Standard",
+				},
+			},
+		},
+		{
+			name: "v1",
+			fixture: StateUpgradeFixture{
+				Version: 1,
+				Attributes: map[string]interface{}{
+					"name":     "example",
+					"sku_name": "Standard",
+					"timeouts": nil,
+				},
+			},
+		},
+		{
+			name: "fixture doesn't match the point-in-time schema",
+			fixture: StateUpgradeFixture{
+				Version: 1,
+				Attributes: map[string]interface{}{
+					"name": "example",
+					"sku":  "Standard",
+				},
+			},
+			expectErr: "doesn't match the Schema for Version 1",
+		},
+		{
+			name:      "attribute wiped",
+			wipesName: true,
+			fixture: StateUpgradeFixture{
+				Version: 0,
+				Attributes: map[string]interface{}{
+					"name": "example",
+				},
+			},
+			expectErr: "the attribute(s) name were removed",
+		},
+		{
+			name:      "attribute intentionally removed",
+			wipesName: true,
+			fixture: StateUpgradeFixture{
+				Version: 0,
+				Attributes: map[string]interface{}{
+					"name": "example",
+				},
+				Removed: []string{"name"},
+			},
+		},
+		{
+			name: "unexpected output",
+			fixture: StateUpgradeFixture{
+				Version: 1,
+				Attributes: map[string]interface{}{
+					"name": "example",
+				},
+				Expected: map[string]interface{}{
+					"id":   "/examples/other",
+					"name": "example",
+				},
+			},
+			expectErr: "expected the upgraded State to be",
+		},
+	}
+
+	for _, v := range testData {
+		t.Logf("[DEBUG] Testing %q..", v.name)
+
+		err := VerifyStateUpgradeFixture(context.TODO(), testResourceForStateUpgrades(v.wipesName), v.fixture, nil)
+		if v.expectErr == "" {
+			if err != nil {
+				t.Fatalf("expected no error but got: %+v", err)
+			}
+			continue
+		}
+
+		if err == nil || !strings.Contains(err.Error(), v.expectErr) {
+			t.Fatalf("expected an error containing %q but got: %+v", v.expectErr, err)
+		}
+	}
+}
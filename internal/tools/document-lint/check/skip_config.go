@@ -16,6 +16,7 @@ var skipProps = []string{
 	"all.timezone",
 	"all.time_zone",
 	"all.time_zone_id",
+	"all.effective_tags",                     // added to every resource which supports tags when opted into using `ARM_PROVIDER_EFFECTIVE_TAGS`
 	"azurerm_nginx_deployment.identity.type", // there is a diff between real supported values and common identity schema
 	"azurerm_kubernetes_cluster.default_node_pool.os_sku",
 	"azurerm_kubernetes_cluster_node_pool.os_sku",
@@ -0,0 +1,75 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/sweep"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/acceptance/testclient"
+)
+
+func main() {
+	f := flag.NewFlagSet("sweeper", flag.ExitOnError)
+	dryRun := f.Bool("dry-run", false, "-dry-run")
+	minimumAge := f.Duration("minimum-age", 3*time.Hour, "-minimum-age=3h")
+	parallelism := f.Int("parallelism", 10, "-parallelism=10")
+	prefixes := f.String("prefixes", strings.Join(sweep.DefaultPrefixes(), ","), "-prefixes=acctest,testacc")
+	sweepers := f.String("sweepers", "", "-sweepers=resource_groups,soft_deleted_key_vaults")
+	timeout := f.Duration("timeout", 2*time.Hour, "-timeout=2h")
+	list := f.Bool("list", false, "-list")
+	if err := f.Parse(os.Args[1:]); err != nil {
+		log.Fatalf("parsing arguments: %+v", err)
+	}
+
+	if *list {
+		for _, name := range sweep.Names() {
+			log.Printf("%s", name)
+		}
+		return
+	}
+
+	options := sweep.DefaultOptions()
+	options.DryRun = *dryRun
+	options.MinimumAge = *minimumAge
+	options.Parallelism = *parallelism
+	options.Prefixes = splitList(*prefixes)
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	client, err := testclient.Build()
+	if err != nil {
+		log.Fatalf("building client: %+v", err)
+	}
+
+	results, err := sweep.Run(ctx, client, options, splitList(*sweepers)...)
+	for name, ids := range results {
+		for _, id := range ids {
+			if options.DryRun {
+				log.Printf("[%s] would remove %q", name, id)
+				continue
+			}
+			log.Printf("[%s] removed %q", name, id)
+		}
+	}
+	if err != nil {
+		log.Fatalf("error: %+v", err)
+	}
+}
+
+func splitList(input string) []string {
+	output := make([]string, 0)
+	for _, v := range strings.Split(input, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			output = append(output, v)
+		}
+	}
+	return output
+}
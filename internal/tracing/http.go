@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	headerCorrelationRequestId = "x-ms-correlation-request-id"
+	headerRequestId            = "x-ms-request-id"
+)
+
+type requestStartTimeKey struct{}
+
+// WithRequestStartTime returns a copy of the HTTP Request which records the time the Request was sent,
+// for use when the Span for this Request is recorded using RecordRequest.
+func WithRequestStartTime(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), requestStartTimeKey{}, time.Now()))
+}
+
+// RecordRequest records a Span for an HTTP Request sent to an Azure API (including each poll of a
+// Long Running Operation), as a child of any Span within the Context of the Request.
+//
+// Spans for API requests are recorded once the Response has been received, rather than when the Request
+// is sent, since not all of the HTTP Clients used by the Provider support wrapping the Request.
+func RecordRequest(req *http.Request, resp *http.Response, err error) {
+	if req == nil {
+		return
+	}
+
+	ctx := req.Context()
+	start, ok := ctx.Value(requestStartTimeKey{}).(time.Time)
+	if !ok {
+		start = time.Now()
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(req.Method),
+		semconv.ServerAddress(req.URL.Hostname()),
+		// the query string is omitted since this can contain credentials (e.g. a SAS Token)
+		semconv.URLPath(req.URL.Path),
+	}
+	if v := req.URL.Query().Get("api-version"); v != "" {
+		attributes = append(attributes, attribute.String("azure.api_version", v))
+	}
+
+	correlationRequestId := req.Header.Get(headerCorrelationRequestId)
+	if resp != nil {
+		attributes = append(attributes, semconv.HTTPResponseStatusCode(resp.StatusCode))
+		if v := resp.Header.Get(headerCorrelationRequestId); v != "" {
+			correlationRequestId = v
+		}
+		if v := resp.Header.Get(headerRequestId); v != "" {
+			attributes = append(attributes, AttributeRequestId.String(v))
+		}
+	}
+	if correlationRequestId != "" {
+		attributes = append(attributes, AttributeCorrelationRequestId.String(correlationRequestId))
+	}
+
+	_, span := tracer().Start(ctx, fmt.Sprintf("HTTP %s", req.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attributes...),
+	)
+
+	switch {
+	case err != nil:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	case resp != nil && resp.StatusCode >= http.StatusBadRequest:
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	span.End()
+}
@@ -0,0 +1,127 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/hashicorp/terraform-provider-azurerm/version"
+)
+
+const (
+	// instrumentationName is the name of the Tracer used to create Spans within the Provider
+	instrumentationName = "github.com/hashicorp/terraform-provider-azurerm"
+
+	serviceName = "terraform-provider-azurerm"
+)
+
+const (
+	// AttributeResourceType is the Terraform Resource Type (e.g. `azurerm_resource_group`) a Span relates to
+	AttributeResourceType = attribute.Key("azurerm.resource_type")
+
+	// AttributeOperation is the CRUD operation (e.g. `Create`) a Span relates to
+	AttributeOperation = attribute.Key("azurerm.operation")
+
+	// AttributeResourceId is the Azure Resource ID of the Resource a Span relates to, when known
+	AttributeResourceId = attribute.Key("azurerm.resource_id")
+
+	// AttributeCorrelationRequestId is the value of the `x-ms-correlation-request-id` header for an API request
+	AttributeCorrelationRequestId = attribute.Key("azurerm.correlation_request_id")
+
+	// AttributeRequestId is the value of the `x-ms-request-id` header returned by the API
+	AttributeRequestId = attribute.Key("azurerm.request_id")
+)
+
+// Configure sets up the global Tracer Provider to export Spans via OTLP over HTTP when either the
+// `OTEL_EXPORTER_OTLP_ENDPOINT` or `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT` environment variables are set -
+// the remaining `OTEL_EXPORTER_OTLP_*` environment variables (e.g. for headers) are supported by the exporter.
+//
+// When neither is set, tracing remains disabled and Spans are discarded. The returned function flushes
+// any pending Spans and should be called prior to the Provider exiting.
+func Configure(ctx context.Context) (func(context.Context) error, error) {
+	if os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") == "" && os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") == "" {
+		return func(context.Context) error {
+			return nil
+		}, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("building the OTLP exporter: %+v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version.ProviderVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("building the OpenTelemetry resource: %+v", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		log.Printf("[DEBUG] OpenTelemetry: %+v", err)
+	}))
+
+	log.Printf("[DEBUG] Exporting OpenTelemetry traces via OTLP")
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName, trace.WithInstrumentationVersion(version.ProviderVersion))
+}
+
+// StartOperation starts a Span for the specified CRUD operation (e.g. `Create`) of a Resource or Data Source,
+// returning a Context containing the Span which should be used for any API requests made by the operation.
+//
+// Since Terraform doesn't send the address of a Resource (e.g. `azurerm_resource_group.example`) to the Provider,
+// Spans are identified by the Resource Type and, when known, the Resource ID.
+func StartOperation(ctx context.Context, resourceType string, operation string, id string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		AttributeResourceType.String(resourceType),
+		AttributeOperation.String(operation),
+	}
+	if id != "" {
+		attributes = append(attributes, AttributeResourceId.String(id))
+	}
+
+	return tracer().Start(ctx, fmt.Sprintf("%s %s", resourceType, operation),
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attributes...),
+	)
+}
+
+// EndOperation ends a Span started using StartOperation, recording the Resource ID (which is only known
+// once a Resource has been created) and the outcome of the operation.
+func EndOperation(span trace.Span, id string, err error) {
+	if id != "" {
+		span.SetAttributes(AttributeResourceId.String(id))
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+
+	span.End()
+}
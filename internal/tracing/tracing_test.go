@@ -0,0 +1,218 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a stand-in for an OpenTelemetry Collector, which records the Spans exported via OTLP over HTTP
+type collector struct {
+	sync.Mutex
+	headers http.Header
+	spans   []*tracepb.Span
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var request coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(body, &request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.Lock()
+	c.headers = r.Header.Clone()
+	for _, resourceSpans := range request.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			c.spans = append(c.spans, scopeSpans.Spans...)
+		}
+	}
+	c.Unlock()
+
+	response, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response) // nolint: errcheck
+}
+
+func (c *collector) span(name string) *tracepb.Span {
+	c.Lock()
+	defer c.Unlock()
+	for _, span := range c.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func attributeValue(span *tracepb.Span, key string) *commonpb.AnyValue {
+	for _, v := range span.Attributes {
+		if v.Key == key {
+			return v.Value
+		}
+	}
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	c := &collector{}
+	collectorServer := httptest.NewServer(c)
+	defer collectorServer.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ms-correlation-request-id", r.Header.Get("x-ms-correlation-request-id"))
+		w.Header().Set("x-ms-request-id", "11111111-1111-1111-1111-111111111111")
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collectorServer.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "Authorization=Bearer%20example")
+	t.Cleanup(func() {
+		otel.SetTracerProvider(trace.NewNoopTracerProvider())
+	})
+
+	ctx := context.Background()
+	shutdown, err := tracing.Configure(ctx)
+	if err != nil {
+		t.Fatalf("configuring tracing: %+v", err)
+	}
+
+	send := func(ctx context.Context, method string) {
+		req, err := http.NewRequestWithContext(ctx, method, api.URL+"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example?api-version=2020-06-01", nil)
+		if err != nil {
+			t.Fatalf("building request: %+v", err)
+		}
+		req.Header.Set("x-ms-correlation-request-id", "22222222-2222-2222-2222-222222222222")
+		req = tracing.WithRequestStartTime(req)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("sending request: %+v", err)
+		}
+		defer resp.Body.Close()
+		tracing.RecordRequest(req, resp, nil)
+	}
+
+	id := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example"
+	createCtx, createSpan := tracing.StartOperation(ctx, "azurerm_resource_group", "Create", "")
+	send(createCtx, http.MethodPut)
+	tracing.EndOperation(createSpan, id, nil)
+
+	deleteCtx, deleteSpan := tracing.StartOperation(ctx, "azurerm_resource_group", "Delete", id)
+	send(deleteCtx, http.MethodDelete)
+	tracing.EndOperation(deleteSpan, id, errors.New("deleting: unexpected status 409"))
+
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutting down tracing: %+v", err)
+	}
+
+	create := c.span("azurerm_resource_group Create")
+	if create == nil {
+		t.Fatalf("expected a Span for the Create operation but got none")
+	}
+	if v := attributeValue(create, string(tracing.AttributeResourceId)); v == nil || v.GetStringValue() != id {
+		t.Fatalf("expected the Create Span to have the Resource ID %q but got %+v", id, v)
+	}
+	if create.Status.Code != tracepb.Status_STATUS_CODE_OK {
+		t.Fatalf("expected the Create Span to have the status OK but got %s", create.Status.Code)
+	}
+
+	put := c.span("HTTP PUT")
+	if put == nil {
+		t.Fatalf("expected a Span for the PUT request but got none")
+	}
+	if string(put.ParentSpanId) != string(create.SpanId) {
+		t.Fatalf("expected the Span for the PUT request to be a child of the Create Span")
+	}
+	if put.Kind != tracepb.Span_SPAN_KIND_CLIENT {
+		t.Fatalf("expected the Span for the PUT request to be a Client Span but got %s", put.Kind)
+	}
+	expected := map[string]string{
+		"http.request.method": http.MethodPut,
+		"url.path":            "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example",
+		"azure.api_version":   "2020-06-01",
+		string(tracing.AttributeCorrelationRequestId): "22222222-2222-2222-2222-222222222222",
+		string(tracing.AttributeRequestId):            "11111111-1111-1111-1111-111111111111",
+	}
+	for k, expectedValue := range expected {
+		if v := attributeValue(put, k); v == nil || v.GetStringValue() != expectedValue {
+			t.Fatalf("expected the attribute %q of the PUT Span to be %q but got %+v", k, expectedValue, v)
+		}
+	}
+	if v := attributeValue(put, "http.response.status_code"); v == nil || v.GetIntValue() != http.StatusOK {
+		t.Fatalf("expected the PUT Span to have the status code 200 but got %+v", v)
+	}
+
+	del := c.span("azurerm_resource_group Delete")
+	if del == nil {
+		t.Fatalf("expected a Span for the Delete operation but got none")
+	}
+	if del.Status.Code != tracepb.Status_STATUS_CODE_ERROR {
+		t.Fatalf("expected the Delete Span to have the status Error but got %s", del.Status.Code)
+	}
+	deleteRequest := c.span("HTTP DELETE")
+	if deleteRequest == nil {
+		t.Fatalf("expected a Span for the DELETE request but got none")
+	}
+	if string(deleteRequest.ParentSpanId) != string(del.SpanId) {
+		t.Fatalf("expected the Span for the DELETE request to be a child of the Delete Span")
+	}
+	if deleteRequest.Status.Code != tracepb.Status_STATUS_CODE_ERROR {
+		t.Fatalf("expected the DELETE Span to have the status Error but got %s", deleteRequest.Status.Code)
+	}
+
+	if v := c.headers.Get("Authorization"); v != "Bearer example" {
+		t.Fatalf("expected the headers from `OTEL_EXPORTER_OTLP_HEADERS` to be sent but got %q", v)
+	}
+}
+
+func TestConfigure_Disabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	shutdown, err := tracing.Configure(context.Background())
+	if err != nil {
+		t.Fatalf("configuring tracing: %+v", err)
+	}
+
+	// spans should be no-ops when no endpoint is configured
+	_, span := tracing.StartOperation(context.Background(), "azurerm_resource_group", "Read", "")
+	if span.SpanContext().IsValid() {
+		t.Fatalf("expected the Span to be a no-op when tracing isn't configured")
+	}
+	tracing.EndOperation(span, "", nil)
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutting down tracing: %+v", err)
+	}
+}
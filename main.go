@@ -7,9 +7,14 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/hashicorp/terraform-provider-azurerm/internal/provider"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tf/pluginsdk"
+	"github.com/hashicorp/terraform-provider-azurerm/internal/tracing"
 )
 
 func main() {
@@ -21,18 +26,46 @@ func main() {
 	flag.BoolVar(&debugMode, "debuggable", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
+	// tracing is only enabled when an OTLP endpoint is configured via the `OTEL_EXPORTER_OTLP_*` environment variables
+	shutdownTracing, err := tracing.Configure(context.Background())
+	if err != nil {
+		log.Printf("[WARN] configuring OpenTelemetry tracing: %+v", err)
+		shutdownTracing = nil
+	}
+
+	// NOTE: this is intentionally called explicitly (rather than deferred) prior to each exit path, since
+	// deferred functions aren't run when the process exits via `os.Exit` or `log.Fatal`
+	flushTracing := func() {
+		if shutdownTracing == nil {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+			log.Printf("[WARN] flushing OpenTelemetry traces: %+v", shutdownErr)
+		}
+	}
+
+	// the gRPC Provider Server is wrapped so that Warnings can be surfaced during the plan
+	grpcProviderFunc := func() tfprotov5.ProviderServer {
+		return pluginsdk.WithPlanWarnings(schema.NewGRPCProviderServer(provider.AzureProvider()))
+	}
+
 	if debugMode {
 		//nolint:staticcheck
-		err := plugin.Debug(context.Background(), "registry.terraform.io/hashicorp/azurerm",
+		err = plugin.Debug(context.Background(), "registry.terraform.io/hashicorp/azurerm",
 			&plugin.ServeOpts{
-				ProviderFunc: provider.AzureProvider,
+				GRPCProviderFunc: grpcProviderFunc,
 			})
+		flushTracing()
 		if err != nil {
 			log.Println(err.Error())
 		}
 	} else {
 		plugin.Serve(&plugin.ServeOpts{
-			ProviderFunc: provider.AzureProvider,
+			GRPCProviderFunc: grpcProviderFunc,
 		})
+		flushTracing()
 	}
 }